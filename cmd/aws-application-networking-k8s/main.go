@@ -82,7 +82,11 @@ func addOptionalCRDs(scheme *runtime.Scheme) {
 		&anv1alpha1.TargetGroupPolicy{}, &anv1alpha1.TargetGroupPolicyList{},
 		&anv1alpha1.AccessLogPolicy{}, &anv1alpha1.AccessLogPolicyList{},
 		&anv1alpha1.VpcAssociationPolicy{}, &anv1alpha1.VpcAssociationPolicyList{},
-		&anv1alpha1.IAMAuthPolicy{}, &anv1alpha1.IAMAuthPolicyList{})
+		&anv1alpha1.IAMAuthPolicy{}, &anv1alpha1.IAMAuthPolicyList{},
+		&anv1alpha1.LatticeFixedResponse{}, &anv1alpha1.LatticeFixedResponseList{},
+		&anv1alpha1.LatticeRollout{}, &anv1alpha1.LatticeRolloutList{},
+		&anv1alpha1.LambdaFunction{}, &anv1alpha1.LambdaFunctionList{},
+		&anv1alpha1.LatticeTargetGroup{}, &anv1alpha1.LatticeTargetGroupList{})
 
 	metav1.AddToGroupVersion(scheme, groupVersion)
 }
@@ -146,7 +150,7 @@ func main() {
 	// parent logging scope for all controllers
 	ctrlLog := log.Named("controller")
 
-	err = controllers.RegisterPodController(ctrlLog.Named("pod"), mgr)
+	err = controllers.RegisterPodController(ctrlLog.Named("pod"), cloud, latticeDataStore, finalizerManager, mgr)
 	if err != nil {
 		setupLog.Fatalf("pod controller setup failed: %s", err)
 	}
@@ -186,7 +190,22 @@ func main() {
 		setupLog.Fatalf("accesslogpolicy controller setup failed: %s", err)
 	}
 
-	err = controllers.RegisterIAMAuthPolicyController(ctrlLog.Named("iam-auth-policy"), mgr)
+	err = controllers.RegisterLatticeRolloutController(ctrlLog.Named("lattice-rollout"), cloud, latticeDataStore, mgr)
+	if err != nil {
+		setupLog.Fatalf("lattice rollout controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterTargetGroupPolicyController(ctrlLog.Named("target-group-policy"), mgr)
+	if err != nil {
+		setupLog.Fatalf("target group policy controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterVpcAssociationPolicyController(ctrlLog.Named("vpc-association-policy"), mgr)
+	if err != nil {
+		setupLog.Fatalf("vpc association policy controller setup failed: %s", err)
+	}
+
+	err = controllers.RegisterIAMAuthPolicyController(ctrlLog.Named("iam-auth-policy"), cloud, finalizerManager, mgr)
 	if err != nil {
 		setupLog.Fatalf("iam auth policy controller setup failed: %s", err)
 	}
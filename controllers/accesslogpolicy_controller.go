@@ -18,19 +18,22 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	pkg_builder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
@@ -44,7 +47,6 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
-	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
@@ -52,6 +54,13 @@ const (
 	accessLogPolicyFinalizer = "accesslogpolicy.k8s.aws/resources"
 )
 
+var accessLogPolicyType = policyType{
+	newPolicy:     func() policyObject { return &anv1alpha1.AccessLogPolicy{} },
+	newPolicyList: func() policyObjectList { return &anv1alpha1.AccessLogPolicyList{} },
+	targetKinds:   []policyTargetKind{gatewayTargetKind, httpRouteTargetKind, grpcRouteTargetKind},
+	validate:      validateAccessLogPolicy,
+}
+
 type accessLogPolicyReconciler struct {
 	log              gwlog.Logger
 	client           client.Client
@@ -90,10 +99,72 @@ func RegisterAccessLogPolicyController(
 		stackMarshaller:  stackMarshaller,
 	}
 
+	mapper := &policyMapper{log: log, client: mgrClient, policyType: accessLogPolicyType}
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&anv1alpha1.AccessLogPolicy{}, pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
 
-	return builder.Complete(r)
+	// policies with the Services scope subscribe the routes attached to their Gateway, which the route controllers
+	// record in the status of the routes once their lattice services are deployed
+	mapToServicesScopePolicies := handler.EnqueueRequestsFromMapFunc(mapRouteToServicesScopePolicies(mapper))
+	builder.
+		Watches(&source.Kind{Type: &gwv1beta1.HTTPRoute{}}, mapToServicesScopePolicies).
+		Watches(&source.Kind{Type: &gwv1alpha2.GRPCRoute{}}, mapToServicesScopePolicies)
+
+	// TLSRoute is in the experimental channel of the Gateway API, its CRD is not always installed
+	if ok, err := k8s.IsGVKSupported(mgr, gwv1alpha2.GroupVersion.String(), "TLSRoute"); ok {
+		builder.Watches(&source.Kind{Type: &gwv1alpha2.TLSRoute{}}, mapToServicesScopePolicies)
+	} else if err != nil {
+		return err
+	}
+
+	return mapper.watchTargets(builder, targetCreatedOrDeletedPredicate).Complete(r)
+}
+
+// mapRouteToServicesScopePolicies returns a map function enqueueing the policies with the Services scope of the
+// Gateways a route refers to, or which are still recorded as its parents in its status. The route controllers remove
+// the parents a route detached from only once its lattice services are updated, after the parentRef is gone, so
+// detaching routes are enqueued again when their status no longer lists the Gateway.
+func mapRouteToServicesScopePolicies(m *policyMapper) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		route, err := core.NewRoute(obj)
+		if err != nil {
+			return nil
+		}
+		var gwNames []types.NamespacedName
+		addGateway := func(parentRef gwv1beta1.ParentReference) {
+			if gwName, ok := gateway.ParentRefGatewayName(route, parentRef); ok && !slices.Contains(gwNames, gwName) {
+				gwNames = append(gwNames, gwName)
+			}
+		}
+		for _, parentRef := range route.Spec().ParentRefs() {
+			addGateway(parentRef)
+		}
+		for _, parent := range route.Status().Parents() {
+			if parent.ControllerName == config.LatticeGatewayControllerName {
+				addGateway(parent.ParentRef)
+			}
+		}
+
+		var requests []reconcile.Request
+		for _, gwName := range gwNames {
+			policies, err := m.listPolicies(gwName.Namespace)
+			if err != nil {
+				return requests
+			}
+			for _, p := range policies {
+				alp := p.(*anv1alpha1.AccessLogPolicy)
+				if alp.GetScope() != anv1alpha1.AccessLogPolicyScopeServices ||
+					!gateway.IsPolicyAttachedTo(alp, gwv1beta1.GroupName, "Gateway", gwName) {
+					continue
+				}
+				request := reconcile.Request{NamespacedName: alp.GetNamespacedName()}
+				if !slices.Contains(requests, request) {
+					requests = append(requests, request)
+				}
+			}
+		}
+		return requests
+	}
 }
 
 func (r *accessLogPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -116,24 +187,6 @@ func (r *accessLogPolicyReconciler) reconcile(ctx context.Context, req ctrl.Requ
 		return client.IgnoreNotFound(err)
 	}
 
-	if alp.Spec.TargetRef.Group != gwv1beta1.GroupName {
-		message := "The targetRef's Group must be " + gwv1beta1.GroupName
-		err := r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-
-	if !slices.Contains([]string{"Gateway", "HTTPRoute", "GRPCRoute"}, string(alp.Spec.TargetRef.Kind)) {
-		message := "The targetRef's Kind must be Gateway, HTTPRoute, or GRPCRoute"
-		err := r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
-		if err != nil {
-			return err
-		}
-		return nil
-	}
-
 	if !alp.DeletionTimestamp.IsZero() {
 		return r.reconcileDelete(ctx, alp)
 	} else {
@@ -142,12 +195,14 @@ func (r *accessLogPolicyReconciler) reconcile(ctx context.Context, req ctrl.Requ
 }
 
 func (r *accessLogPolicyReconciler) reconcileDelete(ctx context.Context, alp *anv1alpha1.AccessLogPolicy) error {
-	_, err := r.buildAndDeployModel(ctx, alp)
-	if err != nil {
-		return err
+	// the Access Log Subscriptions of a policy are on its targetRef, policies without a valid one have none
+	if validatePolicyTargetRef(alp, accessLogPolicyType.targetKinds) == "" {
+		if _, err := r.buildAndDeployModel(ctx, alp); err != nil {
+			return err
+		}
 	}
 
-	err = r.finalizerManager.RemoveFinalizers(ctx, alp, accessLogPolicyFinalizer)
+	err := r.finalizerManager.RemoveFinalizers(ctx, alp, accessLogPolicyFinalizer)
 	if err != nil {
 		return err
 	}
@@ -162,78 +217,71 @@ func (r *accessLogPolicyReconciler) reconcileUpsert(ctx context.Context, alp *an
 		return err
 	}
 
-	if alp.Spec.TargetRef.Namespace != nil && string(*alp.Spec.TargetRef.Namespace) != alp.Namespace {
-		message := "The targetRef's namespace does not match the access log policy's namespace"
-		return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
-	}
-
-	targetRefExists, err := r.targetRefExists(ctx, alp)
+	reason, message, err := acceptPolicy(ctx, r.client, alp, accessLogPolicyType)
 	if err != nil {
 		return err
 	}
-	if !targetRefExists {
-		message := "The targetRef could not be found"
-		return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonTargetNotFound, message)
+	if reason != gwv1alpha2.PolicyReasonAccepted {
+		return updatePolicyStatus(ctx, r.client, alp, reason, false, message)
 	}
 
-	stack, err := r.buildAndDeployModel(ctx, alp)
-	if err != nil {
+	stack, deployErr := r.buildAndDeployModel(ctx, alp)
+	if stack != nil {
+		if err := setAccessLogPolicyDestinations(alp, stack); err != nil {
+			return err
+		}
+	}
+	if err := deployErr; err != nil {
 		if services.IsConflictError(err) {
-			message := "An Access Log Policy with a Destination Arn for the same destination type already exists for this targetRef"
-			return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonConflicted, message)
+			message := "An Access Log Subscription with a destination of the same type already exists for this targetRef"
+			conflictErr := &services.ConflictError{}
+			if errors.As(err, &conflictErr) && conflictErr.ResourceType == string(model.ServiceSourceType) &&
+				alp.GetScope() == anv1alpha1.AccessLogPolicyScopeServices {
+				message = fmt.Sprintf("An Access Log Subscription with a destination of the same type already exists "+
+					"for the service %s of a route attached to this targetRef", conflictErr.Name)
+			}
+			return updatePolicyStatus(ctx, r.client, alp, gwv1alpha2.PolicyReasonConflicted, false, message)
 		} else if services.IsInvalidError(err) {
 			message := "The AWS resource with the provided Destination Arn could not be found"
-			return r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonInvalid, message)
+			return updatePolicyStatus(ctx, r.client, alp, gwv1alpha2.PolicyReasonInvalid, false, message)
 		}
 		return err
 	}
 
-	err = r.updateAccessLogPolicyAnnotations(ctx, alp, stack)
+	err = updatePolicyStatus(ctx, r.client, alp, gwv1alpha2.PolicyReasonAccepted, true, config.LatticeGatewayControllerName)
 	if err != nil {
 		return err
 	}
 
-	err = r.updateAccessLogPolicyStatus(ctx, alp, gwv1alpha2.PolicyReasonAccepted, config.LatticeGatewayControllerName)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return r.removeAccessLogSubscriptionAnnotation(ctx, alp)
 }
 
-func (r *accessLogPolicyReconciler) targetRefExists(ctx context.Context, alp *anv1alpha1.AccessLogPolicy) (bool, error) {
-	targetRefNamespace := alp.Namespace
-	if alp.Spec.TargetRef.Namespace != nil {
-		targetRefNamespace = string(*alp.Spec.TargetRef.Namespace)
+// validateAccessLogPolicy returns a non-empty message describing why the destinations of the policy are invalid,
+// before any call to VPC Lattice
+func validateAccessLogPolicy(policy policyObject) string {
+	alp := policy.(*anv1alpha1.AccessLogPolicy)
+	if alp.GetScope() == anv1alpha1.AccessLogPolicyScopeServices && alp.Spec.TargetRef.Kind != gatewayTargetKind.kind {
+		return fmt.Sprintf("The scope %s is only supported for Kind %s", anv1alpha1.AccessLogPolicyScopeServices,
+			gatewayTargetKind.kind)
 	}
-
-	targetRefNamespacedName := types.NamespacedName{
-		Name:      string(alp.Spec.TargetRef.Name),
-		Namespace: targetRefNamespace,
-	}
-
-	var err error
-
-	switch alp.Spec.TargetRef.Kind {
-	case "Gateway":
-		gw := &gwv1beta1.Gateway{}
-		err = r.client.Get(ctx, targetRefNamespacedName, gw)
-	case "HTTPRoute":
-		httpRoute := &gwv1beta1.HTTPRoute{}
-		err = r.client.Get(ctx, targetRefNamespacedName, httpRoute)
-	case "GRPCRoute":
-		grpcRoute := &gwv1alpha2.GRPCRoute{}
-		err = r.client.Get(ctx, targetRefNamespacedName, grpcRoute)
-	default:
-		return false, fmt.Errorf("access Log Policy targetRef is for an unsupported Kind: %s",
-			alp.Spec.TargetRef.Kind)
+	destinationArns := alp.GetDestinationArns()
+	if len(destinationArns) == 0 {
+		return "The policy must have a destinationArn or destinationArns"
 	}
-
-	if err != nil && !errors.IsNotFound(err) {
-		return false, err
+	destinationTypes := make(map[model.AccessLogDestinationType]string)
+	for _, destinationArn := range destinationArns {
+		destinationType, err := model.GetAccessLogDestinationType(destinationArn)
+		if err != nil {
+			return fmt.Sprintf("The destination ARN %s is not the ARN of an S3 bucket, "+
+				"a CloudWatch Logs log group or a Firehose delivery stream", destinationArn)
+		}
+		if other, ok := destinationTypes[destinationType]; ok {
+			return fmt.Sprintf("The destinations %s and %s are of the same type %s, "+
+				"VPC Lattice supports one destination of each type", other, destinationArn, destinationType)
+		}
+		destinationTypes[destinationType] = destinationArn
 	}
-
-	return err == nil, nil
+	return ""
 }
 
 func (r *accessLogPolicyReconciler) buildAndDeployModel(
@@ -254,64 +302,68 @@ func (r *accessLogPolicyReconciler) buildAndDeployModel(
 	r.log.Debugw("Successfully built model", "stack", jsonStack)
 
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
-		return nil, err
+		// the stack has the Access Log Subscriptions deployed before the error
+		return stack, err
 	}
 	r.log.Debugf("successfully deployed model for stack %s:%s", stack.StackID().Name, stack.StackID().Namespace)
 
 	return stack, nil
 }
 
-func (r *accessLogPolicyReconciler) updateAccessLogPolicyAnnotations(
-	ctx context.Context,
-	alp *anv1alpha1.AccessLogPolicy,
-	stack core.Stack,
-) error {
+// setAccessLogPolicyDestinations sets the destinations of the status of alp to the Access Log Subscriptions of stack
+// which exist, to be recorded along with its status conditions. The ones of services are the ones of the routes
+// attached to the Gateway of the policy, the others are the ones of its targetRef.
+func setAccessLogPolicyDestinations(alp *anv1alpha1.AccessLogPolicy, stack core.Stack) error {
 	var accessLogSubscriptions []*model.AccessLogSubscription
 	err := stack.ListResources(&accessLogSubscriptions)
 	if err != nil {
 		return err
 	}
 
+	var destinations []anv1alpha1.AccessLogDestinationStatus
 	for _, als := range accessLogSubscriptions {
-		if als.Spec.EventType != core.DeleteEvent {
-			oldAlp := alp.DeepCopy()
-			if alp.ObjectMeta.Annotations == nil {
-				alp.ObjectMeta.Annotations = make(map[string]string)
-			}
-			alp.ObjectMeta.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey] = als.Status.Arn
-			if err := r.client.Patch(ctx, alp, client.MergeFrom(oldAlp)); err != nil {
-				return fmt.Errorf("failed to add annotation to Access Log Policy %s-%s, %w",
-					alp.Name, alp.Namespace, err)
-			}
+		if als.Status == nil {
+			continue
+		}
+		i := slices.IndexFunc(destinations, func(d anv1alpha1.AccessLogDestinationStatus) bool {
+			return d.DestinationArn == als.Spec.DestinationArn
+		})
+		if i < 0 {
+			destinations = append(destinations, anv1alpha1.AccessLogDestinationStatus{DestinationArn: als.Spec.DestinationArn})
+			i = len(destinations) - 1
+		}
+		if alp.Spec.TargetRef.Kind == gatewayTargetKind.kind && als.Spec.SourceType == model.ServiceSourceType {
+			destinations[i].Services = append(destinations[i].Services, anv1alpha1.AccessLogServiceStatus{
+				ServiceName:              als.Spec.SourceName,
+				AccessLogSubscriptionArn: als.Status.Arn,
+			})
+		} else {
+			destinations[i].AccessLogSubscriptionArn = als.Status.Arn
 		}
 	}
-
+	slices.SortFunc(destinations, func(a, b anv1alpha1.AccessLogDestinationStatus) int {
+		return strings.Compare(a.DestinationArn, b.DestinationArn)
+	})
+	for _, destination := range destinations {
+		slices.SortFunc(destination.Services, func(a, b anv1alpha1.AccessLogServiceStatus) int {
+			return strings.Compare(a.ServiceName, b.ServiceName)
+		})
+	}
+	alp.Status.Destinations = destinations
 	return nil
 }
 
-func (r *accessLogPolicyReconciler) updateAccessLogPolicyStatus(
-	ctx context.Context,
-	alp *anv1alpha1.AccessLogPolicy,
-	reason gwv1alpha2.PolicyConditionReason,
-	message string,
-) error {
-	status := metav1.ConditionTrue
-	if reason != gwv1alpha2.PolicyReasonAccepted {
-		status = metav1.ConditionFalse
+// removeAccessLogSubscriptionAnnotation removes the annotation of policies annotated by earlier versions, once
+// their status records their Access Log Subscriptions
+func (r *accessLogPolicyReconciler) removeAccessLogSubscriptionAnnotation(ctx context.Context, alp *anv1alpha1.AccessLogPolicy) error {
+	if _, ok := alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]; !ok {
+		return nil
 	}
-
-	alp.Status.Conditions = utils.GetNewConditions(alp.Status.Conditions, metav1.Condition{
-		Type:               string(gwv1alpha2.PolicyConditionAccepted),
-		ObservedGeneration: alp.Generation,
-		Message:            message,
-		Status:             status,
-		Reason:             string(reason),
-	})
-
-	if err := r.client.Status().Update(ctx, alp); err != nil {
-		return fmt.Errorf("failed to set Access Log Policy Accepted status to %s and reason to %s, %w",
-			status, reason, err)
+	oldAlp := alp.DeepCopy()
+	delete(alp.Annotations, anv1alpha1.AccessLogSubscriptionAnnotationKey)
+	if err := r.client.Patch(ctx, alp, client.MergeFrom(oldAlp)); err != nil {
+		return fmt.Errorf("failed to remove annotation of Access Log Policy %s-%s, %w",
+			alp.Name, alp.Namespace, err)
 	}
-
 	return nil
 }
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_mapRouteToServicesScopePolicies(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+
+	servicesScope := anv1alpha1.AccessLogPolicyScopeServices
+	newPolicy := func(name string, gwName string, scope *anv1alpha1.AccessLogPolicyScope) *anv1alpha1.AccessLogPolicy {
+		return &anv1alpha1.AccessLogPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: anv1alpha1.AccessLogPolicySpec{
+				Scope: scope,
+				TargetRef: &gwv1alpha2.PolicyTargetReference{
+					Group: gwv1beta1.GroupName,
+					Kind:  "Gateway",
+					Name:  gwv1alpha2.ObjectName(gwName),
+				},
+			},
+		}
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(
+		newPolicy("attached", "attached-gw", &servicesScope),
+		newPolicy("detached", "detached-gw", &servicesScope),
+		newPolicy("service-network", "attached-gw", nil),
+		newPolicy("other-controller", "other-gw", &servicesScope),
+	).Build()
+	mapper := &policyMapper{log: gwlog.FallbackLogger, client: k8sClient, policyType: accessLogPolicyType}
+
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "attached-gw"}},
+			},
+		},
+		Status: gwv1beta1.HTTPRouteStatus{
+			RouteStatus: gwv1beta1.RouteStatus{
+				Parents: []gwv1beta1.RouteParentStatus{
+					{
+						ParentRef:      gwv1beta1.ParentReference{Name: "attached-gw"},
+						ControllerName: config.LatticeGatewayControllerName,
+					},
+					// the route detached from this Gateway, the route controller did not update the status yet
+					{
+						ParentRef:      gwv1beta1.ParentReference{Name: "detached-gw"},
+						ControllerName: config.LatticeGatewayControllerName,
+					},
+					{
+						ParentRef:      gwv1beta1.ParentReference{Name: "other-gw"},
+						ControllerName: "example.com/other-controller",
+					},
+				},
+			},
+		},
+	}
+
+	requests := mapRouteToServicesScopePolicies(mapper)(route)
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "attached"}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: "detached"}},
+	}, requests)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
@@ -17,8 +18,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	gateway_api "sigs.k8s.io/gateway-api/apis/v1beta1"
-
-	"github.com/aws/aws-application-networking-k8s/pkg/config"
 )
 
 type enqueueRequestsForGatewayEvent struct {
@@ -74,41 +73,13 @@ func (h *enqueueRequestsForGatewayEvent) enqueueImpactedRoutes(queue workqueue.R
 	}
 
 	for _, route := range routes {
-		if len(route.Spec().ParentRefs()) <= 0 {
-			h.log.Debugf("Ignoring Route with no parentRef %s-%s", route.Name(), route.Namespace())
-			continue
-		}
-
-		// find the parent gw object
-		var gwNamespace = route.Namespace()
-		if route.Spec().ParentRefs()[0].Namespace != nil {
-			gwNamespace = string(*route.Spec().ParentRefs()[0].Namespace)
-		}
-
-		gwName := types.NamespacedName{
-			Namespace: gwNamespace,
-			Name:      string(route.Spec().ParentRefs()[0].Name),
-		}
-
-		gw := &gateway_api.Gateway{}
-		if err := h.client.Get(context.TODO(), gwName, gw); err != nil {
-			h.log.Debugf("Ignoring Route with unknown parentRef %s-%s", route.Name(), route.Namespace())
-			continue
-		}
-
-		// find the parent gateway class name
-		gwClass := &gateway_api.GatewayClass{}
-		gwClassName := types.NamespacedName{
-			Namespace: "default",
-			Name:      string(gw.Spec.GatewayClassName),
-		}
-
-		if err := h.client.Get(context.TODO(), gwClassName, gwClass); err != nil {
-			h.log.Debugf("Ignoring Route with unknown Gateway %s-%s", route.Name(), route.Namespace())
+		parents, err := gateway.GetRouteParents(context.TODO(), h.client, route)
+		if err != nil {
+			h.log.Debugf("Ignoring Route %s-%s with unresolved parentRefs, %s", route.Name(), route.Namespace(), err)
 			continue
 		}
 
-		if gwClass.Spec.ControllerName == config.LatticeGatewayControllerName {
+		if len(parents) > 0 {
 			h.log.Debugf("Adding Route %s-%s to queue due to Gateway event", route.Name(), route.Namespace())
 			queue.Add(reconcile.Request{
 				NamespacedName: types.NamespacedName{
@@ -0,0 +1,52 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type lambdaFunctionEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewLambdaFunctionEventHandler(log gwlog.Logger, client client.Client) *lambdaFunctionEventHandler {
+	return &lambdaFunctionEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+func (h *lambdaFunctionEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return h.mapToRoute(obj, routeType)
+	})
+}
+
+func (h *lambdaFunctionEventHandler) mapToRoute(obj client.Object, routeType core.RouteType) []reconcile.Request {
+	ctx := context.Background()
+	lambdaFunction, ok := obj.(*v1alpha1.LambdaFunction)
+	if !ok {
+		return nil
+	}
+	routes := h.mapper.LambdaFunctionToRoutes(ctx, lambdaFunction, routeType)
+
+	var requests []reconcile.Request
+	for _, route := range routes {
+		routeName := k8s.NamespacedName(route.K8sObject())
+		requests = append(requests, reconcile.Request{NamespacedName: routeName})
+		h.log.Infow("LambdaFunction change triggered Route update",
+			"lambdaFunctionName", obj.GetNamespace()+"/"+obj.GetName(), "routeName", routeName, "routeType", routeType)
+	}
+	return requests
+}
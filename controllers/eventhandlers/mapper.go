@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -13,6 +14,7 @@ import (
 	mcs_api "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -27,6 +29,10 @@ const (
 	serviceKind       = "Service"
 	serviceImportKind = "ServiceImport"
 	gatewayKind       = "Gateway"
+	namespaceKind     = "Namespace"
+	serviceExportKind = "ServiceExport"
+	httpRouteKind     = "HTTPRoute"
+	grpcRouteKind     = "GRPCRoute"
 )
 
 func (r *resourceMapper) ServiceToRoutes(ctx context.Context, svc *corev1.Service, routeType core.RouteType) []core.Route {
@@ -54,12 +60,37 @@ func (r *resourceMapper) ServiceToServiceExport(ctx context.Context, svc *corev1
 	return svcExport
 }
 
-func (r *resourceMapper) EndpointsToService(ctx context.Context, ep *corev1.Endpoints) *corev1.Service {
-	if ep == nil {
+func (r *resourceMapper) ServiceToPods(ctx context.Context, svc *corev1.Service) []*corev1.Pod {
+	if svc == nil || len(svc.Spec.Selector) == 0 {
+		return nil
+	}
+	podList := &corev1.PodList{}
+	if err := r.client.List(ctx, podList, client.InNamespace(svc.Namespace),
+		client.MatchingLabels(svc.Spec.Selector)); err != nil {
+		r.log.Errorf("Failed to list pods of service %s-%s, %s", svc.Name, svc.Namespace, err)
+		return nil
+	}
+	var pods []*corev1.Pod
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+	return pods
+}
+
+func (r *resourceMapper) EndpointSliceToService(ctx context.Context, slice *discoveryv1.EndpointSlice) *corev1.Service {
+	if slice == nil {
+		return nil
+	}
+	svcName, ok := slice.Labels[discoveryv1.LabelServiceName]
+	if !ok {
 		return nil
 	}
 	svc := &corev1.Service{}
-	if err := r.client.Get(ctx, k8s.NamespacedName(ep), svc); err != nil {
+	key := types.NamespacedName{
+		Namespace: slice.Namespace,
+		Name:      svcName,
+	}
+	if err := r.client.Get(ctx, key, svc); err != nil {
 		return nil
 	}
 	return svc
@@ -69,6 +100,93 @@ func (r *resourceMapper) TargetGroupPolicyToService(ctx context.Context, tgp *v1
 	return policyToTargetRefObj(r, ctx, tgp, &corev1.Service{})
 }
 
+// TargetGroupPolicyToServices returns the Services tgp applies to: the Service it is attached to, the Services of
+// the Namespace it is attached to, the Services behind the routes of the Gateway it is attached to, or the Service
+// of the ServiceExport or of the route backend it is attached to
+func (r *resourceMapper) TargetGroupPolicyToServices(ctx context.Context, tgp *v1alpha1.TargetGroupPolicy) []*corev1.Service {
+	targetRef := tgp.Spec.TargetRef
+	if targetRef == nil {
+		return nil
+	}
+	switch {
+	case targetRef.Group == corev1.GroupName && targetRef.Kind == serviceKind:
+		if svc := r.TargetGroupPolicyToService(ctx, tgp); svc != nil {
+			return []*corev1.Service{svc}
+		}
+	case targetRef.Group == corev1.GroupName && targetRef.Kind == namespaceKind:
+		if string(targetRef.Name) != tgp.Namespace {
+			return nil
+		}
+		svcList := &corev1.ServiceList{}
+		if err := r.client.List(ctx, svcList, client.InNamespace(tgp.Namespace)); err != nil {
+			r.log.Errorf("Failed to list services of namespace %s, %s", tgp.Namespace, err)
+			return nil
+		}
+		var services []*corev1.Service
+		for i := range svcList.Items {
+			services = append(services, &svcList.Items[i])
+		}
+		return services
+	case targetRef.Group == gateway_api.GroupName && targetRef.Kind == gatewayKind:
+		if gw := policyToTargetRefObj(r, ctx, tgp, &gateway_api.Gateway{}); gw != nil {
+			return r.gatewayToServices(ctx, gw)
+		}
+	case targetRef.Group == mcs_api.GroupName && targetRef.Kind == serviceExportKind:
+		if export := policyToTargetRefObj(r, ctx, tgp, &mcs_api.ServiceExport{}); export != nil {
+			if svc := r.getService(ctx, k8s.NamespacedName(export)); svc != nil {
+				return []*corev1.Service{svc}
+			}
+		}
+	case targetRef.Group == gateway_api.GroupName && targetRef.Kind == httpRouteKind:
+		if route := policyToTargetRefObj(r, ctx, tgp, &gateway_api.HTTPRoute{}); route != nil {
+			return r.routeBackendToServices(ctx, tgp, core.NewHTTPRoute(*route))
+		}
+	case targetRef.Group == gateway_api.GroupName && targetRef.Kind == grpcRouteKind:
+		if route := policyToTargetRefObj(r, ctx, tgp, &gateway_api_v1alpha2.GRPCRoute{}); route != nil {
+			return r.routeBackendToServices(ctx, tgp, core.NewGRPCRoute(*route))
+		}
+	}
+	return nil
+}
+
+// routeBackendToServices returns the Service backend of route named by the sectionName of the targetRef of tgp
+func (r *resourceMapper) routeBackendToServices(ctx context.Context, tgp *v1alpha1.TargetGroupPolicy, route core.Route) []*corev1.Service {
+	backend, ok := gateway.GetTargetGroupPolicyRouteBackend(tgp, route)
+	if !ok {
+		return nil
+	}
+	if svc := r.getService(ctx, backend); svc != nil {
+		return []*corev1.Service{svc}
+	}
+	return nil
+}
+
+func (r *resourceMapper) getService(ctx context.Context, svcName types.NamespacedName) *corev1.Service {
+	svc := &corev1.Service{}
+	if err := r.client.Get(ctx, svcName, svc); err != nil {
+		return nil
+	}
+	return svc
+}
+
+// InstanceTargetServices returns the Services whose TargetGroupPolicy registers nodes as targets,
+// which need their targets rebuilt whenever nodes change
+func (r *resourceMapper) InstanceTargetServices(ctx context.Context) []*corev1.Service {
+	tgpList := &v1alpha1.TargetGroupPolicyList{}
+	if err := r.client.List(ctx, tgpList); err != nil {
+		r.log.Errorf("Failed to list TargetGroupPolicies, %s", err)
+		return nil
+	}
+	var services []*corev1.Service
+	for _, tgp := range tgpList.Items {
+		if tgp.Spec.TargetType == nil || *tgp.Spec.TargetType != v1alpha1.TargetTypeInstance {
+			continue
+		}
+		services = append(services, r.TargetGroupPolicyToServices(ctx, &tgp)...)
+	}
+	return services
+}
+
 func (r *resourceMapper) VpcAssociationPolicyToGateway(ctx context.Context, vap *v1alpha1.VpcAssociationPolicy) *gateway_api.Gateway {
 	return policyToTargetRefObj(r, ctx, vap, &gateway_api.Gateway{})
 }
@@ -138,15 +256,73 @@ func k8sResourceTypeToGroupAndKind(obj client.Object) (gateway_api.Group, gatewa
 		return corev1.GroupName, serviceKind, nil
 	case *gateway_api.Gateway:
 		return gateway_api.GroupName, gatewayKind, nil
+	case *gateway_api.HTTPRoute:
+		return gateway_api.GroupName, httpRouteKind, nil
+	case *gateway_api_v1alpha2.GRPCRoute:
+		return gateway_api.GroupName, grpcRouteKind, nil
+	case *mcs_api.ServiceExport:
+		return mcs_api.GroupName, serviceExportKind, nil
 	default:
 		return "", "", fmt.Errorf("un-registered obj type: %T", obj)
 	}
 }
 
-func (r *resourceMapper) backendRefToRoutes(ctx context.Context, obj client.Object, group, kind string, routeType core.RouteType) []core.Route {
-	if obj == nil {
+func (r *resourceMapper) FixedResponseToRoutes(ctx context.Context, fixedResponse *v1alpha1.LatticeFixedResponse, routeType core.RouteType) []core.Route {
+	if fixedResponse == nil {
+		return nil
+	}
+	var filteredRoutes []core.Route
+	for _, route := range r.listRoutes(ctx, routeType) {
+		if r.isExtensionRefUsedByRoute(route, fixedResponse, v1alpha1.GroupName, v1alpha1.LatticeFixedResponseKind) {
+			filteredRoutes = append(filteredRoutes, route)
+		}
+	}
+	return filteredRoutes
+}
+
+func (r *resourceMapper) LambdaFunctionToRoutes(ctx context.Context, lambdaFunction *v1alpha1.LambdaFunction, routeType core.RouteType) []core.Route {
+	if lambdaFunction == nil {
+		return nil
+	}
+	return r.backendRefToRoutes(ctx, lambdaFunction, v1alpha1.GroupName, v1alpha1.LambdaFunctionKind, routeType)
+}
+
+func (r *resourceMapper) LatticeTargetGroupToRoutes(ctx context.Context, latticeTargetGroup *v1alpha1.LatticeTargetGroup, routeType core.RouteType) []core.Route {
+	if latticeTargetGroup == nil {
+		return nil
+	}
+	return r.backendRefToRoutes(ctx, latticeTargetGroup, v1alpha1.GroupName, v1alpha1.LatticeTargetGroupKind, routeType)
+}
+
+// ReferenceGrantToRoutes returns the routes the grant may permit or refuse references from, which are routes
+// in a namespace the grant permits references from with a backendRef to the namespace of the grant
+func (r *resourceMapper) ReferenceGrantToRoutes(ctx context.Context, referenceGrant *gateway_api.ReferenceGrant, routeType core.RouteType) []core.Route {
+	if referenceGrant == nil {
+		return nil
+	}
+	var filteredRoutes []core.Route
+	for _, route := range r.listRoutes(ctx, routeType) {
+		if r.isReferenceGrantFromRoute(route, referenceGrant) && r.isBackendRefToNamespace(route, referenceGrant.Namespace) {
+			filteredRoutes = append(filteredRoutes, route)
+		}
+	}
+	return filteredRoutes
+}
+
+func (r *resourceMapper) NamespaceToRoutes(ctx context.Context, namespace *corev1.Namespace, routeType core.RouteType) []core.Route {
+	if namespace == nil {
 		return nil
 	}
+	var filteredRoutes []core.Route
+	for _, route := range r.listRoutes(ctx, routeType) {
+		if route.Namespace() == namespace.Name {
+			filteredRoutes = append(filteredRoutes, route)
+		}
+	}
+	return filteredRoutes
+}
+
+func (r *resourceMapper) listRoutes(ctx context.Context, routeType core.RouteType) []core.Route {
 	var routes []core.Route
 	switch routeType {
 	case core.HttpRouteType:
@@ -161,12 +337,70 @@ func (r *resourceMapper) backendRefToRoutes(ctx context.Context, obj client.Obje
 		for _, k8sRoute := range routeList.Items {
 			routes = append(routes, core.NewGRPCRoute(k8sRoute))
 		}
-	default:
+	case core.TlsRouteType:
+		routeList := &gateway_api_v1alpha2.TLSRouteList{}
+		r.client.List(ctx, routeList)
+		for _, k8sRoute := range routeList.Items {
+			routes = append(routes, core.NewTLSRoute(k8sRoute))
+		}
+	}
+	return routes
+}
+
+// gatewayToServices returns the Services which are backendRefs of the routes attached to gw
+func (r *resourceMapper) gatewayToServices(ctx context.Context, gw *gateway_api.Gateway) []*corev1.Service {
+	var services []*corev1.Service
+	seen := make(map[types.NamespacedName]struct{})
+	for _, routeType := range []core.RouteType{core.HttpRouteType, core.GrpcRouteType, core.TlsRouteType} {
+		for _, route := range r.listRoutes(ctx, routeType) {
+			if !r.isGatewayParentOfRoute(route, gw) {
+				continue
+			}
+			for _, rule := range route.Spec().Rules() {
+				for _, backendRef := range rule.BackendRefs() {
+					if backendRef.Kind() != nil && *backendRef.Kind() != serviceKind {
+						continue
+					}
+					key := types.NamespacedName{Namespace: route.Namespace(), Name: string(backendRef.Name())}
+					if backendRef.Namespace() != nil {
+						key.Namespace = string(*backendRef.Namespace())
+					}
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+					svc := &corev1.Service{}
+					if err := r.client.Get(ctx, key, svc); err != nil {
+						continue
+					}
+					services = append(services, svc)
+				}
+			}
+		}
+	}
+	return services
+}
+
+func (r *resourceMapper) isGatewayParentOfRoute(route core.Route, gw *gateway_api.Gateway) bool {
+	for _, parentRef := range route.Spec().ParentRefs() {
+		namespace := route.Namespace()
+		if parentRef.Namespace != nil {
+			namespace = string(*parentRef.Namespace)
+		}
+		if string(parentRef.Name) == gw.Name && namespace == gw.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resourceMapper) backendRefToRoutes(ctx context.Context, obj client.Object, group, kind string, routeType core.RouteType) []core.Route {
+	if obj == nil {
 		return nil
 	}
 
 	var filteredRoutes []core.Route
-	for _, route := range routes {
+	for _, route := range r.listRoutes(ctx, routeType) {
 		if r.isBackendRefUsedByRoute(route, obj, group, kind) {
 			filteredRoutes = append(filteredRoutes, route)
 		}
@@ -174,6 +408,22 @@ func (r *resourceMapper) backendRefToRoutes(ctx context.Context, obj client.Obje
 	return filteredRoutes
 }
 
+// isExtensionRefUsedByRoute checks the ExtensionRef filters of the route, which can only refer to objects in the route namespace
+func (r *resourceMapper) isExtensionRefUsedByRoute(route core.Route, obj k8s.NamespacedAndNamed, group, kind string) bool {
+	if route.Namespace() != obj.GetNamespace() {
+		return false
+	}
+	for _, rule := range route.Spec().Rules() {
+		for _, extensionRef := range rule.ExtensionRefs() {
+			if string(extensionRef.Group) == group && string(extensionRef.Kind) == kind &&
+				string(extensionRef.Name) == obj.GetName() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (r *resourceMapper) isBackendRefUsedByRoute(route core.Route, obj k8s.NamespacedAndNamed, group, kind string) bool {
 	for _, rule := range route.Spec().Rules() {
 		for _, backendRef := range rule.BackendRefs() {
@@ -194,3 +444,24 @@ func (r *resourceMapper) isBackendRefUsedByRoute(route core.Route, obj k8s.Names
 	}
 	return false
 }
+
+func (r *resourceMapper) isReferenceGrantFromRoute(route core.Route, referenceGrant *gateway_api.ReferenceGrant) bool {
+	for _, from := range referenceGrant.Spec.From {
+		if from.Group == gateway_api.GroupName && from.Kind == core.RouteKind(route) &&
+			string(from.Namespace) == route.Namespace() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *resourceMapper) isBackendRefToNamespace(route core.Route, namespace string) bool {
+	for _, rule := range route.Spec().Rules() {
+		for _, backendRef := range rule.BackendRefs() {
+			if backendRef.Namespace() != nil && string(*backendRef.Namespace()) == namespace {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -10,9 +10,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
 	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
@@ -183,11 +188,13 @@ func TestTargetGroupPolicyToService(t *testing.T) {
 					Namespace: tt.namespace,
 				},
 				Spec: anv1alpha1.TargetGroupPolicySpec{
-					TargetRef: &gwv1alpha2.PolicyTargetReference{
-						Group:     "",
-						Kind:      tt.targetKind,
-						Name:      "test-service",
-						Namespace: tt.targetNamespace,
+					TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+						PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+							Group:     "",
+							Kind:      tt.targetKind,
+							Name:      "test-service",
+							Namespace: tt.targetNamespace,
+						},
 					},
 				},
 			})
@@ -301,3 +308,276 @@ func TestVpcAssociationPolicyToGateway(t *testing.T) {
 		})
 	}
 }
+
+func TestFixedResponseToRoutes(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	createRoute := func(name, namespace string, extensionRef gwv1beta1.LocalObjectReference) gwv1beta1.HTTPRoute {
+		route := createHTTPRoute(name, namespace, gwv1beta1.BackendObjectReference{Name: "test-service"})
+		route.Spec.Rules[0].Filters = []gwv1beta1.HTTPRouteFilter{
+			{
+				Type:         gwv1beta1.HTTPRouteFilterExtensionRef,
+				ExtensionRef: &extensionRef,
+			},
+		}
+		return route
+	}
+	fixedResponseRef := gwv1beta1.LocalObjectReference{
+		Group: anv1alpha1.GroupName,
+		Kind:  anv1alpha1.LatticeFixedResponseKind,
+		Name:  "maintenance",
+	}
+
+	routes := []gwv1beta1.HTTPRoute{
+		createRoute("valid", "ns1", fixedResponseRef),
+		createRoute("invalid-different-namespace", "ns2", fixedResponseRef),
+		createRoute("invalid-different-name", "ns1", gwv1beta1.LocalObjectReference{
+			Group: anv1alpha1.GroupName,
+			Kind:  anv1alpha1.LatticeFixedResponseKind,
+			Name:  "blocked",
+		}),
+		createRoute("invalid-kind", "ns1", gwv1beta1.LocalObjectReference{
+			Group: anv1alpha1.GroupName,
+			Kind:  "NotLatticeFixedResponse",
+			Name:  "maintenance",
+		}),
+		createHTTPRoute("invalid-no-filter", "ns1", gwv1beta1.BackendObjectReference{Name: "test-service"}),
+	}
+
+	mockClient := mock_client.NewMockClient(c)
+	mockClient.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, routeList *gwv1beta1.HTTPRouteList, _ ...interface{}) error {
+			routeList.Items = append(routeList.Items, routes...)
+			return nil
+		},
+	)
+
+	mapper := &resourceMapper{log: gwlog.FallbackLogger, client: mockClient}
+	res := mapper.FixedResponseToRoutes(context.Background(), &anv1alpha1.LatticeFixedResponse{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "maintenance",
+			Namespace: "ns1",
+		},
+	}, core.HttpRouteType)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "valid", res[0].Name())
+}
+
+func TestLambdaFunctionToRoutes(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	lambdaRef := func(name string, namespace *string) gwv1beta1.BackendObjectReference {
+		return gwv1beta1.BackendObjectReference{
+			Group:     (*gwv1beta1.Group)(pointer.String(anv1alpha1.GroupName)),
+			Kind:      (*gwv1beta1.Kind)(pointer.String(anv1alpha1.LambdaFunctionKind)),
+			Namespace: (*gwv1beta1.Namespace)(namespace),
+			Name:      gwv1beta1.ObjectName(name),
+		}
+	}
+
+	routes := []gwv1beta1.HTTPRoute{
+		createHTTPRoute("valid", "ns1", lambdaRef("fn", nil)),
+		createHTTPRoute("valid-cross-namespace", "ns2", lambdaRef("fn", pointer.String("ns1"))),
+		createHTTPRoute("invalid-different-namespace", "ns2", lambdaRef("fn", nil)),
+		createHTTPRoute("invalid-different-name", "ns1", lambdaRef("other-fn", nil)),
+		createHTTPRoute("invalid-service", "ns1", gwv1beta1.BackendObjectReference{
+			Group: (*gwv1beta1.Group)(pointer.String("")),
+			Kind:  (*gwv1beta1.Kind)(pointer.String("Service")),
+			Name:  "fn",
+		}),
+	}
+
+	mockClient := mock_client.NewMockClient(c)
+	mockClient.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, routeList *gwv1beta1.HTTPRouteList, _ ...interface{}) error {
+			routeList.Items = append(routeList.Items, routes...)
+			return nil
+		},
+	)
+
+	mapper := &resourceMapper{log: gwlog.FallbackLogger, client: mockClient}
+	res := mapper.LambdaFunctionToRoutes(context.Background(), &anv1alpha1.LambdaFunction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "fn",
+			Namespace: "ns1",
+		},
+	}, core.HttpRouteType)
+
+	var names []string
+	for _, route := range res {
+		names = append(names, route.Name())
+	}
+	assert.Equal(t, []string{"valid", "valid-cross-namespace"}, names)
+}
+
+func TestReferenceGrantToRoutes(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	routes := []gwv1beta1.HTTPRoute{
+		createHTTPRoute("valid", "ns1", gwv1beta1.BackendObjectReference{
+			Namespace: (*gwv1beta1.Namespace)(pointer.String("ns2")),
+			Name:      "test-service",
+		}),
+		createHTTPRoute("invalid-same-namespace", "ns1", gwv1beta1.BackendObjectReference{
+			Name: "test-service",
+		}),
+		createHTTPRoute("invalid-other-backend-namespace", "ns1", gwv1beta1.BackendObjectReference{
+			Namespace: (*gwv1beta1.Namespace)(pointer.String("ns3")),
+			Name:      "test-service",
+		}),
+		createHTTPRoute("invalid-route-namespace", "ns3", gwv1beta1.BackendObjectReference{
+			Namespace: (*gwv1beta1.Namespace)(pointer.String("ns2")),
+			Name:      "test-service",
+		}),
+	}
+
+	mockClient := mock_client.NewMockClient(c)
+	mockClient.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, routeList *gwv1beta1.HTTPRouteList, _ ...interface{}) error {
+			routeList.Items = append(routeList.Items, routes...)
+			return nil
+		},
+	)
+
+	mapper := &resourceMapper{log: gwlog.FallbackLogger, client: mockClient}
+	res := mapper.ReferenceGrantToRoutes(context.Background(), &gwv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "allow-ns1",
+			Namespace: "ns2",
+		},
+		Spec: gwv1beta1.ReferenceGrantSpec{
+			From: []gwv1beta1.ReferenceGrantFrom{
+				{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "ns1"},
+				{Group: gwv1beta1.GroupName, Kind: "GRPCRoute", Namespace: "ns3"},
+			},
+			To: []gwv1beta1.ReferenceGrantTo{
+				{Group: "", Kind: "Service"},
+			},
+		},
+	}, core.HttpRouteType)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "valid", res[0].Name())
+}
+
+func TestTargetGroupPolicyToServices(t *testing.T) {
+	ctx := context.Background()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+	mcsv1alpha1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	for _, svc := range []types.NamespacedName{
+		{Namespace: "ns1", Name: "svc-1"},
+		{Namespace: "ns1", Name: "svc-2"},
+		{Namespace: "ns2", Name: "svc-3"},
+	} {
+		assert.NoError(t, k8sClient.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: svc.Name, Namespace: svc.Namespace},
+		}))
+	}
+	assert.NoError(t, k8sClient.Create(ctx, &gwv1beta1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "ns2"},
+	}))
+	route := createHTTPRoute("route", "ns2", gwv1beta1.BackendObjectReference{
+		Kind:      (*gwv1beta1.Kind)(pointer.String("Service")),
+		Namespace: (*gwv1beta1.Namespace)(pointer.String("ns1")),
+		Name:      "svc-2",
+	})
+	route.Spec.ParentRefs = []gwv1beta1.ParentReference{{Name: "gw"}}
+	route.Spec.Rules[0].BackendRefs = append(route.Spec.Rules[0].BackendRefs, gwv1beta1.HTTPBackendRef{
+		BackendRef: gwv1beta1.BackendRef{
+			BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "svc-3"},
+		},
+	})
+	assert.NoError(t, k8sClient.Create(ctx, &route))
+	assert.NoError(t, k8sClient.Create(ctx, &mcsv1alpha1.ServiceExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc-1", Namespace: "ns1"},
+	}))
+
+	tgp := func(namespace string, group gwv1beta1.Group, kind gwv1beta1.Kind, name string) *anv1alpha1.TargetGroupPolicy {
+		return &anv1alpha1.TargetGroupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: namespace},
+			Spec: anv1alpha1.TargetGroupPolicySpec{
+				TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+					PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+						Group: group,
+						Kind:  kind,
+						Name:  gwv1alpha2.ObjectName(name),
+					},
+				},
+			},
+		}
+	}
+
+	routeBackendTgp := func(sectionName string) *anv1alpha1.TargetGroupPolicy {
+		p := tgp("ns2", gwv1beta1.GroupName, "HTTPRoute", "route")
+		p.Spec.TargetRef.SectionName = (*gwv1beta1.SectionName)(&sectionName)
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		tgp      *anv1alpha1.TargetGroupPolicy
+		expected []string
+	}{
+		{
+			name:     "attached to service",
+			tgp:      tgp("ns1", "", "Service", "svc-1"),
+			expected: []string{"ns1/svc-1"},
+		},
+		{
+			name:     "attached to namespace",
+			tgp:      tgp("ns1", "", "Namespace", "ns1"),
+			expected: []string{"ns1/svc-1", "ns1/svc-2"},
+		},
+		{
+			name: "attached to another namespace",
+			tgp:  tgp("ns1", "", "Namespace", "ns2"),
+		},
+		{
+			name:     "attached to gateway",
+			tgp:      tgp("ns2", gwv1beta1.GroupName, "Gateway", "gw"),
+			expected: []string{"ns1/svc-2", "ns2/svc-3"},
+		},
+		{
+			name: "attached to missing gateway",
+			tgp:  tgp("ns1", gwv1beta1.GroupName, "Gateway", "gw"),
+		},
+		{
+			name:     "attached to service export",
+			tgp:      tgp("ns1", mcsv1alpha1.GroupName, "ServiceExport", "svc-1"),
+			expected: []string{"ns1/svc-1"},
+		},
+		{
+			name:     "attached to route backend",
+			tgp:      routeBackendTgp("svc-3"),
+			expected: []string{"ns2/svc-3"},
+		},
+		{
+			name: "attached to route backend in another namespace",
+			tgp:  routeBackendTgp("svc-2"),
+		},
+		{
+			name: "attached to missing route backend",
+			tgp:  routeBackendTgp("svc-1"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper := &resourceMapper{log: gwlog.FallbackLogger, client: k8sClient}
+			var names []string
+			for _, svc := range mapper.TargetGroupPolicyToServices(ctx, tt.tgp) {
+				names = append(names, svc.Namespace+"/"+svc.Name)
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package eventhandlers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type namespaceEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewNamespaceEventHandler(log gwlog.Logger, client client.Client) *namespaceEventHandler {
+	return &namespaceEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// MapToRoute enqueues the routes of a namespace whose labels change, since listeners may allow
+// routes from namespaces selected by their labels
+func (h *namespaceEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return handler.Funcs{
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			if equality.Semantic.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) {
+				return
+			}
+			for _, request := range h.mapToRoute(e.ObjectNew, routeType) {
+				queue.Add(request)
+			}
+		},
+	}
+}
+
+func (h *namespaceEventHandler) mapToRoute(obj client.Object, routeType core.RouteType) []reconcile.Request {
+	ctx := context.Background()
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+	routes := h.mapper.NamespaceToRoutes(ctx, namespace, routeType)
+
+	var requests []reconcile.Request
+	for _, route := range routes {
+		routeName := k8s.NamespacedName(route.K8sObject())
+		requests = append(requests, reconcile.Request{NamespacedName: routeName})
+		h.log.Infow("Namespace label change triggered Route update",
+			"namespace", obj.GetName(), "routeName", routeName, "routeType", routeType)
+	}
+	return requests
+}
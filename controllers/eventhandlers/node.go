@@ -0,0 +1,87 @@
+package eventhandlers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type nodeEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewNodeEventHandler(log gwlog.Logger, client client.Client) *nodeEventHandler {
+	return &nodeEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// MapToRoute enqueues the routes with a backendRef to a Service with instance targets
+func (h *nodeEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return h.handlerFor(func(queue workqueue.RateLimitingInterface) {
+		ctx := context.Background()
+		for _, svc := range h.mapper.InstanceTargetServices(ctx) {
+			for _, route := range h.mapper.ServiceToRoutes(ctx, svc, routeType) {
+				routeName := k8s.NamespacedName(route.K8sObject())
+				queue.Add(reconcile.Request{NamespacedName: routeName})
+				h.log.Infow("Node change triggered Route update",
+					"serviceName", svc.Namespace+"/"+svc.Name, "routeName", routeName, "routeType", routeType)
+			}
+		}
+	})
+}
+
+// MapToServiceExport enqueues the ServiceExports of the Services with instance targets
+func (h *nodeEventHandler) MapToServiceExport() handler.EventHandler {
+	return h.handlerFor(func(queue workqueue.RateLimitingInterface) {
+		ctx := context.Background()
+		for _, svc := range h.mapper.InstanceTargetServices(ctx) {
+			svcExport := h.mapper.ServiceToServiceExport(ctx, svc)
+			if svcExport == nil {
+				continue
+			}
+			queue.Add(reconcile.Request{NamespacedName: k8s.NamespacedName(svcExport)})
+			h.log.Infow("Node change triggered ServiceExport update",
+				"serviceName", svc.Namespace+"/"+svc.Name)
+		}
+	})
+}
+
+func (h *nodeEventHandler) handlerFor(enqueue func(queue workqueue.RateLimitingInterface)) handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(queue)
+		},
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if okOld && okNew && isNodeTargetChanged(oldNode, newNode) {
+				enqueue(queue)
+			}
+		},
+		DeleteFunc: func(e event.DeleteEvent, queue workqueue.RateLimitingInterface) {
+			enqueue(queue)
+		},
+	}
+}
+
+// isNodeTargetChanged tells whether the update of a node may change whether it is a target,
+// nodes are updated frequently with heartbeats which do not
+func isNodeTargetChanged(oldNode, newNode *corev1.Node) bool {
+	return gateway.IsNodeReady(oldNode) != gateway.IsNodeReady(newNode) ||
+		oldNode.Spec.ProviderID != newNode.Spec.ProviderID
+}
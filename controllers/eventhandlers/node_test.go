@@ -0,0 +1,134 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestNodeEventHandler_MapToServiceExport(t *testing.T) {
+	ctx := context.Background()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	mcsv1alpha1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+
+	targetTypeInstance := anv1alpha1.TargetTypeInstance
+	targetTypeIP := anv1alpha1.TargetTypeIP
+	for _, svc := range []struct {
+		name       string
+		targetType *anv1alpha1.TargetType
+		exported   bool
+	}{
+		{name: "instance-svc", targetType: &targetTypeInstance, exported: true},
+		{name: "unexported-instance-svc", targetType: &targetTypeInstance},
+		{name: "ip-svc", targetType: &targetTypeIP, exported: true},
+		{name: "default-svc", exported: true},
+	} {
+		assert.NoError(t, k8sClient.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: "ns1"},
+		}))
+		if svc.exported {
+			assert.NoError(t, k8sClient.Create(ctx, &mcsv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: "ns1"},
+			}))
+		}
+		assert.NoError(t, k8sClient.Create(ctx, &anv1alpha1.TargetGroupPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: svc.name, Namespace: "ns1"},
+			Spec: anv1alpha1.TargetGroupPolicySpec{
+				TargetType: svc.targetType,
+				TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+					PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+						Kind: "Service",
+						Name: gwv1alpha2.ObjectName(svc.name),
+					},
+				},
+			},
+		}))
+	}
+
+	readyNode := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1", ResourceVersion: "1"},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-west-2a/i-00000000000000001"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	heartbeatNode := readyNode.DeepCopy()
+	heartbeatNode.ResourceVersion = "2"
+	heartbeatNode.Status.Conditions[0].LastHeartbeatTime = metav1.Now()
+	notReadyNode := readyNode.DeepCopy()
+	notReadyNode.ResourceVersion = "3"
+	notReadyNode.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	expected := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "instance-svc"}},
+	}
+	h := NewNodeEventHandler(gwlog.FallbackLogger, k8sClient).MapToServiceExport()
+
+	tests := []struct {
+		name     string
+		trigger  func(queue workqueue.RateLimitingInterface)
+		expected []reconcile.Request
+	}{
+		{
+			name: "node created",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: readyNode}, queue)
+			},
+			expected: expected,
+		},
+		{
+			name: "node deleted",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Delete(event.DeleteEvent{Object: readyNode}, queue)
+			},
+			expected: expected,
+		},
+		{
+			name: "node no longer ready",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: readyNode, ObjectNew: notReadyNode}, queue)
+			},
+			expected: expected,
+		},
+		{
+			name: "node heartbeat",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: readyNode, ObjectNew: heartbeatNode}, queue)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			tt.trigger(queue)
+
+			var requests []reconcile.Request
+			for queue.Len() > 0 {
+				item, _ := queue.Get()
+				requests = append(requests, item.(reconcile.Request))
+				queue.Done(item)
+			}
+			assert.Equal(t, tt.expected, requests)
+		})
+	}
+}
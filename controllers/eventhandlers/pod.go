@@ -0,0 +1,88 @@
+package eventhandlers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// podEventHandler handles pods with the lattice-target-ready readiness gate. The EndpointSlices of their Services
+// keep them not ready until the gate is, so their targets are built again on the changes of the pods themselves.
+type podEventHandler struct {
+	log    gwlog.Logger
+	client client.Client
+	mapper *resourceMapper
+}
+
+func NewPodEventHandler(log gwlog.Logger, client client.Client) *podEventHandler {
+	return &podEventHandler{
+		log:    log,
+		client: client,
+		mapper: &resourceMapper{log: log, client: client},
+	}
+}
+
+// MapToRoute enqueues the routes with a backendRef to a Service selecting the pod
+func (h *podEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return h.handlerFor(func(pod *corev1.Pod, queue workqueue.RateLimitingInterface) {
+		ctx := context.Background()
+		for _, svc := range h.podServices(ctx, pod) {
+			for _, route := range h.mapper.ServiceToRoutes(ctx, svc, routeType) {
+				routeName := k8s.NamespacedName(route.K8sObject())
+				queue.Add(reconcile.Request{NamespacedName: routeName})
+				h.log.Infow("Pod change triggered Route update",
+					"podName", pod.Namespace+"/"+pod.Name, "routeName", routeName, "routeType", routeType)
+			}
+		}
+	})
+}
+
+// MapToServiceExport enqueues the ServiceExports of the Services selecting the pod
+func (h *podEventHandler) MapToServiceExport() handler.EventHandler {
+	return h.handlerFor(func(pod *corev1.Pod, queue workqueue.RateLimitingInterface) {
+		ctx := context.Background()
+		for _, svc := range h.podServices(ctx, pod) {
+			if svcExport := h.mapper.ServiceToServiceExport(ctx, svc); svcExport != nil {
+				queue.Add(reconcile.Request{NamespacedName: k8s.NamespacedName(svcExport)})
+				h.log.Infow("Pod change triggered ServiceExport update",
+					"podName", pod.Namespace+"/"+pod.Name, "serviceName", svc.Namespace+"/"+svc.Name)
+			}
+		}
+	})
+}
+
+func (h *podEventHandler) podServices(ctx context.Context, pod *corev1.Pod) []*corev1.Service {
+	services, err := gateway.GetPodTargetServices(ctx, h.client, pod)
+	if err != nil {
+		h.log.Errorf("Failed to find services of pod %s-%s, %s", pod.Name, pod.Namespace, err)
+		return nil
+	}
+	return services
+}
+
+func (h *podEventHandler) handlerFor(enqueue func(pod *corev1.Pod, queue workqueue.RateLimitingInterface)) handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(e event.CreateEvent, queue workqueue.RateLimitingInterface) {
+			if pod, ok := e.Object.(*corev1.Pod); ok && gateway.IsPendingLatticeTargetReadiness(pod) {
+				enqueue(pod, queue)
+			}
+		},
+		UpdateFunc: func(e event.UpdateEvent, queue workqueue.RateLimitingInterface) {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			if okOld && okNew && gateway.IsPendingLatticeTargetReadiness(oldPod) != gateway.IsPendingLatticeTargetReadiness(newPod) {
+				enqueue(newPod, queue)
+			}
+		},
+	}
+}
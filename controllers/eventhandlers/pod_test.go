@@ -0,0 +1,121 @@
+package eventhandlers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func TestPodEventHandler_MapToServiceExport(t *testing.T) {
+	ctx := context.Background()
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+	k8sSchema.AddKnownTypes(mcsv1alpha1.SchemeGroupVersion, &mcsv1alpha1.ServiceExport{})
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).
+		WithIndex(&gwv1beta1.HTTPRoute{}, gateway.RouteServiceBackendIndex, gateway.IndexRouteServiceBackends).
+		WithIndex(&gwv1alpha2.GRPCRoute{}, gateway.RouteServiceBackendIndex, gateway.IndexRouteServiceBackends).
+		WithIndex(&gwv1alpha2.TLSRoute{}, gateway.RouteServiceBackendIndex, gateway.IndexRouteServiceBackends).
+		Build()
+
+	for _, name := range []string{"exported", "not-exported"} {
+		assert.NoError(t, k8sClient.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "inventory"}},
+		}))
+	}
+	assert.NoError(t, k8sClient.Create(ctx, &mcsv1alpha1.ServiceExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "exported", Namespace: "ns1"},
+	}))
+
+	pod := func(containersReady corev1.ConditionStatus, gates ...corev1.PodReadinessGate) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pod",
+				Namespace: "ns1",
+				Labels:    map[string]string{"app": "inventory"},
+			},
+			Spec: corev1.PodSpec{ReadinessGates: gates},
+			Status: corev1.PodStatus{
+				Conditions: []corev1.PodCondition{{Type: corev1.ContainersReady, Status: containersReady}},
+			},
+		}
+	}
+	gate := corev1.PodReadinessGate{ConditionType: gateway.LatticeTargetReadyConditionType}
+	startingPod := pod(corev1.ConditionFalse, gate)
+	pendingPod := pod(corev1.ConditionTrue, gate)
+	podWithoutGate := pod(corev1.ConditionTrue)
+
+	expected := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "ns1", Name: "exported"}},
+	}
+	h := NewPodEventHandler(gwlog.FallbackLogger, k8sClient).MapToServiceExport()
+
+	tests := []struct {
+		name     string
+		trigger  func(queue workqueue.RateLimitingInterface)
+		expected []reconcile.Request
+	}{
+		{
+			name: "pending pod created",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: pendingPod}, queue)
+			},
+			expected: expected,
+		},
+		{
+			name: "containers of pod became ready",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: startingPod, ObjectNew: pendingPod}, queue)
+			},
+			expected: expected,
+		},
+		{
+			name: "containers of pod still starting",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Update(event.UpdateEvent{ObjectOld: startingPod, ObjectNew: startingPod}, queue)
+			},
+		},
+		{
+			name: "pod without readiness gate",
+			trigger: func(queue workqueue.RateLimitingInterface) {
+				h.Create(event.CreateEvent{Object: podWithoutGate}, queue)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+			tt.trigger(queue)
+
+			var requests []reconcile.Request
+			for queue.Len() > 0 {
+				item, _ := queue.Get()
+				requests = append(requests, item.(reconcile.Request))
+				queue.Done(item)
+			}
+			assert.Equal(t, tt.expected, requests)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package eventhandlers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type rolloutEventHandler struct {
+	log gwlog.Logger
+}
+
+func NewRolloutEventHandler(log gwlog.Logger) *rolloutEventHandler {
+	return &rolloutEventHandler{log: log}
+}
+
+// MapToRoute enqueues the HTTPRoute a LatticeRollout refers to, whose weights follow the status of the rollout
+func (h *rolloutEventHandler) MapToRoute(routeType core.RouteType) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return h.mapToRoute(obj, routeType)
+	})
+}
+
+func (h *rolloutEventHandler) mapToRoute(obj client.Object, routeType core.RouteType) []reconcile.Request {
+	rollout, ok := obj.(*v1alpha1.LatticeRollout)
+	if !ok || routeType != core.HttpRouteType {
+		return nil
+	}
+
+	routeName := types.NamespacedName{
+		Namespace: rollout.Namespace,
+		Name:      string(rollout.Spec.RouteRef.Name),
+	}
+	h.log.Infow("LatticeRollout change triggered Route update",
+		"rolloutName", obj.GetNamespace()+"/"+obj.GetName(), "routeName", routeName, "routeType", routeType)
+	return []reconcile.Request{{NamespacedName: routeName}}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -35,45 +37,81 @@ func (h *serviceEventHandler) MapToServiceExport() handler.EventHandler {
 	})
 }
 
+// MapToPod enqueues the pods selected by the Service
+func (h *serviceEventHandler) MapToPod() handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+		return h.mapToPod(obj)
+	})
+}
+
+func (h *serviceEventHandler) mapToPod(obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+
+	ctx := context.Background()
+	for _, svc := range h.mapToServices(ctx, obj) {
+		var svcRequests []reconcile.Request
+		for _, pod := range h.mapper.ServiceToPods(ctx, svc) {
+			svcRequests = append(svcRequests, reconcile.Request{
+				NamespacedName: k8s.NamespacedName(pod),
+			})
+		}
+		if len(svcRequests) > 0 {
+			h.log.Infow("Service impacting resource change triggered Pod update",
+				"serviceName", svc.Namespace+"/"+svc.Name, "pods", len(svcRequests))
+		}
+		requests = append(requests, svcRequests...)
+	}
+	return requests
+}
+
 func (h *serviceEventHandler) mapToServiceExport(obj client.Object) []reconcile.Request {
 	var requests []reconcile.Request
 
 	ctx := context.Background()
-	svc := h.mapToService(ctx, obj)
-	svcExport := h.mapper.ServiceToServiceExport(ctx, svc)
-	if svcExport != nil {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: k8s.NamespacedName(svcExport),
-		})
-		h.log.Infow("Service impacting resource change triggered ServiceExport update",
-			"serviceName", svc.Namespace+"/"+svc.Name)
+	for _, svc := range h.mapToServices(ctx, obj) {
+		svcExport := h.mapper.ServiceToServiceExport(ctx, svc)
+		if svcExport != nil {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: k8s.NamespacedName(svcExport),
+			})
+			h.log.Infow("Service impacting resource change triggered ServiceExport update",
+				"serviceName", svc.Namespace+"/"+svc.Name)
+		}
 	}
 	return requests
 }
 
-func (h *serviceEventHandler) mapToService(ctx context.Context, obj client.Object) *corev1.Service {
+// mapToServices returns the Services impacted by obj, a TargetGroupPolicy can apply to several of them
+func (h *serviceEventHandler) mapToServices(ctx context.Context, obj client.Object) []*corev1.Service {
 	switch typed := obj.(type) {
 	case *corev1.Service:
-		return typed
+		return []*corev1.Service{typed}
 	case *v1alpha1.TargetGroupPolicy:
-		return h.mapper.TargetGroupPolicyToService(ctx, typed)
-	case *corev1.Endpoints:
-		return h.mapper.EndpointsToService(ctx, typed)
+		return h.mapper.TargetGroupPolicyToServices(ctx, typed)
+	case *discoveryv1.EndpointSlice:
+		if svc := h.mapper.EndpointSliceToService(ctx, typed); svc != nil {
+			return []*corev1.Service{svc}
+		}
 	}
 	return nil
 }
 
 func (h *serviceEventHandler) mapToRoute(obj client.Object, routeType core.RouteType) []reconcile.Request {
 	ctx := context.Background()
-	svc := h.mapToService(ctx, obj)
-	routes := h.mapper.ServiceToRoutes(ctx, svc, routeType)
 
 	var requests []reconcile.Request
-	for _, route := range routes {
-		routeName := k8s.NamespacedName(route.K8sObject())
-		requests = append(requests, reconcile.Request{NamespacedName: routeName})
-		h.log.Infow("Service impacting resource change triggered Route update",
-			"serviceName", svc.Namespace+"/"+svc.Name, "routeName", routeName, "routeType", routeType)
+	seen := make(map[types.NamespacedName]struct{})
+	for _, svc := range h.mapToServices(ctx, obj) {
+		for _, route := range h.mapper.ServiceToRoutes(ctx, svc, routeType) {
+			routeName := k8s.NamespacedName(route.K8sObject())
+			if _, ok := seen[routeName]; ok {
+				continue
+			}
+			seen[routeName] = struct{}{}
+			requests = append(requests, reconcile.Request{NamespacedName: routeName})
+			h.log.Infow("Service impacting resource change triggered Route update",
+				"serviceName", svc.Namespace+"/"+svc.Name, "routeName", routeName, "routeType", routeType)
+		}
 	}
 	return requests
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
@@ -55,17 +56,20 @@ func TestServiceEventHandler_MapToRoute(t *testing.T) {
 				Namespace: "ns1",
 			},
 			Spec: anv1alpha1.TargetGroupPolicySpec{
-				TargetRef: &gwv1alpha2.PolicyTargetReference{
-					Group: "",
-					Kind:  "Service",
-					Name:  "test-service",
+				TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+					PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+						Group: "",
+						Kind:  "Service",
+						Name:  "test-service",
+					},
 				},
 			},
 		},
-		&corev1.Endpoints{
+		&discoveryv1.EndpointSlice{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-service",
+				Name:      "test-service-x8k2p",
 				Namespace: "ns1",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "test-service"},
 			},
 		},
 		&corev1.Service{
@@ -103,17 +107,20 @@ func TestServiceEventHandler_MapToServiceExport(t *testing.T) {
 				Namespace: "ns1",
 			},
 			Spec: anv1alpha1.TargetGroupPolicySpec{
-				TargetRef: &gwv1alpha2.PolicyTargetReference{
-					Group: "",
-					Kind:  "Service",
-					Name:  "test-service",
+				TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+					PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+						Group: "",
+						Kind:  "Service",
+						Name:  "test-service",
+					},
 				},
 			},
 		},
-		&corev1.Endpoints{
+		&discoveryv1.EndpointSlice{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      "test-service",
+				Name:      "test-service-x8k2p",
 				Namespace: "ns1",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "test-service"},
 			},
 		},
 		&corev1.Service{
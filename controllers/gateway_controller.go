@@ -166,25 +166,15 @@ func (r *gatewayReconciler) reconcileDelete(ctx context.Context, gw *gwv1beta1.G
 	}
 
 	for _, route := range routes {
-		if len(route.Spec().ParentRefs()) <= 0 {
-			continue
-		}
-		gwNamespace := route.Namespace()
-		if route.Spec().ParentRefs()[0].Namespace != nil {
-			gwNamespace = string(*route.Spec().ParentRefs()[0].Namespace)
-		}
-		gwName := types.NamespacedName{
-			Namespace: gwNamespace,
-			Name:      string(route.Spec().ParentRefs()[0].Name),
-		}
-
-		httpGw := &gwv1beta1.Gateway{}
-		if err := r.client.Get(ctx, gwName, httpGw); err != nil {
-			continue
-		}
+		for _, parentRef := range route.Spec().ParentRefs() {
+			gwNamespace := route.Namespace()
+			if parentRef.Namespace != nil {
+				gwNamespace = string(*parentRef.Namespace)
+			}
 
-		if httpGw.Name == gw.Name && httpGw.Namespace == gw.Namespace {
-			return fmt.Errorf("cannot delete gw, there is reference to httpGw, gw: %s, httpGw: %s", gw.Name, httpGw.Name)
+			if string(parentRef.Name) == gw.Name && gwNamespace == gw.Namespace {
+				return fmt.Errorf("cannot delete gw, there is reference to gw from route, gw: %s, route: %s", gw.Name, route.Name())
+			}
 		}
 	}
 
@@ -346,7 +336,18 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 		return fmt.Errorf("failed to find gateway listener")
 	}
 
-	defaultListener := gw.Spec.Listeners[0]
+	var routeParents [][]*gateway.RouteParent
+	for _, route := range routes {
+		if !route.DeletionTimestamp().IsZero() {
+			// Ignore the deleted route
+			continue
+		}
+		parents, err := gateway.GetRouteParents(ctx, k8sClient, route)
+		if err != nil {
+			return err
+		}
+		routeParents = append(routeParents, parents)
+	}
 
 	// go through each section of gw
 	for _, listener := range gw.Spec.Listeners {
@@ -380,34 +381,15 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 				LastTransitionTime: metav1.Now(),
 			}
 
-			for _, route := range routes {
-				if !route.DeletionTimestamp().IsZero() {
-					// Ignore the deleted route
-					continue
-				}
-
-				for _, parentRef := range route.Spec().ParentRefs() {
-					if parentRef.Name != gwv1beta1.ObjectName(gw.Name) {
-						continue
-					}
-
-					if parentRef.Namespace != nil &&
-						*parentRef.Namespace != gwv1beta1.Namespace(gw.Namespace) {
-						continue
-					}
-
-					var sectionName string
-					if parentRef.SectionName == nil {
-						sectionName = string(defaultListener.Name)
-					} else {
-						sectionName = string(*parentRef.SectionName)
-					}
-
-					if sectionName != string(listener.Name) {
+			for _, parents := range routeParents {
+				for _, parent := range parents {
+					// only routes the listener accepts are attached to it
+					if parent.Gateway.Name != gw.Name || parent.Gateway.Namespace != gw.Namespace ||
+						!parent.Accepted() || parent.Listener == nil || parent.Listener.Name != listener.Name {
 						continue
 					}
 
-					if parentRef.Port != nil && *parentRef.Port != listener.Port {
+					if parent.ParentRef.Port != nil && *parent.ParentRef.Port != listener.Port {
 						continue
 					}
 
@@ -415,15 +397,21 @@ func UpdateGWListenerStatus(ctx context.Context, k8sClient client.Client, gw *gw
 				}
 			}
 
-			if listener.Protocol == gwv1beta1.HTTPSProtocolType {
+			if listener.Protocol == gwv1beta1.TLSProtocolType {
 				listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gwv1beta1.RouteGroupKind{
-					Kind: "GRPCRoute",
+					Kind: "TLSRoute",
 				})
-			}
+			} else {
+				if listener.Protocol == gwv1beta1.HTTPSProtocolType {
+					listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gwv1beta1.RouteGroupKind{
+						Kind: "GRPCRoute",
+					})
+				}
 
-			listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gwv1beta1.RouteGroupKind{
-				Kind: "HTTPRoute",
-			})
+				listenerStatus.SupportedKinds = append(listenerStatus.SupportedKinds, gwv1beta1.RouteGroupKind{
+					Kind: "HTTPRoute",
+				})
+			}
 			listenerStatus.Conditions = append(listenerStatus.Conditions, condition)
 		}
 
@@ -470,6 +458,14 @@ func listenerRouteGroupKindSupported(listener gwv1beta1.Listener) (bool, []gwv1b
 			} else {
 				validRoute = false
 			}
+		} else if routeGroupKind.Kind == "TLSRoute" {
+			if listener.Protocol == gwv1beta1.TLSProtocolType {
+				supportedKinds = append(supportedKinds, gwv1beta1.RouteGroupKind{
+					Kind: "TLSRoute",
+				})
+			} else {
+				validRoute = false
+			}
 		} else {
 			validRoute = false
 		}
@@ -2,67 +2,240 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	pkg_builder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
 
-	k8serr "k8s.io/apimachinery/pkg/api/errors"
-	ctrl "sigs.k8s.io/controller-runtime"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+const (
+	iamAuthPolicyFinalizer = "iamauthpolicy.k8s.aws/resources"
 )
 
+var iamAuthPolicyType = policyType{
+	newPolicy:     func() policyObject { return &anv1alpha1.IAMAuthPolicy{} },
+	newPolicyList: func() policyObjectList { return &anv1alpha1.IAMAuthPolicyList{} },
+	targetKinds:   []policyTargetKind{gatewayTargetKind, httpRouteTargetKind, grpcRouteTargetKind},
+	validate:      validateIAMAuthPolicy,
+}
+
 type IAMAuthPolicyController struct {
-	log    gwlog.Logger
-	client client.Client
+	log              gwlog.Logger
+	client           client.Client
+	finalizerManager k8s.FinalizerManager
+	eventRecorder    record.EventRecorder
+	cloud            aws.Cloud
+	policyManager    lattice.IAMAuthPolicyManager
 }
 
-func RegisterIAMAuthPolicyController(log gwlog.Logger, mgr ctrl.Manager) error {
+func RegisterIAMAuthPolicyController(
+	log gwlog.Logger,
+	cloud aws.Cloud,
+	finalizerManager k8s.FinalizerManager,
+	mgr ctrl.Manager,
+) error {
 	controller := &IAMAuthPolicyController{
-		log:    log,
-		client: mgr.GetClient(),
+		log:              log,
+		client:           mgr.GetClient(),
+		finalizerManager: finalizerManager,
+		eventRecorder:    mgr.GetEventRecorderFor("iamauthpolicy"),
+		cloud:            cloud,
+		policyManager:    lattice.NewIAMAuthPolicyManager(log, cloud),
 	}
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&anv1alpha1.IAMAuthPolicy{}).
-		Complete(controller)
-	return err
+	mapper := &policyMapper{log: log, client: mgr.GetClient(), policyType: iamAuthPolicyType}
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&anv1alpha1.IAMAuthPolicy{}, pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	return mapper.watchTargets(builder, targetCreatedOrDeletedPredicate).Complete(controller)
 }
 
 func (c *IAMAuthPolicyController) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	c.log.Infow("reconcile", "req", req)
+	recErr := c.reconcile(ctx, req)
+	res, retryErr := lattice_runtime.HandleReconcileError(recErr)
+	if res.RequeueAfter != 0 {
+		c.log.Infow("requeue request", "req", req, "requeueAfter", res.RequeueAfter)
+	} else if res.Requeue {
+		c.log.Infow("requeue request", "req", req)
+	} else if retryErr == nil {
+		c.log.Infow("successfully reconciled", "req", req)
+	}
+	return res, retryErr
+}
 
+func (c *IAMAuthPolicyController) reconcile(ctx context.Context, req ctrl.Request) error {
 	policy := &anv1alpha1.IAMAuthPolicy{}
-	err := c.client.Get(ctx, req.NamespacedName, policy)
-	if !k8serr.IsNotFound(err) {
-		return ctrl.Result{}, err
-	}
-
-	switch policy.Spec.TargetRef.Kind {
-	case "Gateway":
-		err = c.reconcileGateway(ctx, policy)
-		break
-	case "HTTPRoute":
-	case "GRPCRoute":
-		err = c.reconcileRoute(ctx, policy)
-		break
-	default:
-		err = fmt.Errorf("unsupported targetRef type, req=%s, kind=%s",
-			req, policy.Spec.TargetRef.Kind)
+	if err := c.client.Get(ctx, req.NamespacedName, policy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	if !policy.DeletionTimestamp.IsZero() {
+		return c.reconcileDelete(ctx, policy)
+	}
+	return c.reconcileUpsert(ctx, policy)
+}
+
+func (c *IAMAuthPolicyController) reconcileDelete(ctx context.Context, policy *anv1alpha1.IAMAuthPolicy) error {
+	if resourceId, ok := policy.Annotations[anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey]; ok {
+		err := c.policyManager.Delete(ctx, iamAuthPolicyTargetTypeForResourceId(resourceId), resourceId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.finalizerManager.RemoveFinalizers(ctx, policy, iamAuthPolicyFinalizer)
+}
+
+func (c *IAMAuthPolicyController) reconcileUpsert(ctx context.Context, policy *anv1alpha1.IAMAuthPolicy) error {
+	if err := c.finalizerManager.AddFinalizers(ctx, policy, iamAuthPolicyFinalizer); err != nil {
+		c.eventRecorder.Event(policy, corev1.EventTypeWarning,
+			k8s.IAMAuthPolicyEventReasonFailedAddFinalizer, fmt.Sprintf("Failed to add finalizer due to %s", err))
+		return err
+	}
+
+	reason, message, err := acceptPolicy(ctx, c.client, policy, iamAuthPolicyType)
+	if err != nil {
+		return err
+	}
+	if reason != gwv1alpha2.PolicyReasonAccepted {
+		// the target might have been deleted, or taken by another policy
+		if err := c.detachFromLatticeResource(ctx, policy); err != nil {
+			return err
+		}
+		return updatePolicyStatus(ctx, c.client, policy, reason, false, message)
+	}
+
+	targetType, resourceId, err := c.findLatticeResource(ctx, policy)
+	if err != nil {
+		if services.IsNotFoundError(err) {
+			// the targetRef exists in k8s but its VPC Lattice resource is not created yet
+			message := fmt.Sprintf("Waiting for VPC Lattice resource of the targetRef: %s", err)
+			if err := updatePolicyStatus(ctx, c.client, policy, gwv1alpha2.PolicyReasonAccepted, false, message); err != nil {
+				return err
+			}
+			return lattice.RetryErr
+		}
+		return err
 	}
+
+	// the targetRef might have been changed, detach the policy from the previous resource
+	if oldResourceId, ok := policy.Annotations[anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey]; ok && oldResourceId != resourceId {
+		err := c.policyManager.Delete(ctx, iamAuthPolicyTargetTypeForResourceId(oldResourceId), oldResourceId)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = c.policyManager.Put(ctx, &model.IAMAuthPolicy{
+		Type:       targetType,
+		ResourceId: resourceId,
+		Policy:     policy.Spec.Policy,
+	})
+	if err != nil {
+		c.eventRecorder.Event(policy, corev1.EventTypeWarning,
+			k8s.IAMAuthPolicyEventReasonFailedDeployModel, fmt.Sprintf("Failed to put auth policy due to %s", err))
+		return err
+	}
+
+	if err := c.updateIAMAuthPolicyAnnotations(ctx, policy, resourceId); err != nil {
+		return err
+	}
+
+	return updatePolicyStatus(ctx, c.client, policy, gwv1alpha2.PolicyReasonAccepted, true, config.LatticeGatewayControllerName)
+}
+
+// validateIAMAuthPolicy returns a non-empty message describing why the policy is invalid
+func validateIAMAuthPolicy(policy policyObject) string {
+	var policyDocument map[string]interface{}
+	if err := json.Unmarshal([]byte(policy.(*anv1alpha1.IAMAuthPolicy).Spec.Policy), &policyDocument); err != nil {
+		return fmt.Sprintf("The policy is not a valid JSON object: %s", err)
+	}
+	return ""
+}
+
+func (c *IAMAuthPolicyController) findLatticeResource(
+	ctx context.Context,
+	policy *anv1alpha1.IAMAuthPolicy,
+) (model.IAMAuthPolicyTargetType, string, error) {
+	name, err := utils.TargetRefToLatticeResourceName(policy.Spec.TargetRef, policy.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err
+		return "", "", err
 	}
 
-	c.log.Infow("successfully reconciled", "req", req)
-	return ctrl.Result{}, nil
+	if policy.Spec.TargetRef.Kind == "Gateway" {
+		sn, err := c.cloud.Lattice().FindServiceNetwork(ctx, name, config.AccountID)
+		if err != nil {
+			return "", "", err
+		}
+		return model.ServiceNetworkIAMAuthPolicyTarget, *sn.SvcNetwork.Id, nil
+	}
+
+	svc, err := c.cloud.Lattice().FindService(ctx, services.NewDefaultLatticeServiceNameProvider(name))
+	if err != nil {
+		return "", "", err
+	}
+	return model.ServiceIAMAuthPolicyTarget, *svc.Id, nil
+}
+
+// VPC Lattice service network ids are prefixed with "sn-", service ids with "svc-"
+func iamAuthPolicyTargetTypeForResourceId(resourceId string) model.IAMAuthPolicyTargetType {
+	if strings.HasPrefix(resourceId, "sn-") {
+		return model.ServiceNetworkIAMAuthPolicyTarget
+	}
+	return model.ServiceIAMAuthPolicyTarget
 }
 
-func (c *IAMAuthPolicyController) reconcileGateway(ctx context.Context, policy *anv1alpha1.IAMAuthPolicy) error {
-	c.log.Debugw("reconcile gateway iam policy", "policy", policy)
+// detachFromLatticeResource deletes the auth policy from the VPC Lattice resource it was put on, if any
+func (c *IAMAuthPolicyController) detachFromLatticeResource(ctx context.Context, policy *anv1alpha1.IAMAuthPolicy) error {
+	resourceId, ok := policy.Annotations[anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if err := c.policyManager.Delete(ctx, iamAuthPolicyTargetTypeForResourceId(resourceId), resourceId); err != nil {
+		return err
+	}
+	oldPolicy := policy.DeepCopy()
+	delete(policy.Annotations, anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey)
+	if err := c.client.Patch(ctx, policy, client.MergeFrom(oldPolicy)); err != nil {
+		return fmt.Errorf("failed to remove annotation from IAM auth policy %s-%s, %w",
+			policy.Name, policy.Namespace, err)
+	}
 	return nil
 }
 
-func (c IAMAuthPolicyController) reconcileRoute(ctx context.Context, policy *anv1alpha1.IAMAuthPolicy) error {
-	c.log.Debugw("reconcile route iam policy", "policy", policy)
+func (c *IAMAuthPolicyController) updateIAMAuthPolicyAnnotations(
+	ctx context.Context,
+	policy *anv1alpha1.IAMAuthPolicy,
+	resourceId string,
+) error {
+	if policy.Annotations[anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey] == resourceId {
+		return nil
+	}
+	oldPolicy := policy.DeepCopy()
+	if policy.Annotations == nil {
+		policy.Annotations = make(map[string]string)
+	}
+	policy.Annotations[anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey] = resourceId
+	if err := c.client.Patch(ctx, policy, client.MergeFrom(oldPolicy)); err != nil {
+		return fmt.Errorf("failed to add annotation to IAM auth policy %s-%s, %w",
+			policy.Name, policy.Namespace, err)
+	}
 	return nil
 }
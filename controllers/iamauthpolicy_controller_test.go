@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func Test_IAMAuthPolicyReconcile_TargetDeleted(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+
+	policy := &anv1alpha1.IAMAuthPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "policy",
+			Namespace:   "default",
+			Finalizers:  []string{iamAuthPolicyFinalizer},
+			Annotations: map[string]string{anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey: "svc-123"},
+		},
+		Spec: anv1alpha1.IAMAuthPolicySpec{
+			Policy: "{}",
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Group: gwv1beta1.GroupName,
+				Kind:  "HTTPRoute",
+				Name:  "deleted-route",
+			},
+		},
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(policy).Build()
+
+	policyManager := lattice.NewMockIAMAuthPolicyManager(c)
+	policyManager.EXPECT().Delete(gomock.Any(), model.ServiceIAMAuthPolicyTarget, "svc-123").Return(nil)
+
+	controller := &IAMAuthPolicyController{
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient),
+		eventRecorder:    record.NewFakeRecorder(10),
+		policyManager:    policyManager,
+	}
+
+	policyName := types.NamespacedName{Namespace: "default", Name: "policy"}
+	_, err := controller.Reconcile(context.TODO(), ctrl.Request{NamespacedName: policyName})
+	assert.NoError(t, err)
+
+	got := &anv1alpha1.IAMAuthPolicy{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), policyName, got))
+	assert.NotContains(t, got.Annotations, anv1alpha1.IAMAuthPolicyResourceIdAnnotationKey)
+	accepted := meta.FindStatusCondition(got.Status.Conditions, string(gwv1alpha2.PolicyConditionAccepted))
+	assert.NotNil(t, accepted)
+	assert.Equal(t, string(gwv1alpha2.PolicyReasonTargetNotFound), accepted.Reason)
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	pkg_builder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+type latticeRolloutReconciler struct {
+	log           gwlog.Logger
+	client        client.Client
+	eventRecorder record.EventRecorder
+	cloud         aws.Cloud
+	datastore     *latticestore.LatticeDataStore
+}
+
+func RegisterLatticeRolloutController(
+	log gwlog.Logger,
+	cloud aws.Cloud,
+	datastore *latticestore.LatticeDataStore,
+	mgr ctrl.Manager,
+) error {
+	r := &latticeRolloutReconciler{
+		log:           log,
+		client:        mgr.GetClient(),
+		eventRecorder: mgr.GetEventRecorderFor("latticerollout"),
+		cloud:         cloud,
+		datastore:     datastore,
+	}
+
+	// rollouts are advanced by requeueing them, status updates do not need another reconcile
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&anv1alpha1.LatticeRollout{}, pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	return builder.Complete(r)
+}
+
+//+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=latticerollouts,verbs=get;list;watch
+//+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=latticerollouts/status,verbs=get;update;patch
+
+func (r *latticeRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.log.Infow("reconcile", "name", req.Name)
+	return lattice_runtime.HandleReconcileError(r.reconcile(ctx, req))
+}
+
+func (r *latticeRolloutReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	rollout := &anv1alpha1.LatticeRollout{}
+	if err := r.client.Get(ctx, req.NamespacedName, rollout); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !rollout.DeletionTimestamp.IsZero() {
+		// the route controller restores the weights of the route once the rollout is gone
+		return nil
+	}
+	rolloutOld := rollout.DeepCopy()
+
+	route := &gwv1beta1.HTTPRoute{}
+	routeName := types.NamespacedName{
+		Namespace: rollout.Namespace,
+		Name:      string(rollout.Spec.RouteRef.Name),
+	}
+	if err := r.client.Get(ctx, routeName, route); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return r.updateInvalidStatus(ctx, rollout, rolloutOld, fmt.Sprintf("HTTPRoute %s not found", routeName.Name))
+	}
+	if _, err := gateway.GetRolloutRule(route, rollout); err != nil {
+		return r.updateInvalidStatus(ctx, rollout, rolloutOld, err.Error())
+	}
+
+	requeueAfter, err := gateway.AdvanceRollout(rollout, func() (gateway.RolloutHealth, error) {
+		return r.getCanaryHealth(ctx, rollout)
+	}, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if !reflect.DeepEqual(rollout.Status, rolloutOld.Status) {
+		if err := r.client.Status().Patch(ctx, rollout, client.MergeFrom(rolloutOld)); err != nil {
+			return fmt.Errorf("failed to update lattice rollout status, %w", err)
+		}
+		r.recordStepEvent(rollout, rolloutOld)
+	}
+
+	if requeueAfter > 0 {
+		return lattice_runtime.NewRequeueNeededAfter("rollout in progress", requeueAfter)
+	}
+	return nil
+}
+
+func (r *latticeRolloutReconciler) updateInvalidStatus(
+	ctx context.Context,
+	rollout *anv1alpha1.LatticeRollout,
+	rolloutOld *anv1alpha1.LatticeRollout,
+	message string,
+) error {
+	rollout.Status.ObservedGeneration = rollout.Generation
+	rollout.Status.Phase = anv1alpha1.LatticeRolloutPhaseInvalid
+	rollout.Status.Message = message
+	if reflect.DeepEqual(rollout.Status, rolloutOld.Status) {
+		return nil
+	}
+	if err := r.client.Status().Patch(ctx, rollout, client.MergeFrom(rolloutOld)); err != nil {
+		return fmt.Errorf("failed to update lattice rollout status, %w", err)
+	}
+	r.eventRecorder.Event(rollout, corev1.EventTypeWarning, k8s.LatticeRolloutEventReasonInvalid, message)
+	return nil
+}
+
+func (r *latticeRolloutReconciler) recordStepEvent(rollout *anv1alpha1.LatticeRollout, rolloutOld *anv1alpha1.LatticeRollout) {
+	if len(rollout.Status.History) == len(rolloutOld.Status.History) &&
+		rollout.Status.Phase == rolloutOld.Status.Phase {
+		return
+	}
+	switch rollout.Status.Phase {
+	case anv1alpha1.LatticeRolloutPhaseRolledBack:
+		r.eventRecorder.Event(rollout, corev1.EventTypeWarning, k8s.LatticeRolloutEventReasonRolledBack, rollout.Status.Message)
+	default:
+		r.eventRecorder.Event(rollout, corev1.EventTypeNormal, k8s.LatticeRolloutEventReasonStep,
+			fmt.Sprintf("%s, canary weight %d", rollout.Status.Message, rollout.Status.CanaryWeight))
+	}
+}
+
+// getCanaryHealth counts the targets of the target group of the canary Service by their health status
+func (r *latticeRolloutReconciler) getCanaryHealth(ctx context.Context, rollout *anv1alpha1.LatticeRollout) (gateway.RolloutHealth, error) {
+	var health gateway.RolloutHealth
+
+	tgName := latticestore.TargetGroupName(string(rollout.Spec.CanaryService), rollout.Namespace)
+	tg, err := r.datastore.GetTargetGroup(tgName, string(rollout.Spec.RouteRef.Name), false)
+	if err != nil || tg.ID == "" {
+		r.log.Debugf("Target group %s of rollout %s-%s is not created yet", tgName, rollout.Name, rollout.Namespace)
+		return health, nil
+	}
+
+	targets, err := r.cloud.Lattice().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{
+		TargetGroupIdentifier: awssdk.String(tg.ID),
+	})
+	if err != nil {
+		return health, fmt.Errorf("failed to list targets of target group %s, %w", tg.ID, err)
+	}
+
+	for _, target := range targets {
+		switch awssdk.StringValue(target.Status) {
+		case vpclattice.TargetStatusDraining:
+			continue
+		case vpclattice.TargetStatusUnhealthy:
+			health.Unhealthy++
+		case vpclattice.TargetStatusInitial:
+			health.Pending++
+		}
+		health.Total++
+	}
+	return health, nil
+}
@@ -18,30 +18,79 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
-	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/aws/aws-application-networking-k8s/controllers/eventhandlers"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
+const (
+	// how often the target health of a pod waiting for its readiness gate is checked,
+	// VPC Lattice does not notify of health changes
+	podReadinessRetryInterval = 10 * time.Second
+	// how often VPC Lattice is checked for the targets of a terminating pod
+	podDrainingRetryInterval = 5 * time.Second
+)
+
+// podReconciler sets the lattice-target-ready readiness gate of pods once VPC Lattice reports them healthy
+// in the target groups of their Services, so that rolling updates wait for the new pods to receive traffic.
+// It also keeps terminating pods of Services with a draining timeout until VPC Lattice drained their targets.
 type podReconciler struct {
-	log    gwlog.Logger
-	client client.Client
-	scheme *runtime.Scheme
+	log              gwlog.Logger
+	client           client.Client
+	scheme           *runtime.Scheme
+	cloud            aws.Cloud
+	datastore        *latticestore.LatticeDataStore
+	finalizerManager k8s.FinalizerManager
 }
 
-func RegisterPodController(log gwlog.Logger, mgr ctrl.Manager) error {
+func RegisterPodController(
+	log gwlog.Logger,
+	cloud aws.Cloud,
+	datastore *latticestore.LatticeDataStore,
+	finalizerManager k8s.FinalizerManager,
+	mgr ctrl.Manager,
+) error {
 	pr := &podReconciler{
-		log:    log,
-		client: mgr.GetClient(),
-		scheme: mgr.GetScheme(),
+		log:              log,
+		client:           mgr.GetClient(),
+		scheme:           mgr.GetScheme(),
+		cloud:            cloud,
+		datastore:        datastore,
+		finalizerManager: finalizerManager,
 	}
-	err := ctrl.NewControllerManagedBy(mgr).
-		For(&corev1.Pod{}).
-		Complete(pr)
-	return err
+	svcEventHandler := eventhandlers.NewServiceEventHandler(log, mgr.GetClient())
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{})
+
+	if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.TargetGroupPolicyKind); ok {
+		builder.Watches(&source.Kind{Type: &anv1alpha1.TargetGroupPolicy{}}, svcEventHandler.MapToPod())
+	} else {
+		if err != nil {
+			return err
+		}
+		log.Infof("TargetGroupPolicy CRD is not installed, skipping watch")
+	}
+
+	return builder.Complete(pr)
 }
 
 //+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete
@@ -49,10 +98,147 @@ func RegisterPodController(log gwlog.Logger, mgr ctrl.Manager) error {
 //+kubebuilder:rbac:groups=core,resources=pods/finalizers,verbs=update
 
 func (r *podReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return lattice_runtime.HandleReconcileError(r.reconcile(ctx, req))
+}
+
+func (r *podReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
 	pod := &corev1.Pod{}
 	if err := r.client.Get(ctx, req.NamespacedName, pod); err != nil {
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return client.IgnoreNotFound(err)
+	}
+	if !pod.DeletionTimestamp.IsZero() {
+		return r.reconcileDraining(ctx, pod)
+	}
+	if err := r.reconcileDrainingFinalizer(ctx, pod); err != nil {
+		return err
+	}
+	return r.reconcileReadinessGate(ctx, pod)
+}
+
+// reconcileDrainingFinalizer adds the draining finalizer to pods of Services with a draining timeout,
+// and removes it once none of their Services has one anymore
+func (r *podReconciler) reconcileDrainingFinalizer(ctx context.Context, pod *corev1.Pod) error {
+	services, err := gateway.GetPodTargetServices(ctx, r.client, pod)
+	if err != nil {
+		return err
+	}
+	timeout, err := gateway.GetDrainingTimeout(ctx, r.client, services)
+	if err != nil {
+		return err
+	}
+	if timeout > 0 {
+		return r.finalizerManager.AddFinalizers(ctx, pod, gateway.LatticeTargetDrainingFinalizer)
+	}
+	if k8s.HasFinalizer(pod, gateway.LatticeTargetDrainingFinalizer) {
+		return r.finalizerManager.RemoveFinalizers(ctx, pod, gateway.LatticeTargetDrainingFinalizer)
+	}
+	return nil
+}
+
+// reconcileDraining removes the draining finalizer of a terminating pod once VPC Lattice no longer lists its
+// targets, or once the draining timeout expired
+func (r *podReconciler) reconcileDraining(ctx context.Context, pod *corev1.Pod) error {
+	if !k8s.HasFinalizer(pod, gateway.LatticeTargetDrainingFinalizer) {
+		return nil
+	}
+
+	services, err := gateway.GetPodTargetServices(ctx, r.client, pod)
+	if err != nil {
+		return err
+	}
+	timeout, err := gateway.GetDrainingTimeout(ctx, r.client, services)
+	if err != nil {
+		return err
+	}
+	remaining := time.Until(pod.DeletionTimestamp.Add(timeout))
+	if remaining <= 0 {
+		r.log.Infow("draining timeout expired", "name", k8s.NamespacedName(pod), "timeout", timeout)
+		return r.finalizerManager.RemoveFinalizers(ctx, pod, gateway.LatticeTargetDrainingFinalizer)
+	}
+
+	targets, err := r.getPodTargets(ctx, pod)
+	if err != nil {
+		return err
+	}
+	if !gateway.IsPodTargetRegistered(podIPs(pod), targets) {
+		r.log.Infow("targets drained", "name", k8s.NamespacedName(pod))
+		return r.finalizerManager.RemoveFinalizers(ctx, pod, gateway.LatticeTargetDrainingFinalizer)
+	}
+
+	if remaining > podDrainingRetryInterval {
+		remaining = podDrainingRetryInterval
+	}
+	return lattice_runtime.NewRequeueNeededAfter("waiting for lattice targets to drain", remaining)
+}
+
+// reconcileReadinessGate sets the lattice-target-ready condition of pods with the readiness gate
+func (r *podReconciler) reconcileReadinessGate(ctx context.Context, pod *corev1.Pod) error {
+	// once ready, the gate stays ready like the readiness gates of the AWS Load Balancer Controller,
+	// the health of the pod is up to its readiness probes from then on
+	if !gateway.IsPendingLatticeTargetReadiness(pod) || pod.Status.PodIP == "" {
+		return nil
+	}
+
+	targets, err := r.getPodTargets(ctx, pod)
+	if err != nil {
+		return err
+	}
+	ready, message := gateway.GetLatticeTargetReadiness(podIPs(pod), targets)
+
+	podOld := pod.DeepCopy()
+	if gateway.SetLatticeTargetReadyCondition(pod, ready, message, metav1.Now()) {
+		if err := r.client.Status().Patch(ctx, pod, client.StrategicMergeFrom(podOld)); err != nil {
+			return fmt.Errorf("failed to update readiness gate of pod %s, %w", k8s.NamespacedName(pod), err)
+		}
+		r.log.Infow("updated lattice target readiness gate", "name", k8s.NamespacedName(pod), "ready", ready, "message", message)
+	}
+
+	if !ready {
+		return lattice_runtime.NewRequeueNeededAfter(message, podReadinessRetryInterval)
+	}
+	return nil
+}
+
+func podIPs(pod *corev1.Pod) []string {
+	var ips []string
+	for _, podIP := range pod.Status.PodIPs {
+		ips = append(ips, podIP.IP)
+	}
+	return ips
+}
+
+// getPodTargets returns the targets of the pod in the IP target groups of its Services, by target group ID
+func (r *podReconciler) getPodTargets(ctx context.Context, pod *corev1.Pod) (map[string][]*vpclattice.TargetSummary, error) {
+	services, err := gateway.GetPodTargetServices(ctx, r.client, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	var podTargets []*vpclattice.Target
+	for _, ip := range podIPs(pod) {
+		podTargets = append(podTargets, &vpclattice.Target{Id: awssdk.String(ip)})
+	}
 
+	targets := make(map[string][]*vpclattice.TargetSummary)
+	for _, svc := range services {
+		tgTypes, err := gateway.GetServiceTargetGroupTypes(ctx, r.client, k8s.NamespacedName(svc))
+		if err != nil {
+			return nil, err
+		}
+		for _, tg := range r.datastore.GetTargetGroupsByName(latticestore.TargetGroupName(svc.Name, svc.Namespace)) {
+			// instance target groups register the nodes of the pod, not its IPs
+			if tg.ID == "" || tgTypes[tg.TargetGroupKey.RouteName] != model.TargetGroupTypeIP {
+				continue
+			}
+			tgTargets, err := r.cloud.Lattice().ListTargetsAsList(ctx, &vpclattice.ListTargetsInput{
+				TargetGroupIdentifier: awssdk.String(tg.ID),
+				Targets:               podTargets,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list targets of target group %s, %w", tg.ID, err)
+			}
+			targets[tg.ID] = tgTargets
+		}
 	}
-	return ctrl.Result{}, nil
+	return targets, nil
 }
@@ -0,0 +1,378 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	pkg_builder "sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	lattice_runtime "github.com/aws/aws-application-networking-k8s/pkg/runtime"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const (
+	policyConditionProgrammed = "Programmed"
+	policyReasonProgrammed    = "Programmed"
+	policyReasonPending       = "Pending"
+)
+
+// policyObject is a policy resource, e.g. a TargetGroupPolicy
+type policyObject interface {
+	client.Object
+	core.Policy
+}
+
+type policyObjectList interface {
+	client.ObjectList
+	core.PolicyList
+}
+
+// policyTargetKind is a kind of object policies can be attached to
+type policyTargetKind struct {
+	group gwv1beta1.Group
+	kind  gwv1beta1.Kind
+	// newObject returns an empty object of the kind, nil for a Namespace, which always exists for policies in it
+	newObject func() client.Object
+}
+
+var (
+	serviceTargetKind   = policyTargetKind{corev1.GroupName, "Service", func() client.Object { return &corev1.Service{} }}
+	namespaceTargetKind = policyTargetKind{corev1.GroupName, "Namespace", nil}
+	gatewayTargetKind   = policyTargetKind{gwv1beta1.GroupName, "Gateway", func() client.Object { return &gwv1beta1.Gateway{} }}
+	httpRouteTargetKind = policyTargetKind{gwv1beta1.GroupName, "HTTPRoute", func() client.Object { return &gwv1beta1.HTTPRoute{} }}
+	grpcRouteTargetKind = policyTargetKind{gwv1beta1.GroupName, "GRPCRoute", func() client.Object { return &gwv1alpha2.GRPCRoute{} }}
+
+	serviceExportTargetKind = policyTargetKind{mcsv1alpha1.GroupName, "ServiceExport", func() client.Object { return &mcsv1alpha1.ServiceExport{} }}
+)
+
+// targetCreatedOrDeletedPredicate passes the events of targets coming or going, for the controllers of policies which
+// do not depend on the state of their targets
+var targetCreatedOrDeletedPredicate = predicate.Funcs{
+	UpdateFunc: func(event.UpdateEvent) bool { return false },
+}
+
+// policyType describes a kind of policy for the reconcilers of their status
+type policyType struct {
+	newPolicy     func() policyObject
+	newPolicyList func() policyObjectList
+	targetKinds   []policyTargetKind
+	// validate returns a non-empty message describing why the policy is invalid, beyond its targetRef
+	validate func(policy policyObject) string
+	// programmed tells whether an accepted policy took effect, with a message describing its state.
+	// Policies applied by their own controller, which sets their Programmed condition, do not need it.
+	programmed func(ctx context.Context, k8sClient client.Client, policy policyObject) (bool, string, error)
+}
+
+// policyReconciler sets the status conditions of policies which are applied by the reconcilers of their targets
+type policyReconciler struct {
+	log        gwlog.Logger
+	client     client.Client
+	policyType policyType
+	mapper     *policyMapper
+}
+
+// newPolicyReconciler returns the reconciler of the policies of policyType, and a builder of its controller
+// watching the policies and their targets, for further watches of what the policies are programmed from
+func newPolicyReconciler(log gwlog.Logger, mgr ctrl.Manager, pt policyType) (*policyReconciler, *pkg_builder.Builder) {
+	r := &policyReconciler{
+		log:        log,
+		client:     mgr.GetClient(),
+		policyType: pt,
+		mapper:     &policyMapper{log: log, client: mgr.GetClient(), policyType: pt},
+	}
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(pt.newPolicy(), pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	return r, r.mapper.watchTargets(builder)
+}
+
+func (r *policyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.log.Infow("reconcile", "name", req.Name)
+	recErr := r.reconcile(ctx, req)
+	res, retryErr := lattice_runtime.HandleReconcileError(recErr)
+	if res.RequeueAfter != 0 {
+		r.log.Infow("requeue request", "name", req.Name, "requeueAfter", res.RequeueAfter)
+	} else if res.Requeue {
+		r.log.Infow("requeue request", "name", req.Name)
+	} else if retryErr == nil {
+		r.log.Infow("reconciled", "name", req.Name)
+	}
+	return res, retryErr
+}
+
+func (r *policyReconciler) reconcile(ctx context.Context, req ctrl.Request) error {
+	policy := r.policyType.newPolicy()
+	if err := r.client.Get(ctx, req.NamespacedName, policy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !policy.GetDeletionTimestamp().IsZero() {
+		return nil
+	}
+
+	reason, message, err := acceptPolicy(ctx, r.client, policy, r.policyType)
+	if err != nil {
+		return err
+	}
+	programmed := false
+	if reason == gwv1alpha2.PolicyReasonAccepted {
+		programmed, message, err = r.policyType.programmed(ctx, r.client, policy)
+		if err != nil {
+			return err
+		}
+	}
+	return updatePolicyStatus(ctx, r.client, policy, reason, programmed, message)
+}
+
+// acceptPolicy evaluates whether the policy is accepted, per Gateway API policy attachment, returning the reason
+// of its Accepted condition, with a message describing it
+func acceptPolicy(
+	ctx context.Context,
+	k8sClient client.Client,
+	policy policyObject,
+	pt policyType,
+) (gwv1alpha2.PolicyConditionReason, string, error) {
+	message := validatePolicyTargetRef(policy, pt.targetKinds)
+	if message == "" && pt.validate != nil {
+		message = pt.validate(policy)
+	}
+	if message != "" {
+		return gwv1alpha2.PolicyReasonInvalid, message, nil
+	}
+
+	targetRefExists, err := policyTargetRefExists(ctx, k8sClient, policy, pt.targetKinds)
+	if err != nil {
+		return "", "", err
+	}
+	if !targetRefExists {
+		return gwv1alpha2.PolicyReasonTargetNotFound, "The targetRef could not be found", nil
+	}
+
+	conflicting, err := gateway.GetConflictingPolicy(ctx, k8sClient, policy)
+	if err != nil {
+		return "", "", err
+	}
+	if conflicting != nil {
+		message := fmt.Sprintf("The targetRef already has the policy %s, which is older and takes precedence",
+			conflicting.GetNamespacedName())
+		return gwv1alpha2.PolicyReasonConflicted, message, nil
+	}
+
+	return gwv1alpha2.PolicyReasonAccepted, config.LatticeGatewayControllerName, nil
+}
+
+// validatePolicyTargetRef returns a non-empty message describing why the targetRef of policy is invalid
+func validatePolicyTargetRef(policy policyObject, targetKinds []policyTargetKind) string {
+	targetRef := policy.GetTargetRef()
+	if targetRef == nil {
+		return "The targetRef is required"
+	}
+	if targetRef.Namespace != nil && string(*targetRef.Namespace) != policy.GetNamespace() {
+		return "The targetRef's namespace does not match the policy's namespace"
+	}
+	targetKind, ok := findPolicyTargetKind(targetRef.Kind, targetKinds)
+	if !ok {
+		var kinds []string
+		for _, tk := range targetKinds {
+			kinds = append(kinds, string(tk.kind))
+		}
+		if len(kinds) > 1 {
+			kinds[len(kinds)-1] = "or " + kinds[len(kinds)-1]
+		}
+		return "The targetRef's Kind must be " + strings.Join(kinds, ", ")
+	}
+	if targetRef.Group != targetKind.group {
+		return fmt.Sprintf("The targetRef's Group must be %q for Kind %s", targetKind.group, targetKind.kind)
+	}
+	return ""
+}
+
+func findPolicyTargetKind(kind gwv1beta1.Kind, targetKinds []policyTargetKind) (policyTargetKind, bool) {
+	for _, tk := range targetKinds {
+		if tk.kind == kind {
+			return tk, true
+		}
+	}
+	return policyTargetKind{}, false
+}
+
+// policyTargetRefExists tells whether the targetRef of policy, which is valid, exists
+func policyTargetRefExists(
+	ctx context.Context,
+	k8sClient client.Client,
+	policy policyObject,
+	targetKinds []policyTargetKind,
+) (bool, error) {
+	targetRef := policy.GetTargetRef()
+	targetKind, ok := findPolicyTargetKind(targetRef.Kind, targetKinds)
+	if !ok {
+		return false, fmt.Errorf("policy targetRef is for an unsupported Kind: %s", targetRef.Kind)
+	}
+	if targetKind.newObject == nil {
+		return true, nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: policy.GetNamespace(),
+		Name:      string(targetRef.Name),
+	}
+	err := k8sClient.Get(ctx, key, targetKind.newObject())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, err
+	}
+	return err == nil, nil
+}
+
+// updatePolicyStatus sets the Accepted condition according to the given reason,
+// and the Programmed condition according to whether the policy took effect.
+func updatePolicyStatus(
+	ctx context.Context,
+	k8sClient client.Client,
+	policy policyObject,
+	reason gwv1alpha2.PolicyConditionReason,
+	programmed bool,
+	message string,
+) error {
+	acceptedStatus := metav1.ConditionTrue
+	if reason != gwv1alpha2.PolicyReasonAccepted {
+		acceptedStatus = metav1.ConditionFalse
+	}
+
+	programmedStatus := metav1.ConditionFalse
+	programmedReason := policyReasonPending
+	if programmed {
+		programmedStatus = metav1.ConditionTrue
+		programmedReason = policyReasonProgrammed
+	} else if reason != gwv1alpha2.PolicyReasonAccepted {
+		programmedReason = string(reason)
+	}
+
+	conditions := utils.GetNewConditions(policy.GetStatusConditions(), metav1.Condition{
+		Type:               string(gwv1alpha2.PolicyConditionAccepted),
+		ObservedGeneration: policy.GetGeneration(),
+		Message:            message,
+		Status:             acceptedStatus,
+		Reason:             string(reason),
+	})
+	conditions = utils.GetNewConditions(conditions, metav1.Condition{
+		Type:               policyConditionProgrammed,
+		ObservedGeneration: policy.GetGeneration(),
+		Message:            message,
+		Status:             programmedStatus,
+		Reason:             programmedReason,
+	})
+	policy.SetStatusConditions(conditions)
+
+	if err := k8sClient.Status().Update(ctx, policy); err != nil {
+		return fmt.Errorf("failed to set %s Accepted status to %s and reason to %s, %w",
+			policy.GetNamespacedName(), acceptedStatus, reason, err)
+	}
+
+	return nil
+}
+
+// policyMapper maps events to reconcile requests of the policies of policyType
+type policyMapper struct {
+	log        gwlog.Logger
+	client     client.Client
+	policyType policyType
+}
+
+// watchTargets adds to builder the watches of the targets of the policies, as policies need to be re-evaluated when
+// their target comes or goes, and of the policies themselves, as policies attached to the same target conflict.
+func (m *policyMapper) watchTargets(builder *pkg_builder.Builder, targetPredicates ...predicate.Predicate) *pkg_builder.Builder {
+	builder.Watches(&source.Kind{Type: m.policyType.newPolicy()}, handler.EnqueueRequestsFromMapFunc(m.mapToPoliciesOfSameTarget),
+		pkg_builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+	for _, tk := range m.policyType.targetKinds {
+		if tk.newObject == nil {
+			continue
+		}
+		builder.Watches(&source.Kind{Type: tk.newObject()}, handler.EnqueueRequestsFromMapFunc(m.mapToPoliciesOfTarget(tk)),
+			pkg_builder.WithPredicates(targetPredicates...))
+	}
+	return builder
+}
+
+func (m *policyMapper) mapToPoliciesOfTarget(tk policyTargetKind) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		return m.policiesAttachedTo(tk.group, tk.kind, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}, nil)
+	}
+}
+
+func (m *policyMapper) mapToPoliciesOfSameTarget(obj client.Object) []reconcile.Request {
+	policy, ok := obj.(policyObject)
+	if !ok || policy.GetTargetRef() == nil {
+		return nil
+	}
+	targetRef := policy.GetTargetRef()
+	target := types.NamespacedName{Namespace: policy.GetNamespace(), Name: string(targetRef.Name)}
+	return m.policiesAttachedTo(targetRef.Group, targetRef.Kind, target, policy)
+}
+
+// mapToPoliciesInNamespaces returns a map function enqueueing all policies of the namespaces returned by namespaces
+func (m *policyMapper) mapToPoliciesInNamespaces(namespaces func(obj client.Object) []string) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		var requests []reconcile.Request
+		for _, namespace := range namespaces(obj) {
+			policies, err := m.listPolicies(namespace)
+			if err != nil {
+				return requests
+			}
+			for _, p := range policies {
+				requests = append(requests, reconcile.Request{NamespacedName: p.GetNamespacedName()})
+			}
+		}
+		return requests
+	}
+}
+
+func (m *policyMapper) policiesAttachedTo(
+	group gwv1beta1.Group,
+	kind gwv1beta1.Kind,
+	target types.NamespacedName,
+	except core.Policy,
+) []reconcile.Request {
+	policies, err := m.listPolicies(target.Namespace)
+	if err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, p := range policies {
+		if except != nil && p.GetNamespacedName() == except.GetNamespacedName() {
+			continue
+		}
+		if gateway.IsPolicyAttachedTo(p, group, kind, target) {
+			requests = append(requests, reconcile.Request{NamespacedName: p.GetNamespacedName()})
+		}
+	}
+	return requests
+}
+
+func (m *policyMapper) listPolicies(namespace string) ([]core.Policy, error) {
+	policyList := m.policyType.newPolicyList()
+	if err := m.client.List(context.Background(), policyList, client.InNamespace(namespace)); err != nil {
+		if !meta.IsNoMatchError(err) {
+			m.log.Errorf("Failed to list %T in namespace %s, %s", policyList, namespace, err)
+		}
+		return nil, err
+	}
+	return policyList.GetItems(), nil
+}
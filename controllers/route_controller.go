@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
@@ -28,9 +29,9 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -39,6 +40,8 @@ import (
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
+	"golang.org/x/exp/slices"
+
 	"sigs.k8s.io/external-dns/endpoint"
 
 	"github.com/aws/aws-application-networking-k8s/controllers/eventhandlers"
@@ -58,6 +61,7 @@ import (
 var routeTypeToFinalizer = map[core.RouteType]string{
 	core.HttpRouteType: "httproute.k8s.aws/resources",
 	core.GrpcRouteType: "grpcroute.k8s.aws/resources",
+	core.TlsRouteType:  "tlsroute.k8s.aws/resources",
 }
 
 type routeReconciler struct {
@@ -96,15 +100,34 @@ func RegisterAllRouteControllers(
 	mgrClient := mgr.GetClient()
 	gwEventHandler := eventhandlers.NewEnqueueRequestGatewayEvent(log, mgrClient)
 	svcEventHandler := eventhandlers.NewServiceEventHandler(log, mgrClient)
-
-	routeInfos := []struct {
+	fixedResponseEventHandler := eventhandlers.NewFixedResponseEventHandler(log, mgrClient)
+	referenceGrantEventHandler := eventhandlers.NewReferenceGrantEventHandler(log, mgrClient)
+	namespaceEventHandler := eventhandlers.NewNamespaceEventHandler(log, mgrClient)
+	nodeEventHandler := eventhandlers.NewNodeEventHandler(log, mgrClient)
+	podEventHandler := eventhandlers.NewPodEventHandler(log, mgrClient)
+	latticeTargetGroupEventHandler := eventhandlers.NewLatticeTargetGroupEventHandler(log, mgrClient)
+	rolloutEventHandler := eventhandlers.NewRolloutEventHandler(log)
+	lambdaFunctionEventHandler := eventhandlers.NewLambdaFunctionEventHandler(log, mgrClient)
+
+	type routeInfo struct {
 		routeType      core.RouteType
 		gatewayApiType client.Object
-	}{
+	}
+	routeInfos := []routeInfo{
 		{core.HttpRouteType, &gwv1beta1.HTTPRoute{}},
 		{core.GrpcRouteType, &gwv1alpha2.GRPCRoute{}},
 	}
 
+	// TLSRoute is in the experimental channel of the Gateway API, its CRD is not always installed
+	if ok, err := k8s.IsGVKSupported(mgr, gwv1alpha2.GroupVersion.String(), "TLSRoute"); ok {
+		routeInfos = append(routeInfos, routeInfo{core.TlsRouteType, &gwv1alpha2.TLSRoute{}})
+	} else {
+		if err != nil {
+			return err
+		}
+		log.Infof("TLSRoute CRD is not installed, skipping TLSRoute controller")
+	}
+
 	for _, routeInfo := range routeInfos {
 		reconciler := routeReconciler{
 			routeType:        routeInfo.routeType,
@@ -120,6 +143,12 @@ func RegisterAllRouteControllers(
 			cloud:            cloud,
 		}
 
+		// TargetGroupPolicies attached to a Gateway look up the routes of a Service on every pod event
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), routeInfo.gatewayApiType,
+			gateway.RouteServiceBackendIndex, gateway.IndexRouteServiceBackends); err != nil {
+			return err
+		}
+
 		svcImportEventHandler := eventhandlers.NewServiceImportEventHandler(log, mgrClient)
 
 		builder := ctrl.NewControllerManagedBy(mgr).
@@ -127,7 +156,10 @@ func RegisterAllRouteControllers(
 			Watches(&source.Kind{Type: &gwv1beta1.Gateway{}}, gwEventHandler).
 			Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler.MapToRoute(routeInfo.routeType)).
 			Watches(&source.Kind{Type: &mcsv1alpha1.ServiceImport{}}, svcImportEventHandler.MapToRoute(routeInfo.routeType)).
-			Watches(&source.Kind{Type: &corev1.Endpoints{}}, svcEventHandler.MapToRoute(routeInfo.routeType))
+			Watches(&source.Kind{Type: &discoveryv1.EndpointSlice{}}, svcEventHandler.MapToRoute(routeInfo.routeType)).
+			Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventHandler.MapToRoute(routeInfo.routeType)).
+			Watches(&source.Kind{Type: &corev1.Pod{}}, podEventHandler.MapToRoute(routeInfo.routeType)).
+			Watches(&source.Kind{Type: &corev1.Namespace{}}, namespaceEventHandler.MapToRoute(routeInfo.routeType))
 
 		if ok, err := k8s.IsGVKSupported(mgr, v1alpha1.GroupVersion.String(), v1alpha1.TargetGroupPolicyKind); ok {
 			builder.Watches(&source.Kind{Type: &v1alpha1.TargetGroupPolicy{}}, svcEventHandler.MapToRoute(routeInfo.routeType))
@@ -138,6 +170,51 @@ func RegisterAllRouteControllers(
 			log.Infof("TargetGroupPolicy CRD is not installed, skipping watch")
 		}
 
+		if ok, err := k8s.IsGVKSupported(mgr, v1alpha1.GroupVersion.String(), v1alpha1.LatticeFixedResponseKind); ok {
+			builder.Watches(&source.Kind{Type: &v1alpha1.LatticeFixedResponse{}}, fixedResponseEventHandler.MapToRoute(routeInfo.routeType))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof("LatticeFixedResponse CRD is not installed, skipping watch")
+		}
+
+		if ok, err := k8s.IsGVKSupported(mgr, v1alpha1.GroupVersion.String(), v1alpha1.LambdaFunctionKind); ok {
+			builder.Watches(&source.Kind{Type: &v1alpha1.LambdaFunction{}}, lambdaFunctionEventHandler.MapToRoute(routeInfo.routeType))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof("LambdaFunction CRD is not installed, skipping watch")
+		}
+
+		if ok, err := k8s.IsGVKSupported(mgr, v1alpha1.GroupVersion.String(), v1alpha1.LatticeTargetGroupKind); ok {
+			builder.Watches(&source.Kind{Type: &v1alpha1.LatticeTargetGroup{}}, latticeTargetGroupEventHandler.MapToRoute(routeInfo.routeType))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof("LatticeTargetGroup CRD is not installed, skipping watch")
+		}
+
+		if ok, err := k8s.IsGVKSupported(mgr, v1alpha1.GroupVersion.String(), v1alpha1.LatticeRolloutKind); ok {
+			builder.Watches(&source.Kind{Type: &v1alpha1.LatticeRollout{}}, rolloutEventHandler.MapToRoute(routeInfo.routeType))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof("LatticeRollout CRD is not installed, skipping watch")
+		}
+
+		if ok, err := k8s.IsGVKSupported(mgr, gwv1beta1.GroupVersion.String(), "ReferenceGrant"); ok {
+			builder.Watches(&source.Kind{Type: &gwv1beta1.ReferenceGrant{}}, referenceGrantEventHandler.MapToRoute(routeInfo.routeType))
+		} else {
+			if err != nil {
+				return err
+			}
+			log.Infof("ReferenceGrant CRD is not installed, skipping watch")
+		}
+
 		if ok, err := k8s.IsGVKSupported(mgr, "externaldns.k8s.io/v1alpha1", "DNSEndpoint"); ok {
 			builder.Owns(&endpoint.DNSEndpoint{})
 		} else {
@@ -156,9 +233,13 @@ func RegisterAllRouteControllers(
 	return nil
 }
 
-//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes;httproutes,verbs=get;list;watch;create;update;patch;delete
-//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes/status;httproutes/status,verbs=get;update;patch
-//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes/finalizers;httproutes/finalizers,verbs=update
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes;httproutes;tlsroutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes/status;httproutes/status;tlsroutes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes/finalizers;httproutes/finalizers;tlsroutes/finalizers,verbs=update
+//+kubebuilder:rbac:groups=application-networking.k8s.aws,resources=latticefixedresponses;lambdafunctions;latticetargetgroups,verbs=get;list;watch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=namespaces;nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 
 func (r *routeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	return lattice_runtime.HandleReconcileError(r.reconcile(ctx, req))
@@ -176,7 +257,11 @@ func (r *routeReconciler) reconcile(ctx context.Context, req ctrl.Request) error
 		return client.IgnoreNotFound(err)
 	}
 
-	if !r.isRouteRelevant(ctx, route) {
+	relevant, err := r.isRouteRelevant(ctx, route)
+	if err != nil {
+		return err
+	}
+	if !relevant {
 		return nil
 	}
 
@@ -210,29 +295,25 @@ func (r *routeReconciler) getRoute(ctx context.Context, req ctrl.Request) (core.
 		return core.GetHTTPRoute(ctx, r.client, req.NamespacedName)
 	case core.GrpcRouteType:
 		return core.GetGRPCRoute(ctx, r.client, req.NamespacedName)
+	case core.TlsRouteType:
+		return core.GetTLSRoute(ctx, r.client, req.NamespacedName)
 	default:
 		return nil, fmt.Errorf("unknown route type for type %s", string(r.routeType))
 	}
 }
 
 func updateRouteListenerStatus(ctx context.Context, k8sClient client.Client, route core.Route) error {
-	gw := &gwv1beta1.Gateway{}
-
-	gwNamespace := route.Namespace()
-	if route.Spec().ParentRefs()[0].Namespace != nil {
-		gwNamespace = string(*route.Spec().ParentRefs()[0].Namespace)
-	}
-	gwName := types.NamespacedName{
-		Namespace: gwNamespace,
-		// TODO assume one parent for now and point to service network
-		Name: string(route.Spec().ParentRefs()[0].Name),
+	parents, err := gateway.GetRouteParents(ctx, k8sClient, route)
+	if err != nil {
+		return fmt.Errorf("update route listener: %w", err)
 	}
 
-	if err := k8sClient.Get(ctx, gwName, gw); err != nil {
-		return fmt.Errorf("update route listener: gw not found, gw: %s, err: %w", gwName, err)
+	for _, parent := range parents {
+		if err := UpdateGWListenerStatus(ctx, k8sClient, parent.Gateway); err != nil {
+			return err
+		}
 	}
-
-	return UpdateGWListenerStatus(ctx, k8sClient, gw)
+	return nil
 }
 
 func (r *routeReconciler) cleanupRouteResources(ctx context.Context, route core.Route) error {
@@ -240,48 +321,26 @@ func (r *routeReconciler) cleanupRouteResources(ctx context.Context, route core.
 	return err
 }
 
-func (r *routeReconciler) isRouteRelevant(ctx context.Context, route core.Route) bool {
+// isRouteRelevant returns an error when the parentRefs of the route could not be resolved, so the route is
+// reconciled again rather than ignored
+func (r *routeReconciler) isRouteRelevant(ctx context.Context, route core.Route) (bool, error) {
 	if len(route.Spec().ParentRefs()) == 0 {
 		r.log.Infof("Ignore Route which has no ParentRefs gateway %s ", route.Name())
-		return false
+		return false, nil
 	}
 
-	gw := &gwv1beta1.Gateway{}
-
-	gwNamespace := route.Namespace()
-	if route.Spec().ParentRefs()[0].Namespace != nil {
-		gwNamespace = string(*route.Spec().ParentRefs()[0].Namespace)
-	}
-	gwName := types.NamespacedName{
-		Namespace: gwNamespace,
-		Name:      string(route.Spec().ParentRefs()[0].Name),
-	}
-
-	if err := r.client.Get(ctx, gwName, gw); err != nil {
-		r.log.Infof("Could not find gateway %s with err %s. Ignoring route %+v whose ParentRef gateway object"+
-			" is not defined.", gwName.String(), err, route.Spec())
-		return false
-	}
-
-	// make sure gateway is an aws-vpc-lattice
-	gwClass := &gwv1beta1.GatewayClass{}
-	gwClassName := types.NamespacedName{
-		Namespace: defaultNameSpace,
-		Name:      string(gw.Spec.GatewayClassName),
-	}
-
-	if err := r.client.Get(ctx, gwClassName, gwClass); err != nil {
-		r.log.Infof("Ignore Route not controlled by any GatewayClass %s, %s", route.Name(), route.Namespace())
-		return false
+	parents, err := gateway.GetRouteParents(ctx, r.client, route)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve parentRefs of route %s-%s: %w", route.Name(), route.Namespace(), err)
 	}
 
-	if gwClass.Spec.ControllerName == config.LatticeGatewayControllerName {
+	if len(parents) > 0 {
 		r.log.Infof("Found aws-vpc-lattice for Route for %s, %s", route.Name(), route.Namespace())
-		return true
+		return true, nil
 	}
 
 	r.log.Infof("Ignore non aws-vpc-lattice Route %s, %s", route.Name(), route.Namespace())
-	return false
+	return false, nil
 }
 
 func (r *routeReconciler) buildAndDeployModel(
@@ -306,6 +365,7 @@ func (r *routeReconciler) buildAndDeployModel(
 	}
 
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
+		recordTargetFailureEvents(r.eventRecorder, route.K8sObject(), err)
 		if errors.As(err, &lattice.RetryErr) {
 			r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeNormal,
 				k8s.RouteEventReasonRetryReconcile, "retry reconcile...")
@@ -331,23 +391,35 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 	backendRefIPFamiliesErr := r.validateBackendRefsIpFamilies(ctx, route)
 
 	if backendRefIPFamiliesErr != nil {
-		httpRouteOld := route.DeepCopy()
-
-		route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+		if err := r.updateRouteNotAccepted(ctx, route, gwv1beta1.RouteReasonUnsupportedValue,
+			backendRefIPFamiliesErr); err != nil {
+			return err
+		}
+		return backendRefIPFamiliesErr
+	}
 
-		route.Status().UpdateRouteCondition(metav1.Condition{
-			Type:               string(gwv1beta1.RouteConditionAccepted),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: route.K8sObject().GetGeneration(),
-			Reason:             string(gwv1beta1.RouteReasonUnsupportedValue),
-			Message:            fmt.Sprintf("Dual stack Service is not supported"),
-		})
+	latticeTargetGroupErr := gateway.ValidateLatticeTargetGroupBackendRefs(ctx, r.client, r.cloud, route)
 
-		if err := r.client.Status().Patch(ctx, route.K8sObject(), client.MergeFrom(httpRouteOld.K8sObject())); err != nil {
-			return errors.Wrapf(err, "failed to update httproute status")
+	if latticeTargetGroupErr != nil {
+		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning,
+			k8s.RouteEventReasonInvalidBackendRef, latticeTargetGroupErr.Error())
+		if err := r.updateRouteNotAccepted(ctx, route, gwv1beta1.RouteReasonUnsupportedValue,
+			latticeTargetGroupErr); err != nil {
+			return err
 		}
+		return latticeTargetGroupErr
+	}
 
-		return backendRefIPFamiliesErr
+	parents, err := gateway.GetRouteParents(ctx, r.client, route)
+	if err != nil {
+		return err
+	}
+	if len(parents) == 0 {
+		// the gateways went away since the route was found relevant, reconcile again instead of deprovisioning
+		return lattice.RetryErr
+	}
+	if !slices.ContainsFunc(parents, (*gateway.RouteParent).Accepted) {
+		return r.reconcileNotAccepted(ctx, req, route)
 	}
 
 	if _, _, err := r.buildAndDeployModel(ctx, route); err != nil {
@@ -374,6 +446,42 @@ func (r *routeReconciler) reconcileUpsert(ctx context.Context, req ctrl.Request,
 	return nil
 }
 
+// reconcileNotAccepted deprovisions the lattice service of a route which none of its parents accepts, the same way
+// as for a deleted route, so a route no longer allowed by the listeners of its gateways stops receiving traffic
+func (r *routeReconciler) reconcileNotAccepted(ctx context.Context, req ctrl.Request, route core.Route) error {
+	r.log.Infof("No VPC Lattice gateway accepts route %s-%s, deprovisioning its lattice service",
+		route.Name(), route.Namespace())
+
+	deprovisioned := route.DeepCopy()
+	now := metav1.Now()
+	deprovisioned.K8sObject().SetDeletionTimestamp(&now)
+	if err := r.cleanupRouteResources(ctx, deprovisioned); err != nil {
+		return fmt.Errorf("failed to deprovision route %s-%s: %w", route.Name(), route.Namespace(), err)
+	}
+
+	// the lattice service is gone, so its DNS name is no longer an address of the gateways
+	if _, ok := route.K8sObject().GetAnnotations()[LatticeAssignedDomainName]; ok {
+		routeOld := route.DeepCopy()
+		delete(route.K8sObject().GetAnnotations(), LatticeAssignedDomainName)
+		if err := r.client.Patch(ctx, route.K8sObject(), client.MergeFrom(routeOld.K8sObject())); err != nil {
+			return fmt.Errorf("failed to update route status due to err %w", err)
+		}
+	}
+
+	if err := updateRouteListenerStatus(ctx, r.client, route); err != nil {
+		return err
+	}
+
+	// every parent sets the reason it does not accept the route
+	if err := r.updateRouteNotAccepted(ctx, route, gwv1beta1.RouteReasonNoMatchingParent,
+		errors.New("no VPC Lattice gateway accepts the route")); err != nil {
+		return err
+	}
+
+	r.log.Infow("reconciled", "name", req.Name)
+	return nil
+}
+
 func (r *routeReconciler) updateRouteStatus(ctx context.Context, dns string, route core.Route) error {
 	r.log.Debugf("Updating route %s-%s with DNS %s", route.Name(), route.Namespace(), dns)
 	routeOld := route.DeepCopy()
@@ -388,32 +496,56 @@ func (r *routeReconciler) updateRouteStatus(ctx context.Context, dns string, rou
 	}
 	routeOld = route.DeepCopy()
 
-	route.Status().UpdateParentRefs(route.Spec().ParentRefs()[0], config.LatticeGatewayControllerName)
+	parents, err := gateway.GetRouteParents(ctx, r.client, route)
+	if err != nil {
+		return err
+	}
+	setRouteParents(route, parents)
 
-	// Update listener Status
-	if err := updateRouteListenerStatus(ctx, r.client, route); err != nil {
-		route.Status().UpdateRouteCondition(metav1.Condition{
-			Type:               string(gwv1beta1.RouteConditionAccepted),
-			Status:             metav1.ConditionFalse,
-			ObservedGeneration: route.K8sObject().GetGeneration(),
-			Reason:             string(gwv1beta1.RouteReasonNoMatchingParent),
-			Message:            fmt.Sprintf("Could not match gateway %s: %s", route.Spec().ParentRefs()[0].Name, err),
-		})
-	} else {
-		route.Status().UpdateRouteCondition(metav1.Condition{
+	unpermitted, err := gateway.GetUnpermittedBackendRefs(ctx, r.client, route)
+	if err != nil {
+		return err
+	}
+	resolvedRefsCondition := metav1.Condition{
+		Type:               string(gwv1beta1.RouteConditionResolvedRefs),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: route.K8sObject().GetGeneration(),
+		Reason:             string(gwv1beta1.RouteReasonResolvedRefs),
+	}
+	if len(unpermitted) > 0 {
+		resolvedRefsCondition.Status = metav1.ConditionFalse
+		resolvedRefsCondition.Reason = string(gwv1beta1.RouteReasonRefNotPermitted)
+		resolvedRefsCondition.Message = fmt.Sprintf("No ReferenceGrant permits backendRefs %s",
+			gateway.FormatBackendRefs(route, unpermitted))
+		r.eventRecorder.Event(route.K8sObject(), corev1.EventTypeWarning,
+			k8s.RouteEventReasonRefNotPermitted, resolvedRefsCondition.Message)
+	}
+
+	message := routeDnsMessage(dns, route)
+	if resolvedRefsCondition.Message == "" {
+		resolvedRefsCondition.Message = message
+	}
+	for _, parent := range parents {
+		if !parent.Accepted() {
+			setRouteParentNotAccepted(route, parent.ParentRef, parent.Reason, parent.Err)
+			continue
+		}
+
+		// Update listener Status
+		if err := UpdateGWListenerStatus(ctx, r.client, parent.Gateway); err != nil {
+			setRouteParentNotAccepted(route, parent.ParentRef, gwv1beta1.RouteReasonNoMatchingParent,
+				fmt.Errorf("Could not match gateway %s: %w", parent.ParentRef.Name, err))
+			continue
+		}
+
+		route.Status().UpdateRouteCondition(parent.ParentRef, metav1.Condition{
 			Type:               string(gwv1beta1.RouteConditionAccepted),
 			Status:             metav1.ConditionTrue,
 			ObservedGeneration: route.K8sObject().GetGeneration(),
 			Reason:             string(gwv1beta1.RouteReasonAccepted),
-			Message:            fmt.Sprintf("DNS Name: %s", dns),
-		})
-		route.Status().UpdateRouteCondition(metav1.Condition{
-			Type:               string(gwv1beta1.RouteConditionResolvedRefs),
-			Status:             metav1.ConditionTrue,
-			ObservedGeneration: route.K8sObject().GetGeneration(),
-			Reason:             string(gwv1beta1.RouteReasonResolvedRefs),
-			Message:            fmt.Sprintf("DNS Name: %s", dns),
+			Message:            message,
 		})
+		route.Status().UpdateRouteCondition(parent.ParentRef, resolvedRefsCondition)
 	}
 
 	if err := r.client.Status().Patch(ctx, route.K8sObject(), client.MergeFrom(routeOld.K8sObject())); err != nil {
@@ -424,6 +556,75 @@ func (r *routeReconciler) updateRouteStatus(ctx context.Context, dns string, rou
 	return nil
 }
 
+// routeDnsMessage tells the DNS name of the lattice service and which hostname of the route became
+// its custom domain name, the other hostnames only resolve through DNSEndpoint records
+func routeDnsMessage(dns string, route core.Route) string {
+	hostnames := route.Spec().Hostnames()
+	if len(hostnames) == 0 {
+		return fmt.Sprintf("DNS Name: %s", dns)
+	}
+	message := fmt.Sprintf("DNS Name: %s, Custom Domain Name: %s", dns, hostnames[0])
+	if len(hostnames) > 1 {
+		message += fmt.Sprintf(", Additional Hostnames: %v", hostnames[1:])
+	}
+	return message
+}
+
+// setRouteParents adds a parent status for each of parents, and removes the parent statuses of this controller
+// for parentRefs which are no longer VPC Lattice parents of the route
+func setRouteParents(route core.Route, parents []*gateway.RouteParent) {
+	var parentStatuses []gwv1beta1.RouteParentStatus
+	for _, parentStatus := range route.Status().Parents() {
+		isParent := slices.ContainsFunc(parents, func(parent *gateway.RouteParent) bool {
+			return reflect.DeepEqual(parent.ParentRef, parentStatus.ParentRef)
+		})
+		if isParent || parentStatus.ControllerName != config.LatticeGatewayControllerName {
+			parentStatuses = append(parentStatuses, parentStatus)
+		}
+	}
+	route.Status().SetParents(parentStatuses)
+
+	for _, parent := range parents {
+		route.Status().UpdateParentRefs(parent.ParentRef, config.LatticeGatewayControllerName)
+	}
+}
+
+// updateRouteNotAccepted sets the Accepted condition of every accepted parent of route to false with reason
+func (r *routeReconciler) updateRouteNotAccepted(ctx context.Context, route core.Route,
+	reason gwv1beta1.RouteConditionReason, reasonErr error) error {
+	routeOld := route.DeepCopy()
+
+	parents, err := gateway.GetRouteParents(ctx, r.client, route)
+	if err != nil {
+		return err
+	}
+	setRouteParents(route, parents)
+
+	for _, parent := range parents {
+		if !parent.Accepted() {
+			setRouteParentNotAccepted(route, parent.ParentRef, parent.Reason, parent.Err)
+			continue
+		}
+		setRouteParentNotAccepted(route, parent.ParentRef, reason, reasonErr)
+	}
+
+	if err := r.client.Status().Patch(ctx, route.K8sObject(), client.MergeFrom(routeOld.K8sObject())); err != nil {
+		return errors.Wrapf(err, "failed to update httproute status")
+	}
+	return nil
+}
+
+func setRouteParentNotAccepted(route core.Route, parentRef gwv1beta1.ParentReference,
+	reason gwv1beta1.RouteConditionReason, err error) {
+	route.Status().UpdateRouteCondition(parentRef, metav1.Condition{
+		Type:               string(gwv1beta1.RouteConditionAccepted),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: route.K8sObject().GetGeneration(),
+		Reason:             string(reason),
+		Message:            err.Error(),
+	})
+}
+
 func (r *routeReconciler) validateBackendRefsIpFamilies(ctx context.Context, route core.Route) error {
 	rules := route.Spec().Rules()
 
@@ -431,8 +632,8 @@ func (r *routeReconciler) validateBackendRefsIpFamilies(ctx context.Context, rou
 		backendRefs := rule.BackendRefs()
 
 		for _, backendRef := range backendRefs {
-			// For now we skip checking service import
-			if *backendRef.Kind() == "ServiceImport" {
+			// For now we skip checking service import, Lambda functions have no ip families
+			if *backendRef.Kind() != "Service" {
 				continue
 			}
 
@@ -442,8 +643,9 @@ func (r *routeReconciler) validateBackendRefsIpFamilies(ctx context.Context, rou
 				continue
 			}
 
-			if len(svc.Spec.IPFamilies) > 1 {
-				return errors.New("Invalid IpFamilies, Lattice Target Group doesn't support dual stack ip addresses")
+			// dual-stack Services register the addresses of one family, which has to be one of the Service
+			if _, err := gateway.GetRouteServiceIpAddressType(ctx, r.client, route, svc); err != nil {
+				return fmt.Errorf("invalid IpFamilies of Service %s-%s, %w", svc.Name, svc.Namespace, err)
 			}
 		}
 	}
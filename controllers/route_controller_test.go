@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// fakeLatticeServiceBuilder records the routes it builds a model for
+type fakeLatticeServiceBuilder struct {
+	routes []core.Route
+}
+
+func (b *fakeLatticeServiceBuilder) Build(ctx context.Context, route core.Route) (core.Stack, *model.Service, error) {
+	b.routes = append(b.routes, route)
+	return core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject()))), nil, nil
+}
+
+type fakeStackDeployer struct {
+	deployed int
+}
+
+func (d *fakeStackDeployer) Deploy(ctx context.Context, stack core.Stack) error {
+	d.deployed++
+	return nil
+}
+
+func Test_RouteReconcile_NotAcceptedByAnyParent(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+
+	hostname := gwv1beta1.Hostname("*.example.com")
+	sameNamespace := gwv1beta1.NamespacesFromSame
+	platform := gwv1beta1.Namespace("platform")
+	serviceKind := gwv1beta1.Kind("Service")
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "route",
+			Namespace:   "default",
+			Annotations: map[string]string{LatticeAssignedDomainName: "route-default.lattice.aws"},
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{
+					{Name: "fenced", Namespace: &platform},
+					{Name: "hostname"},
+				},
+			},
+			Hostnames: []gwv1beta1.Hostname{"example.org"},
+			Rules: []gwv1beta1.HTTPRouteRule{{
+				BackendRefs: []gwv1beta1.HTTPBackendRef{{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Kind: &serviceKind, Name: "svc"},
+					},
+				}},
+			}},
+		},
+	}
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+			Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "fenced", Namespace: "platform"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{{
+					Name:          "http",
+					Port:          80,
+					Protocol:      gwv1beta1.HTTPProtocolType,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &sameNamespace}},
+				}},
+			},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "hostname", Namespace: "default"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{{
+					Name:          "http",
+					Port:          80,
+					Protocol:      gwv1beta1.HTTPProtocolType,
+					Hostname:      &hostname,
+					AllowedRoutes: &gwv1beta1.AllowedRoutes{Namespaces: &gwv1beta1.RouteNamespaces{From: &sameNamespace}},
+				}},
+			},
+		},
+		route,
+	).Build()
+
+	builder := &fakeLatticeServiceBuilder{}
+	deployer := &fakeStackDeployer{}
+	r := &routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient),
+		eventRecorder:    record.NewFakeRecorder(10),
+		modelBuilder:     builder,
+		stackDeployer:    deployer,
+		stackMarshaller:  deploy.NewDefaultStackMarshaller(),
+	}
+
+	routeName := types.NamespacedName{Namespace: "default", Name: "route"}
+	err := r.reconcile(context.TODO(), ctrl.Request{NamespacedName: routeName})
+	assert.NoError(t, err)
+
+	// the lattice service is deprovisioned like the one of a deleted route
+	assert.Len(t, builder.routes, 1)
+	assert.False(t, builder.routes[0].DeletionTimestamp().IsZero())
+	assert.Equal(t, 1, deployer.deployed)
+
+	got := &gwv1beta1.HTTPRoute{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), routeName, got))
+	assert.True(t, got.DeletionTimestamp.IsZero())
+	assert.NotContains(t, got.Annotations, LatticeAssignedDomainName)
+
+	wantReasons := map[gwv1beta1.ObjectName]gwv1beta1.RouteConditionReason{
+		"fenced":   gwv1beta1.RouteReasonNotAllowedByListeners,
+		"hostname": gwv1beta1.RouteReasonNoMatchingListenerHostname,
+	}
+	assert.Len(t, got.Status.Parents, len(wantReasons))
+	for _, parentStatus := range got.Status.Parents {
+		accepted := meta.FindStatusCondition(parentStatus.Conditions, string(gwv1beta1.RouteConditionAccepted))
+		assert.NotNil(t, accepted)
+		assert.Equal(t, metav1.ConditionFalse, accepted.Status)
+		assert.Equal(t, string(wantReasons[parentStatus.ParentRef.Name]), accepted.Reason)
+	}
+
+	// the route does not count as attached to the listeners rejecting it
+	gw := &gwv1beta1.Gateway{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), types.NamespacedName{Namespace: "platform", Name: "fenced"}, gw))
+	for _, listener := range gw.Status.Listeners {
+		assert.Equal(t, int32(0), listener.AttachedRoutes)
+	}
+}
+
+// unavailableGatewayClient fails to get Gateways, like when the API server cannot be reached
+type unavailableGatewayClient struct {
+	client.Client
+}
+
+func (c *unavailableGatewayClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, ok := obj.(*gwv1beta1.Gateway); ok {
+		return errors.New("connection refused")
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func Test_RouteReconcile_ParentsNotResolved(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+
+	route := &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "route",
+			Namespace:   "default",
+			Annotations: map[string]string{LatticeAssignedDomainName: "route-default.lattice.aws"},
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "gw"}},
+			},
+		},
+	}
+	k8sClient := &unavailableGatewayClient{testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+			Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw", Namespace: "default"},
+			Spec:       gwv1beta1.GatewaySpec{GatewayClassName: "amazon-vpc-lattice"},
+		},
+		route,
+	).Build()}
+
+	builder := &fakeLatticeServiceBuilder{}
+	deployer := &fakeStackDeployer{}
+	r := &routeReconciler{
+		routeType:        core.HttpRouteType,
+		log:              gwlog.FallbackLogger,
+		client:           k8sClient,
+		finalizerManager: k8s.NewDefaultFinalizerManager(k8sClient),
+		eventRecorder:    record.NewFakeRecorder(10),
+		modelBuilder:     builder,
+		stackDeployer:    deployer,
+		stackMarshaller:  deploy.NewDefaultStackMarshaller(),
+	}
+
+	routeName := types.NamespacedName{Namespace: "default", Name: "route"}
+	err := r.reconcile(context.TODO(), ctrl.Request{NamespacedName: routeName})
+	assert.Error(t, err)
+
+	// the lattice service is kept until the parents of the route can be resolved
+	assert.Empty(t, builder.routes)
+	assert.Equal(t, 0, deployer.deployed)
+
+	got := &gwv1beta1.HTTPRoute{}
+	assert.NoError(t, k8sClient.Get(context.TODO(), routeName, got))
+	assert.Contains(t, got.Annotations, LatticeAssignedDomainName)
+}
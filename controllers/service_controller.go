@@ -135,6 +135,7 @@ func (r *serviceReconciler) buildAndDeployModel(ctx context.Context, svc *corev1
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
 		r.eventRecorder.Event(svc, corev1.EventTypeWarning,
 			k8s.ServiceEventReasonFailedDeployModel, fmt.Sprintf("failed deploy model: %s", err))
+		recordTargetFailureEvents(r.eventRecorder, svc, err)
 		return nil, nil, err
 	}
 
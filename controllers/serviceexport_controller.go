@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -85,10 +86,15 @@ func RegisterServiceExportController(
 	}
 
 	svcEventHandler := eventhandlers.NewServiceEventHandler(log, r.client)
+	podEventHandler := eventhandlers.NewPodEventHandler(log, r.client)
+	nodeEventHandler := eventhandlers.NewNodeEventHandler(log, r.client)
 
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&mcsv1alpha1.ServiceExport{}).
-		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler.MapToServiceExport())
+		Watches(&source.Kind{Type: &corev1.Service{}}, svcEventHandler.MapToServiceExport()).
+		Watches(&source.Kind{Type: &discoveryv1.EndpointSlice{}}, svcEventHandler.MapToServiceExport()).
+		Watches(&source.Kind{Type: &corev1.Pod{}}, podEventHandler.MapToServiceExport()).
+		Watches(&source.Kind{Type: &corev1.Node{}}, nodeEventHandler.MapToServiceExport())
 
 	if ok, err := k8s.IsGVKSupported(mgr, anv1alpha1.GroupVersion.String(), anv1alpha1.TargetGroupPolicyKind); ok {
 		builder.Watches(&source.Kind{Type: &anv1alpha1.TargetGroupPolicy{}}, svcEventHandler.MapToServiceExport())
@@ -171,6 +177,7 @@ func (r *serviceExportReconciler) buildAndDeployModel(
 	if err := r.stackDeployer.Deploy(ctx, stack); err != nil {
 		r.eventRecorder.Event(srvExport, corev1.EventTypeWarning,
 			k8s.ServiceExportEventReasonFailedDeployModel, fmt.Sprintf("Failed deploy model due to %s", err))
+		recordTargetFailureEvents(r.eventRecorder, srvExport, err)
 		return err
 	}
 
@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+)
+
+// recordTargetFailureEvents records an event on obj for each target VPC Lattice failed to register or deregister,
+// with its failure code, when err is a TargetsFailedError
+func recordTargetFailureEvents(eventRecorder record.EventRecorder, obj runtime.Object, err error) {
+	var targetsFailedErr *lattice.TargetsFailedError
+	if !errors.As(err, &targetsFailedErr) {
+		return
+	}
+	for _, failure := range targetsFailedErr.Registration {
+		eventRecorder.Event(obj, corev1.EventTypeWarning, k8s.TargetEventReasonFailedRegister,
+			targetFailureMessage("register", targetsFailedErr.TargetGroupID, failure))
+	}
+	for _, failure := range targetsFailedErr.Deregistration {
+		eventRecorder.Event(obj, corev1.EventTypeWarning, k8s.TargetEventReasonFailedDeregister,
+			targetFailureMessage("deregister", targetsFailedErr.TargetGroupID, failure))
+	}
+}
+
+func targetFailureMessage(operation string, tgID string, failure *vpclattice.TargetFailure) string {
+	target := awssdk.StringValue(failure.Id)
+	if failure.Port != nil {
+		target = fmt.Sprintf("%s:%d", target, awssdk.Int64Value(failure.Port))
+	}
+	return fmt.Sprintf("Failed to %s target %s of target group %s due to %s: %s", operation, target, tgID,
+		awssdk.StringValue(failure.FailureCode), awssdk.StringValue(failure.FailureMessage))
+}
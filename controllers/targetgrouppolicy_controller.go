@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/exp/slices"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/gateway"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// TargetGroupPolicies are programmed once they apply to a Service which has target groups. The target group
+// reconcilers resolve the policies in effect for their target groups, see gateway.GetTargetGroupPolicy.
+var targetGroupPolicyType = policyType{
+	newPolicy:     func() policyObject { return &anv1alpha1.TargetGroupPolicy{} },
+	newPolicyList: func() policyObjectList { return &anv1alpha1.TargetGroupPolicyList{} },
+	targetKinds: []policyTargetKind{serviceTargetKind, serviceExportTargetKind, httpRouteTargetKind, grpcRouteTargetKind,
+		gatewayTargetKind, namespaceTargetKind},
+	validate:   validateTargetGroupPolicy,
+	programmed: isTargetGroupPolicyProgrammed,
+}
+
+func RegisterTargetGroupPolicyController(
+	log gwlog.Logger,
+	mgr ctrl.Manager,
+) error {
+	r, builder := newPolicyReconciler(log, mgr, targetGroupPolicyType)
+
+	// whether a policy is programmed depends on the routes and exports of the Services it applies to
+	mapToPolicies := handler.EnqueueRequestsFromMapFunc(r.mapper.mapToPoliciesInNamespaces(routeOrExportNamespaces))
+	builder.
+		Watches(&source.Kind{Type: &gwv1beta1.HTTPRoute{}}, mapToPolicies).
+		Watches(&source.Kind{Type: &gwv1alpha2.GRPCRoute{}}, mapToPolicies).
+		Watches(&source.Kind{Type: &mcsv1alpha1.ServiceExport{}}, mapToPolicies)
+
+	// TLSRoute is in the experimental channel of the Gateway API, its CRD is not always installed
+	if ok, err := k8s.IsGVKSupported(mgr, gwv1alpha2.GroupVersion.String(), "TLSRoute"); ok {
+		builder.Watches(&source.Kind{Type: &gwv1alpha2.TLSRoute{}}, mapToPolicies)
+	} else if err != nil {
+		return err
+	}
+
+	return builder.Complete(r)
+}
+
+// validateTargetGroupPolicy returns a non-empty message describing why the policy is invalid
+func validateTargetGroupPolicy(policy policyObject) string {
+	targetRef := policy.GetTargetRef()
+	if targetRef.Kind == namespaceTargetKind.kind && string(targetRef.Name) != policy.GetNamespace() {
+		return "The targetRef Namespace must be the namespace of the target group policy"
+	}
+	isRoute := targetRef.Kind == httpRouteTargetKind.kind || targetRef.Kind == grpcRouteTargetKind.kind
+	sectionName := policy.(*anv1alpha1.TargetGroupPolicy).Spec.TargetRef.SectionName
+	if isRoute && sectionName == nil {
+		return fmt.Sprintf("The targetRef sectionName is required for Kind %s, it names the Service backend of the route", targetRef.Kind)
+	}
+	if !isRoute && sectionName != nil {
+		return fmt.Sprintf("The targetRef sectionName is only supported for Kinds %s and %s",
+			httpRouteTargetKind.kind, grpcRouteTargetKind.kind)
+	}
+	return ""
+}
+
+func isTargetGroupPolicyProgrammed(ctx context.Context, k8sClient client.Client, policy policyObject) (bool, string, error) {
+	inUse, err := gateway.IsTargetGroupPolicyInUse(ctx, k8sClient, policy.(*anv1alpha1.TargetGroupPolicy))
+	if err != nil {
+		return false, "", err
+	}
+	if !inUse {
+		if targetRef := policy.GetTargetRef(); targetRef.Kind == httpRouteTargetKind.kind || targetRef.Kind == grpcRouteTargetKind.kind {
+			return false, "The targetRef sectionName is not the name of a Service backend of the route", nil
+		}
+		return false, "The policy does not apply to any Service which is a backend of a route or is exported", nil
+	}
+	return true, config.LatticeGatewayControllerName, nil
+}
+
+// routeOrExportNamespaces returns the namespaces of the policies which may apply to the Services of a route or
+// a ServiceExport: the ones of its Services, and of the parent Gateways of a route
+func routeOrExportNamespaces(obj client.Object) []string {
+	namespaces := []string{obj.GetNamespace()}
+	route, err := core.NewRoute(obj)
+	if err != nil {
+		return namespaces
+	}
+	for _, parentRef := range route.Spec().ParentRefs() {
+		if parentRef.Namespace != nil && !slices.Contains(namespaces, string(*parentRef.Namespace)) {
+			namespaces = append(namespaces, string(*parentRef.Namespace))
+		}
+	}
+	for _, rule := range route.Spec().Rules() {
+		for _, backendRef := range rule.BackendRefs() {
+			if backendRef.Namespace() != nil && !slices.Contains(namespaces, string(*backendRef.Namespace())) {
+				namespaces = append(namespaces, string(*backendRef.Namespace()))
+			}
+		}
+	}
+	return namespaces
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// VpcAssociationPolicies are programmed along with their Gateway, whose reconciler applies them to the
+// VPC association of its service network.
+var vpcAssociationPolicyType = policyType{
+	newPolicy:     func() policyObject { return &anv1alpha1.VpcAssociationPolicy{} },
+	newPolicyList: func() policyObjectList { return &anv1alpha1.VpcAssociationPolicyList{} },
+	targetKinds:   []policyTargetKind{gatewayTargetKind},
+	programmed:    isVpcAssociationPolicyProgrammed,
+}
+
+func RegisterVpcAssociationPolicyController(
+	log gwlog.Logger,
+	mgr ctrl.Manager,
+) error {
+	r, builder := newPolicyReconciler(log, mgr, vpcAssociationPolicyType)
+	return builder.Complete(r)
+}
+
+func isVpcAssociationPolicyProgrammed(ctx context.Context, k8sClient client.Client, policy policyObject) (bool, string, error) {
+	gw := &gwv1beta1.Gateway{}
+	key := types.NamespacedName{Namespace: policy.GetNamespace(), Name: string(policy.GetTargetRef().Name)}
+	if err := k8sClient.Get(ctx, key, gw); err != nil {
+		return false, "", err
+	}
+	programmed := meta.FindStatusCondition(gw.Status.Conditions, string(gwv1beta1.GatewayConditionProgrammed))
+	if programmed == nil || programmed.Status != metav1.ConditionTrue || programmed.ObservedGeneration != gw.Generation {
+		return false, fmt.Sprintf("Waiting for the Gateway %s to be programmed", key), nil
+	}
+	return true, config.LatticeGatewayControllerName, nil
+}
@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"golang.org/x/exp/slices"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -10,7 +11,10 @@ import (
 )
 
 const (
-	AccessLogPolicyKind                = "AccessLogPolicy"
+	AccessLogPolicyKind = "AccessLogPolicy"
+
+	// AccessLogSubscriptionAnnotationKey annotated the policies with the ARN of their Access Log Subscription before
+	// the status recorded the Access Log Subscriptions of their destinations. It is removed once they are recorded.
 	AccessLogSubscriptionAnnotationKey = "VpcLatticeAccessLogSubscription"
 )
 
@@ -44,17 +48,46 @@ type AccessLogPolicyList struct {
 type AccessLogPolicySpec struct {
 	// The Amazon Resource Name (ARN) of the destination that will store access logs.
 	// Supported values are S3 Bucket, CloudWatch Log Group, and Firehose Delivery Stream ARNs.
+	// It is a destination in addition to the ones of destinationArns.
 	//
 	// Changes to this value results in replacement of the VPC Lattice Access Log Subscription.
+	// +optional
 	// +kubebuilder:validation:Pattern=`^arn(:[a-z0-9]+([.-][a-z0-9]+)*){2}(:([a-z0-9]+([.-][a-z0-9]+)*)?){2}:([^/].*)?`
-	DestinationArn *string `json:"destinationArn"`
+	DestinationArn *string `json:"destinationArn,omitempty"`
+
+	// The Amazon Resource Names (ARNs) of the destinations that will store access logs, at most one of each type:
+	// S3 Bucket, CloudWatch Log Group, and Firehose Delivery Stream. VPC Lattice has one Access Log Subscription
+	// for each destination type of the targetRef.
+	//
+	// Changes to a destination update its VPC Lattice Access Log Subscription in place when its type does not change.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=3
+	DestinationArns []string `json:"destinationArns,omitempty"`
 
 	// TargetRef points to the Kubernetes Gateway, HTTPRoute, or GRPCRoute resource that will have this policy attached.
 	//
 	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
 	TargetRef *v1alpha2.PolicyTargetReference `json:"targetRef"`
+
+	// The resources whose access logs are sent to the destinations when the targetRef is a Gateway.
+	// Supported values are ServiceNetwork (default), which subscribes the VPC Lattice service network of the Gateway,
+	// and Services, which subscribes the VPC Lattice service of every route attached to the Gateway instead.
+	// With Services, Access Log Subscriptions are added and removed as routes attach to and detach from the Gateway.
+	//
+	// Changes to this value results in replacement of the VPC Lattice Access Log Subscriptions.
+	// +optional
+	Scope *AccessLogPolicyScope `json:"scope,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=ServiceNetwork;Services
+type AccessLogPolicyScope string
+
+const (
+	AccessLogPolicyScopeServiceNetwork AccessLogPolicyScope = "ServiceNetwork"
+	AccessLogPolicyScopeServices       AccessLogPolicyScope = "Services"
+)
+
 // AccessLogPolicyStatus defines the observed state of AccessLogPolicy.
 type AccessLogPolicyStatus struct {
 	// Conditions describe the current conditions of the AccessLogPolicy.
@@ -67,7 +100,7 @@ type AccessLogPolicyStatus struct {
 	// Known condition types are:
 	//
 	// * "Accepted"
-	// * "Ready"
+	// * "Programmed"
 	//
 	// +optional
 	// +listType=map
@@ -75,12 +108,69 @@ type AccessLogPolicyStatus struct {
 	// +kubebuilder:validation:MaxItems=8
 	// +kubebuilder:default={{type: "Accepted", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"},{type: "Programmed", status: "Unknown", reason:"Pending", message:"Waiting for controller", lastTransitionTime: "1970-01-01T00:00:00Z"}}
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Destinations are the VPC Lattice Access Log Subscriptions of the policy, one for each of its destinations.
+	//
+	// +optional
+	// +listType=map
+	// +listMapKey=destinationArn
+	Destinations []AccessLogDestinationStatus `json:"destinations,omitempty"`
+}
+
+// AccessLogDestinationStatus defines the observed state of a destination of an AccessLogPolicy.
+type AccessLogDestinationStatus struct {
+	// The Amazon Resource Name (ARN) of the destination.
+	DestinationArn string `json:"destinationArn"`
+
+	// The Amazon Resource Name (ARN) of the VPC Lattice Access Log Subscription sending access logs of the targetRef
+	// to the destination. Policies with the Services scope have none.
+	// +optional
+	AccessLogSubscriptionArn string `json:"accessLogSubscriptionArn,omitempty"`
+
+	// Services are the VPC Lattice Access Log Subscriptions sending access logs of the services of the routes
+	// attached to the Gateway to the destination, for policies with the Services scope.
+	// +optional
+	// +listType=map
+	// +listMapKey=serviceName
+	Services []AccessLogServiceStatus `json:"services,omitempty"`
+}
+
+// AccessLogServiceStatus defines the observed state of the access logs of a VPC Lattice service sent to a destination.
+type AccessLogServiceStatus struct {
+	// The name of the VPC Lattice service of a route attached to the Gateway.
+	ServiceName string `json:"serviceName"`
+
+	// The Amazon Resource Name (ARN) of the VPC Lattice Access Log Subscription of the service.
+	AccessLogSubscriptionArn string `json:"accessLogSubscriptionArn"`
 }
 
 func (p *AccessLogPolicy) GetTargetRef() *v1alpha2.PolicyTargetReference {
 	return p.Spec.TargetRef
 }
 
+// GetDestinationArns returns the destinationArn of the policy, if any, followed by its destinationArns,
+// without duplicates
+func (p *AccessLogPolicy) GetDestinationArns() []string {
+	var destinationArns []string
+	if p.Spec.DestinationArn != nil {
+		destinationArns = append(destinationArns, *p.Spec.DestinationArn)
+	}
+	for _, destinationArn := range p.Spec.DestinationArns {
+		if !slices.Contains(destinationArns, destinationArn) {
+			destinationArns = append(destinationArns, destinationArn)
+		}
+	}
+	return destinationArns
+}
+
+// GetScope returns the scope of the policy, ServiceNetwork unless it says otherwise
+func (p *AccessLogPolicy) GetScope() AccessLogPolicyScope {
+	if p.Spec.Scope == nil {
+		return AccessLogPolicyScopeServiceNetwork
+	}
+	return *p.Spec.Scope
+}
+
 func (p *AccessLogPolicy) GetStatusConditions() []metav1.Condition {
 	return p.Status.Conditions
 }
@@ -95,8 +185,8 @@ func (p *AccessLogPolicy) GetNamespacedName() types.NamespacedName {
 
 func (pl *AccessLogPolicyList) GetItems() []core.Policy {
 	items := make([]core.Policy, len(pl.Items))
-	for i, item := range pl.Items {
-		items[i] = &item
+	for i := range pl.Items {
+		items[i] = &pl.Items[i]
 	}
 	return items
 }
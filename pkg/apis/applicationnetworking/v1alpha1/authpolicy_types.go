@@ -11,6 +11,8 @@ import (
 
 const (
 	IAMAuthPolicyKind = "IAMAuthPolicy"
+	// IAMAuthPolicyResourceIdAnnotationKey records the VPC Lattice resource the policy is currently attached to
+	IAMAuthPolicyResourceIdAnnotationKey = "application-networking.k8s.aws/resourceId"
 )
 
 // +genclient
@@ -65,7 +67,7 @@ type IAMAuthPolicyStatus struct {
 	// Known condition types are:
 	//
 	// * "Accepted"
-	// * "Ready"
+	// * "Programmed"
 	//
 	// +optional
 	// +listType=map
@@ -93,8 +95,8 @@ func (p *IAMAuthPolicy) GetNamespacedName() types.NamespacedName {
 
 func (pl *IAMAuthPolicyList) GetItems() []core.Policy {
 	items := make([]core.Policy, len(pl.Items))
-	for i, item := range pl.Items {
-		items[i] = &item
+	for i := range pl.Items {
+		items[i] = &pl.Items[i]
 	}
 	return items
 }
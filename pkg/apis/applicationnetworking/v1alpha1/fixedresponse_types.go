@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	LatticeFixedResponseKind = "LatticeFixedResponse"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=gateway-api,shortName=lfr
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Status Code",type=integer,JSONPath=`.spec.statusCode`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type LatticeFixedResponse struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LatticeFixedResponseSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// LatticeFixedResponseList contains a list of LatticeFixedResponses.
+type LatticeFixedResponseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LatticeFixedResponse `json:"items"`
+}
+
+// LatticeFixedResponseSpec defines the fixed response of a route rule.
+// It is referenced from an `ExtensionRef` filter of an HTTPRoute or GRPCRoute rule in the same namespace,
+// requests matching that rule are answered by VPC Lattice with the status code instead of being forwarded to backends.
+type LatticeFixedResponseSpec struct {
+	// The HTTP status code of the response.
+	//
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	StatusCode int64 `json:"statusCode"`
+}
@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	LambdaFunctionKind = "LambdaFunction"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=gateway-api,shortName=lfn
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Function ARN",type=string,JSONPath=`.spec.functionArn`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type LambdaFunction struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LambdaFunctionSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// LambdaFunctionList contains a list of LambdaFunctions.
+type LambdaFunctionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LambdaFunction `json:"items"`
+}
+
+// LambdaFunctionSpec defines a Lambda function which serves the requests of route rules.
+// It is referenced from a backendRef of an HTTPRoute rule with group `application-networking.k8s.aws`
+// and kind `LambdaFunction`, the controller creates a VPC Lattice target group of type LAMBDA for it
+// and registers the function as its only target.
+type LambdaFunctionSpec struct {
+	// FunctionArn is the ARN of the Lambda function, optionally qualified with a version or alias.
+	//
+	// +kubebuilder:validation:Pattern=`^arn:[a-z0-9\-]+:lambda:[a-z0-9\-]+:\d{12}:function:[a-zA-Z0-9\-_]+(:[a-zA-Z0-9\-_$]+)?$`
+	FunctionArn string `json:"functionArn"`
+}
@@ -0,0 +1,42 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	LatticeTargetGroupKind = "LatticeTargetGroup"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=gateway-api,shortName=ltg
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Target Group",type=string,JSONPath=`.spec.targetGroupIdentifier`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type LatticeTargetGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LatticeTargetGroupSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+// LatticeTargetGroupList contains a list of LatticeTargetGroups.
+type LatticeTargetGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LatticeTargetGroup `json:"items"`
+}
+
+// LatticeTargetGroupSpec defines an existing VPC Lattice target group which is not managed by the controller,
+// e.g. one created by other tools for targets outside the cluster. It is referenced from a backendRef of
+// a route rule with group `application-networking.k8s.aws` and kind `LatticeTargetGroup`, the controller
+// forwards traffic to the target group without creating, modifying or deleting it.
+type LatticeTargetGroupSpec struct {
+	// TargetGroupIdentifier is the ID or the ARN of the VPC Lattice target group.
+	// The target group must be in the VPC of the cluster.
+	//
+	// +kubebuilder:validation:Pattern=`^((tg-[0-9a-z]{17})|(arn:[a-z0-9\-]+:vpc-lattice:[a-zA-Z0-9\-]+:\d{12}:targetgroup/tg-[0-9a-z]{17}))$`
+	TargetGroupIdentifier string `json:"targetGroupIdentifier"`
+}
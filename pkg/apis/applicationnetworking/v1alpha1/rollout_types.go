@@ -0,0 +1,174 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+const (
+	LatticeRolloutKind = "LatticeRollout"
+
+	// MaxLatticeRolloutHistory is the number of history entries kept in the status of a LatticeRollout
+	MaxLatticeRolloutHistory = 20
+)
+
+// LatticeRolloutPhase is the state of a LatticeRollout.
+type LatticeRolloutPhase string
+
+const (
+	// LatticeRolloutPhaseProgressing is the phase of a rollout stepping the canary weight.
+	LatticeRolloutPhaseProgressing LatticeRolloutPhase = "Progressing"
+	// LatticeRolloutPhaseSucceeded is the phase of a rollout which completed its last step.
+	LatticeRolloutPhaseSucceeded LatticeRolloutPhase = "Succeeded"
+	// LatticeRolloutPhaseRolledBack is the phase of a rollout which moved all traffic back to the stable Service
+	// because the canary Service was unhealthy.
+	LatticeRolloutPhaseRolledBack LatticeRolloutPhase = "RolledBack"
+	// LatticeRolloutPhaseInvalid is the phase of a rollout whose route rule or Services cannot be found.
+	LatticeRolloutPhaseInvalid LatticeRolloutPhase = "Invalid"
+)
+
+// +genclient
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=gateway-api,shortName=lro
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Route",type=string,JSONPath=`.spec.routeRef.name`
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Canary Weight",type=integer,JSONPath=`.status.canaryWeight`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
+type LatticeRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LatticeRolloutSpec `json:"spec"`
+
+	// Status defines the current state of LatticeRollout.
+	Status LatticeRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// LatticeRolloutList contains a list of LatticeRollouts.
+type LatticeRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LatticeRollout `json:"items"`
+}
+
+// LatticeRolloutSpec defines how traffic of an HTTPRoute rule is shifted from a stable to a canary Service.
+// The rule has to have backendRefs to both Services, whose weights are set by the controller
+// while the rollout is in progress, overriding the weights in the route.
+type LatticeRolloutSpec struct {
+	// RouteRef is the HTTPRoute rule whose traffic is shifted.
+	RouteRef LatticeRolloutRouteRef `json:"routeRef"`
+
+	// StableService is the name of the Service in the namespace of the rollout which gets the traffic
+	// the canary Service does not get.
+	StableService gwv1beta1.ObjectName `json:"stableService"`
+
+	// CanaryService is the name of the Service in the namespace of the rollout which traffic is shifted to.
+	CanaryService gwv1beta1.ObjectName `json:"canaryService"`
+
+	// Steps are the weights of the canary Service, in the order they are applied.
+	// The stable Service gets the remaining weight up to 100.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	Steps []LatticeRolloutStep `json:"steps"`
+
+	// StepInterval is how long each step lasts before the next one is applied.
+	//
+	// +optional
+	// +kubebuilder:default="5m"
+	StepInterval *metav1.Duration `json:"stepInterval,omitempty"`
+
+	// MaxUnhealthyTargetsPercent is the percentage of unhealthy canary targets above which the rollout
+	// moves all traffic back to the stable Service. The health of the canary targets is checked before each step.
+	//
+	// +optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxUnhealthyTargetsPercent int32 `json:"maxUnhealthyTargetsPercent,omitempty"`
+}
+
+// LatticeRolloutRouteRef refers to a rule of an HTTPRoute in the namespace of the rollout.
+type LatticeRolloutRouteRef struct {
+	// Name is the name of the HTTPRoute.
+	Name gwv1beta1.ObjectName `json:"name"`
+
+	// RuleIndex is the index of the rule in the rules of the HTTPRoute, starting at 0.
+	//
+	// +optional
+	// +kubebuilder:default=0
+	// +kubebuilder:validation:Minimum=0
+	RuleIndex int32 `json:"ruleIndex,omitempty"`
+}
+
+// LatticeRolloutStep is a step of a rollout.
+type LatticeRolloutStep struct {
+	// Weight is the percentage of the traffic of the rule the canary Service gets.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+}
+
+// LatticeRolloutStatus defines the observed state of LatticeRollout.
+type LatticeRolloutStatus struct {
+	// ObservedGeneration is the generation of the spec the status is for. The rollout starts over
+	// from its first step when the spec changes.
+	//
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the state of the rollout.
+	//
+	// +optional
+	Phase LatticeRolloutPhase `json:"phase,omitempty"`
+
+	// Message explains the phase.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// CurrentStep is the index of the step applied last.
+	//
+	// +optional
+	CurrentStep int32 `json:"currentStep,omitempty"`
+
+	// CanaryWeight is the weight the canary Service currently gets.
+	//
+	// +optional
+	CanaryWeight int32 `json:"canaryWeight,omitempty"`
+
+	// LastStepTime is when the current step was applied.
+	//
+	// +optional
+	LastStepTime *metav1.Time `json:"lastStepTime,omitempty"`
+
+	// History lists the latest steps and rollbacks of the rollout, oldest first.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	History []LatticeRolloutHistoryEntry `json:"history,omitempty"`
+}
+
+// LatticeRolloutHistoryEntry records a change of the canary weight.
+type LatticeRolloutHistoryEntry struct {
+	// Time is when the change was made.
+	Time metav1.Time `json:"time"`
+
+	// Step is the index of the step applied.
+	Step int32 `json:"step"`
+
+	// CanaryWeight is the weight the canary Service got.
+	CanaryWeight int32 `json:"canaryWeight"`
+
+	// Phase is the phase of the rollout after the change.
+	Phase LatticeRolloutPhase `json:"phase"`
+
+	// Message explains the change.
+	//
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -4,6 +4,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -17,6 +18,7 @@ const (
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=gateway-api,shortName=tgp
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 type TargetGroupPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -37,23 +39,60 @@ type TargetGroupPolicyList struct {
 
 // TargetGroupPolicySpec defines the desired state of TargetGroupPolicy.
 type TargetGroupPolicySpec struct {
-	// The protocol to use for routing traffic to the targets. Supported values are HTTP (default) and HTTPS.
+	// The protocol to use for routing traffic to the targets. Supported values are HTTP (default), HTTPS and TCP.
+	// When a policy is behind TLSRoute, this field value will be ignored as TLS passthrough is only supported through TCP.
 	//
 	// Changes to this value results in a replacement of VPC Lattice target group.
 	// +optional
 	Protocol *string `json:"protocol,omitempty"`
 
 	// The protocol version to use. Supported values are HTTP1 (default) and HTTP2. When a policy is behind GRPCRoute,
-	// this field value will be ignored as GRPC is only supported through HTTP/2.
+	// this field value will be ignored as GRPC is only supported through HTTP/2. TCP has no protocol version.
 	//
 	// Changes to this value results in a replacement of VPC Lattice target group.
 	// +optional
 	ProtocolVersion *string `json:"protocolVersion,omitempty"`
 
-	// TargetRef points to the kubernetes Service resource that will have this policy attached.
+	// The type of targets registered to the target group. Supported values are IP (default), which registers
+	// the IPs of the endpoints of the Service, and Instance, which registers the instance IDs of the nodes
+	// with the NodePort of the Service, for pods whose IPs are not routable from VPC Lattice.
+	// Instance requires a Service of type NodePort or LoadBalancer.
+	//
+	// Changes to this value results in a replacement of VPC Lattice target group.
+	// +optional
+	TargetType *TargetType `json:"targetType,omitempty"`
+
+	// The IP address type of the target group, IPV4 or IPV6. It chooses the family of the endpoint addresses
+	// registered as targets of a dual-stack Service, which defaults to the primary family of the Service.
+	// The Service needs to have the family. Takes precedence over the
+	// "application-networking.k8s.aws/ip-address-type" annotation of the Service.
+	//
+	// Changes to this value results in a replacement of VPC Lattice target group.
+	// +optional
+	IpAddressType *IpAddressType `json:"ipAddressType,omitempty"`
+
+	// How long, in seconds, the pods of the Service are kept terminating while VPC Lattice drains their
+	// deregistered targets. When set, the controller adds a finalizer to the pods of the Service, and removes it
+	// once VPC Lattice no longer lists their targets, or once the timeout expires after their deletion.
+	//
+	// Changes to this value do not affect VPC Lattice resources.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=3600
+	DrainingTimeoutSeconds *int64 `json:"drainingTimeoutSeconds,omitempty"`
+
+	// TargetRef points to the kubernetes Service, ServiceExport, HTTPRoute, GRPCRoute, Gateway or Namespace resource
+	// that will have this policy attached. For an HTTPRoute or a GRPCRoute, the sectionName names the Service backend
+	// of the route the policy applies to, and is required.
+	//
+	// A policy attached to a Namespace or a Gateway sets defaults for the Services of the Namespace or behind the
+	// routes of the Gateway, a policy attached to a Service overrides them field by field. A policy attached to a
+	// route backend or a ServiceExport overrides them in turn, for the target group of the Service created for
+	// that route or that export only. When several policies are attached to the same resource, the oldest one
+	// takes effect, the others are Conflicted.
 	//
 	// This field is following the guidelines of Kubernetes Gateway API policy attachment.
-	TargetRef *v1alpha2.PolicyTargetReference `json:"targetRef"`
+	TargetRef *PolicyTargetReferenceWithSectionName `json:"targetRef"`
 
 	// The health check configuration.
 	//
@@ -62,6 +101,16 @@ type TargetGroupPolicySpec struct {
 	HealthCheck *HealthCheckConfig `json:"healthCheck,omitempty"`
 }
 
+// PolicyTargetReferenceWithSectionName identifies a target object of a policy, or a section of it.
+type PolicyTargetReferenceWithSectionName struct {
+	v1alpha2.PolicyTargetReference `json:",inline"`
+
+	// SectionName is the name of a section of the target resource. For an HTTPRoute or a GRPCRoute,
+	// it is the name of a Service in the backendRefs of the route, in the namespace of the route.
+	// +optional
+	SectionName *v1beta1.SectionName `json:"sectionName,omitempty"`
+}
+
 // HealthCheckConfig defines health check configuration for given VPC Lattice target group.
 // For the detailed explanation and supported values, please refer to VPC Lattice documentationon health checks.
 type HealthCheckConfig struct {
@@ -128,7 +177,7 @@ type TargetGroupPolicyStatus struct {
 	// Known condition types are:
 	//
 	// * "Accepted"
-	// * "Ready"
+	// * "Programmed"
 	//
 	// +optional
 	// +listType=map
@@ -138,6 +187,22 @@ type TargetGroupPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=IP;Instance
+type TargetType string
+
+const (
+	TargetTypeIP       TargetType = "IP"
+	TargetTypeInstance TargetType = "Instance"
+)
+
+// +kubebuilder:validation:Enum=IPV4;IPV6
+type IpAddressType string
+
+const (
+	IpAddressTypeIPv4 IpAddressType = "IPV4"
+	IpAddressTypeIPv6 IpAddressType = "IPV6"
+)
+
 // +kubebuilder:validation:Enum=HTTP;HTTPS
 type HealthCheckProtocol string
 
@@ -155,7 +220,10 @@ const (
 )
 
 func (p *TargetGroupPolicy) GetTargetRef() *v1alpha2.PolicyTargetReference {
-	return p.Spec.TargetRef
+	if p.Spec.TargetRef == nil {
+		return nil
+	}
+	return &p.Spec.TargetRef.PolicyTargetReference
 }
 
 func (p *TargetGroupPolicy) GetNamespacedName() types.NamespacedName {
@@ -172,8 +240,8 @@ func (p *TargetGroupPolicy) SetStatusConditions(conditions []metav1.Condition) {
 
 func (pl *TargetGroupPolicyList) GetItems() []core.Policy {
 	items := make([]core.Policy, len(pl.Items))
-	for i, item := range pl.Items {
-		items[i] = &item
+	for i := range pl.Items {
+		items[i] = &pl.Items[i]
 	}
 	return items
 }
@@ -18,6 +18,7 @@ const (
 // +kubebuilder:resource:categories=gateway-api,shortName=vap
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +kubebuilder:subresource:status
 type VpcAssociationPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -65,19 +66,19 @@ type VpcAssociationPolicySpec struct {
 	TargetRef *v1alpha2.PolicyTargetReference `json:"targetRef"`
 }
 
-// VpcAssociationPolicyStatus defines the observed state of AccessLogPolicy.
+// VpcAssociationPolicyStatus defines the observed state of VpcAssociationPolicy.
 type VpcAssociationPolicyStatus struct {
-	// Conditions describe the current conditions of the AccessLogPolicy.
+	// Conditions describe the current conditions of the VpcAssociationPolicy.
 	//
 	// Implementations should prefer to express Policy conditions
 	// using the `PolicyConditionType` and `PolicyConditionReason`
 	// constants so that operators and tools can converge on a common
-	// vocabulary to describe AccessLogPolicy state.
+	// vocabulary to describe VpcAssociationPolicy state.
 	//
 	// Known condition types are:
 	//
 	// * "Accepted"
-	// * "Ready"
+	// * "Programmed"
 	//
 	// +optional
 	// +listType=map
@@ -105,8 +106,8 @@ func (p *VpcAssociationPolicy) GetNamespacedName() types.NamespacedName {
 
 func (pl *VpcAssociationPolicyList) GetItems() []core.Policy {
 	items := make([]core.Policy, len(pl.Items))
-	for i, item := range pl.Items {
-		items[i] = &item
+	for i := range pl.Items {
+		items[i] = &pl.Items[i]
 	}
 	return items
 }
@@ -8,8 +8,29 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/gateway-api/apis/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogDestinationStatus) DeepCopyInto(out *AccessLogDestinationStatus) {
+	*out = *in
+	if in.Services != nil {
+		in, out := &in.Services, &out.Services
+		*out = make([]AccessLogServiceStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogDestinationStatus.
+func (in *AccessLogDestinationStatus) DeepCopy() *AccessLogDestinationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogDestinationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessLogPolicy) DeepCopyInto(out *AccessLogPolicy) {
 	*out = *in
@@ -77,11 +98,21 @@ func (in *AccessLogPolicySpec) DeepCopyInto(out *AccessLogPolicySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DestinationArns != nil {
+		in, out := &in.DestinationArns, &out.DestinationArns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(v1alpha2.PolicyTargetReference)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(AccessLogPolicyScope)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicySpec.
@@ -104,6 +135,13 @@ func (in *AccessLogPolicyStatus) DeepCopyInto(out *AccessLogPolicyStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]AccessLogDestinationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogPolicyStatus.
@@ -116,6 +154,21 @@ func (in *AccessLogPolicyStatus) DeepCopy() *AccessLogPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AccessLogServiceStatus) DeepCopyInto(out *AccessLogServiceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AccessLogServiceStatus.
+func (in *AccessLogServiceStatus) DeepCopy() *AccessLogServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AccessLogServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
 	*out = *in
@@ -282,6 +335,403 @@ func (in *IAMAuthPolicyStatus) DeepCopy() *IAMAuthPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LambdaFunction) DeepCopyInto(out *LambdaFunction) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LambdaFunction.
+func (in *LambdaFunction) DeepCopy() *LambdaFunction {
+	if in == nil {
+		return nil
+	}
+	out := new(LambdaFunction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LambdaFunction) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LambdaFunctionList) DeepCopyInto(out *LambdaFunctionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LambdaFunction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LambdaFunctionList.
+func (in *LambdaFunctionList) DeepCopy() *LambdaFunctionList {
+	if in == nil {
+		return nil
+	}
+	out := new(LambdaFunctionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LambdaFunctionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LambdaFunctionSpec) DeepCopyInto(out *LambdaFunctionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LambdaFunctionSpec.
+func (in *LambdaFunctionSpec) DeepCopy() *LambdaFunctionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LambdaFunctionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeFixedResponse) DeepCopyInto(out *LatticeFixedResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeFixedResponse.
+func (in *LatticeFixedResponse) DeepCopy() *LatticeFixedResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeFixedResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LatticeFixedResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeFixedResponseList) DeepCopyInto(out *LatticeFixedResponseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LatticeFixedResponse, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeFixedResponseList.
+func (in *LatticeFixedResponseList) DeepCopy() *LatticeFixedResponseList {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeFixedResponseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LatticeFixedResponseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeFixedResponseSpec) DeepCopyInto(out *LatticeFixedResponseSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeFixedResponseSpec.
+func (in *LatticeFixedResponseSpec) DeepCopy() *LatticeFixedResponseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeFixedResponseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRollout) DeepCopyInto(out *LatticeRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRollout.
+func (in *LatticeRollout) DeepCopy() *LatticeRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LatticeRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRolloutHistoryEntry) DeepCopyInto(out *LatticeRolloutHistoryEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRolloutHistoryEntry.
+func (in *LatticeRolloutHistoryEntry) DeepCopy() *LatticeRolloutHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRolloutHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRolloutList) DeepCopyInto(out *LatticeRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LatticeRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRolloutList.
+func (in *LatticeRolloutList) DeepCopy() *LatticeRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LatticeRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRolloutRouteRef) DeepCopyInto(out *LatticeRolloutRouteRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRolloutRouteRef.
+func (in *LatticeRolloutRouteRef) DeepCopy() *LatticeRolloutRouteRef {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRolloutRouteRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRolloutSpec) DeepCopyInto(out *LatticeRolloutSpec) {
+	*out = *in
+	out.RouteRef = in.RouteRef
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]LatticeRolloutStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.StepInterval != nil {
+		in, out := &in.StepInterval, &out.StepInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRolloutSpec.
+func (in *LatticeRolloutSpec) DeepCopy() *LatticeRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRolloutStatus) DeepCopyInto(out *LatticeRolloutStatus) {
+	*out = *in
+	if in.LastStepTime != nil {
+		in, out := &in.LastStepTime, &out.LastStepTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]LatticeRolloutHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRolloutStatus.
+func (in *LatticeRolloutStatus) DeepCopy() *LatticeRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeRolloutStep) DeepCopyInto(out *LatticeRolloutStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeRolloutStep.
+func (in *LatticeRolloutStep) DeepCopy() *LatticeRolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeRolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeTargetGroup) DeepCopyInto(out *LatticeTargetGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeTargetGroup.
+func (in *LatticeTargetGroup) DeepCopy() *LatticeTargetGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeTargetGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LatticeTargetGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeTargetGroupList) DeepCopyInto(out *LatticeTargetGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LatticeTargetGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeTargetGroupList.
+func (in *LatticeTargetGroupList) DeepCopy() *LatticeTargetGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeTargetGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LatticeTargetGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LatticeTargetGroupSpec) DeepCopyInto(out *LatticeTargetGroupSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LatticeTargetGroupSpec.
+func (in *LatticeTargetGroupSpec) DeepCopy() *LatticeTargetGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LatticeTargetGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTargetReferenceWithSectionName) DeepCopyInto(out *PolicyTargetReferenceWithSectionName) {
+	*out = *in
+	in.PolicyTargetReference.DeepCopyInto(&out.PolicyTargetReference)
+	if in.SectionName != nil {
+		in, out := &in.SectionName, &out.SectionName
+		*out = new(v1beta1.SectionName)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTargetReferenceWithSectionName.
+func (in *PolicyTargetReferenceWithSectionName) DeepCopy() *PolicyTargetReferenceWithSectionName {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTargetReferenceWithSectionName)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetGroupPolicy) DeepCopyInto(out *TargetGroupPolicy) {
 	*out = *in
@@ -354,9 +804,24 @@ func (in *TargetGroupPolicySpec) DeepCopyInto(out *TargetGroupPolicySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.TargetType != nil {
+		in, out := &in.TargetType, &out.TargetType
+		*out = new(TargetType)
+		**out = **in
+	}
+	if in.IpAddressType != nil {
+		in, out := &in.IpAddressType, &out.IpAddressType
+		*out = new(IpAddressType)
+		**out = **in
+	}
+	if in.DrainingTimeoutSeconds != nil {
+		in, out := &in.DrainingTimeoutSeconds, &out.DrainingTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
-		*out = new(v1alpha2.PolicyTargetReference)
+		*out = new(PolicyTargetReferenceWithSectionName)
 		(*in).DeepCopyInto(*out)
 	}
 	if in.HealthCheck != nil {
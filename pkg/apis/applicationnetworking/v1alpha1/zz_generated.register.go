@@ -61,6 +61,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&AccessLogPolicyList{},
 		&IAMAuthPolicy{},
 		&IAMAuthPolicyList{},
+		&LambdaFunction{},
+		&LambdaFunctionList{},
+		&LatticeFixedResponse{},
+		&LatticeFixedResponseList{},
+		&LatticeRollout{},
+		&LatticeRolloutList{},
+		&LatticeTargetGroup{},
+		&LatticeTargetGroupList{},
 		&TargetGroupPolicy{},
 		&TargetGroupPolicyList{},
 		&VpcAssociationPolicy{},
@@ -55,9 +55,12 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 		Namespace: service.Spec.Namespace,
 		Name:      service.Spec.Name,
 	}
-	if service.Spec.RouteType == core.GrpcRouteType {
+	switch service.Spec.RouteType {
+	case core.GrpcRouteType:
 		route, err = core.GetGRPCRoute(ctx, s.k8sClient, routeNamespacedName)
-	} else {
+	case core.TlsRouteType:
+		route, err = core.GetTLSRoute(ctx, s.k8sClient, routeNamespacedName)
+	default:
 		route, err = core.GetHTTPRoute(ctx, s.k8sClient, routeNamespacedName)
 	}
 	if err != nil {
@@ -69,23 +72,14 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 	if err := s.k8sClient.Get(ctx, namespacedName, ep); err != nil {
 		if apierrors.IsNotFound(err) {
 			s.log.Debugf("Attempting creation of DNSEndpoint for %s - %s -> %s",
-				namespacedName.String(), service.Spec.CustomerDomainName, service.Status.Dns)
+				namespacedName.String(), dnsNames(service), service.Status.Dns)
 			ep = &endpoint.DNSEndpoint{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      namespacedName.Name,
 					Namespace: namespacedName.Namespace,
 				},
 				Spec: endpoint.DNSEndpointSpec{
-					Endpoints: []*endpoint.Endpoint{
-						{
-							DNSName: service.Spec.CustomerDomainName,
-							Targets: []string{
-								service.Status.Dns,
-							},
-							RecordType: "CNAME",
-							RecordTTL:  300,
-						},
-					},
+					Endpoints: buildEndpoints(service),
 				},
 			}
 			controllerutil.SetControllerReference(route.K8sObject(), ep, s.k8sClient.Scheme())
@@ -100,18 +94,9 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 		}
 	} else {
 		s.log.Debugf("Attempting update of DNSEndpoint for %s - %s -> %s",
-			namespacedName.String(), service.Spec.CustomerDomainName, service.Status.Dns)
+			namespacedName.String(), dnsNames(service), service.Status.Dns)
 		old := ep.DeepCopy()
-		ep.Spec.Endpoints = []*endpoint.Endpoint{
-			{
-				DNSName: service.Spec.CustomerDomainName,
-				Targets: []string{
-					service.Status.Dns,
-				},
-				RecordType: "CNAME",
-				RecordTTL:  300,
-			},
-		}
+		ep.Spec.Endpoints = buildEndpoints(service)
 		if !reflect.DeepEqual(ep.Spec.Endpoints, old.Spec.Endpoints) {
 			if err = s.k8sClient.Patch(ctx, ep, client.MergeFrom(old)); err != nil {
 				return err
@@ -120,3 +105,24 @@ func (s *defaultDnsEndpointManager) Create(ctx context.Context, service *lattice
 	}
 	return nil
 }
+
+// dnsNames are the custom domain name of the service followed by the other hostnames of the route
+func dnsNames(service *latticemodel.Service) []string {
+	return append([]string{service.Spec.CustomerDomainName}, service.Spec.AdditionalDomainNames...)
+}
+
+// buildEndpoints points a CNAME record for every hostname of the route at the DNS name of the service
+func buildEndpoints(service *latticemodel.Service) []*endpoint.Endpoint {
+	var endpoints []*endpoint.Endpoint
+	for _, dnsName := range dnsNames(service) {
+		endpoints = append(endpoints, &endpoint.Endpoint{
+			DNSName: dnsName,
+			Targets: []string{
+				service.Status.Dns,
+			},
+			RecordType: "CNAME",
+			RecordTTL:  300,
+		})
+	}
+	return endpoints
+}
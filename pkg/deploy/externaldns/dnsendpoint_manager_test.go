@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/external-dns/endpoint"
 )
 
@@ -94,6 +95,23 @@ func TestCreateDnsEndpoint(t *testing.T) {
 			created:   true,
 			errIsNil:  true,
 		},
+		{
+			name: "Create new DNSEndpoint with a record for every hostname",
+			service: model.Service{
+				Spec: model.ServiceSpec{
+					Name:                  "service",
+					Namespace:             "default",
+					CustomerDomainName:    "custom-domain",
+					AdditionalDomainNames: []string{"blue-domain", "green-domain"},
+				},
+				Status: &model.ServiceStatus{
+					Dns: "lattice-internal-domain",
+				},
+			},
+			dnsGetErr: apierrors.NewNotFound(schema.GroupResource{}, ""),
+			created:   true,
+			errIsNil:  true,
+		},
 		{
 			name: "Return error on creation failure",
 			service: model.Service{
@@ -165,6 +183,34 @@ func TestCreateDnsEndpoint(t *testing.T) {
 			updated:  false,
 			errIsNil: true,
 		},
+		{
+			name: "Update DNSEndpoint when a hostname is added",
+			service: model.Service{
+				Spec: model.ServiceSpec{
+					Name:                  "service",
+					Namespace:             "default",
+					CustomerDomainName:    "custom-domain",
+					AdditionalDomainNames: []string{"blue-domain"},
+				},
+				Status: &model.ServiceStatus{
+					Dns: "lattice-internal-domain",
+				},
+			},
+			existingEndpoint: endpoint.DNSEndpoint{
+				Spec: endpoint.DNSEndpointSpec{
+					Endpoints: []*endpoint.Endpoint{
+						{
+							DNSName:    "custom-domain",
+							Targets:    []string{"lattice-internal-domain"},
+							RecordType: "CNAME",
+							RecordTTL:  300,
+						},
+					},
+				},
+			},
+			updated:  true,
+			errIsNil: true,
+		},
 		{
 			name: "Return error on update failure",
 			service: model.Service{
@@ -248,13 +294,21 @@ func TestCreateDnsEndpoint(t *testing.T) {
 				return tt.dnsGetErr
 			}).AnyTimes()
 
-			createCall := mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).Return(tt.dnsCreateErr)
+			createCall := mockClient.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, ep *endpoint.DNSEndpoint, _ ...interface{}) error {
+					assertEndpoints(t, tt.service, ep)
+					return tt.dnsCreateErr
+				})
 			if tt.created {
 				createCall.Times(1)
 			} else {
 				createCall.Times(0)
 			}
-			patchCall := mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.dnsUpdateErr)
+			patchCall := mockClient.EXPECT().Patch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, ep *endpoint.DNSEndpoint, _ client.Patch, _ ...interface{}) error {
+					assertEndpoints(t, tt.service, ep)
+					return tt.dnsUpdateErr
+				})
 			if tt.updated {
 				patchCall.Times(1)
 			} else {
@@ -270,3 +324,13 @@ func TestCreateDnsEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func assertEndpoints(t *testing.T, service model.Service, ep *endpoint.DNSEndpoint) {
+	dnsNames := append([]string{service.Spec.CustomerDomainName}, service.Spec.AdditionalDomainNames...)
+	assert.Len(t, ep.Spec.Endpoints, len(dnsNames))
+	for i, dnsName := range dnsNames {
+		assert.Equal(t, dnsName, ep.Spec.Endpoints[i].DNSName)
+		assert.Equal(t, []string{service.Status.Dns}, []string(ep.Spec.Endpoints[i].Targets))
+		assert.Equal(t, "CNAME", ep.Spec.Endpoints[i].RecordType)
+	}
+}
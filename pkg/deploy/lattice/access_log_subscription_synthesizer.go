@@ -58,12 +58,14 @@ func (s *accessLogSubscriptionSynthesizer) Synthesize(ctx context.Context) error
 			s.log.Debugf("Started deleting Access Log Subscription %s", als.ID())
 			if als.Status == nil {
 				s.log.Debugf("Ignoring deletion of Access Log Subscription because als %s has no ARN", als.ID())
-				return nil
+				continue
 			}
 			err := s.accessLogSubscriptionManager.Delete(ctx, als.Status.Arn)
 			if err != nil {
 				return err
 			}
+			// the subscription no longer exists
+			als.Status = nil
 		}
 	}
 
@@ -37,7 +37,8 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 			},
 		}
 
-		stack, accessLogSubscription, _ := builder.Build(context.Background(), input)
+		stack, accessLogSubscriptions, _ := builder.Build(context.Background(), input)
+		accessLogSubscription := accessLogSubscriptions[0]
 
 		mockManager.EXPECT().Create(ctx, accessLogSubscription).Return(&lattice.AccessLogSubscriptionStatus{}, nil).Times(1)
 
@@ -57,7 +58,8 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 			},
 		}
 
-		stack, accessLogSubscription, _ := builder.Build(context.Background(), input)
+		stack, accessLogSubscriptions, _ := builder.Build(context.Background(), input)
+		accessLogSubscription := accessLogSubscriptions[0]
 
 		mockManager.EXPECT().Create(ctx, accessLogSubscription).Return(nil, errors.New("mock error")).Times(1)
 
@@ -82,7 +84,8 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 			},
 		}
 
-		stack, accessLogSubscription, _ := builder.Build(context.Background(), input)
+		stack, accessLogSubscriptions, _ := builder.Build(context.Background(), input)
+		accessLogSubscription := accessLogSubscriptions[0]
 
 		k8sClient.EXPECT().List(context.Background(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		mockManager.EXPECT().Update(ctx, accessLogSubscription).Return(&lattice.AccessLogSubscriptionStatus{}, nil).AnyTimes()
@@ -108,7 +111,8 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 			},
 		}
 
-		stack, accessLogSubscription, _ := builder.Build(context.Background(), input)
+		stack, accessLogSubscriptions, _ := builder.Build(context.Background(), input)
+		accessLogSubscription := accessLogSubscriptions[0]
 
 		k8sClient.EXPECT().List(context.Background(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 		mockManager.EXPECT().Update(ctx, accessLogSubscription).Return(nil, errors.New("mock error")).AnyTimes()
@@ -135,7 +139,8 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 			},
 		}
 
-		stack, accessLogSubscription, _ := builder.Build(context.Background(), input)
+		stack, accessLogSubscriptions, _ := builder.Build(context.Background(), input)
+		accessLogSubscription := accessLogSubscriptions[0]
 
 		mockManager.EXPECT().Delete(ctx, accessLogSubscription.Status.Arn).Return(nil).Times(1)
 
@@ -185,7 +190,8 @@ func TestSynthesizeAccessLogSubscription(t *testing.T) {
 			},
 		}
 
-		stack, accessLogSubscription, _ := builder.Build(context.Background(), input)
+		stack, accessLogSubscriptions, _ := builder.Build(context.Background(), input)
+		accessLogSubscription := accessLogSubscriptions[0]
 
 		mockManager.EXPECT().Delete(ctx, accessLogSubscription.Status.Arn).Return(errors.New("mock error")).Times(1)
 
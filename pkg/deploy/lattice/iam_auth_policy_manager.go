@@ -0,0 +1,116 @@
+package lattice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+
+	an_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+//go:generate mockgen -destination iam_auth_policy_manager_mock.go -package lattice github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice IAMAuthPolicyManager
+
+type IAMAuthPolicyManager interface {
+	Put(ctx context.Context, policy *lattice.IAMAuthPolicy) (lattice.IAMAuthPolicyStatus, error)
+	Delete(ctx context.Context, targetType lattice.IAMAuthPolicyTargetType, resourceId string) error
+}
+
+type defaultIAMAuthPolicyManager struct {
+	log   gwlog.Logger
+	cloud an_aws.Cloud
+}
+
+func NewIAMAuthPolicyManager(
+	log gwlog.Logger,
+	cloud an_aws.Cloud,
+) *defaultIAMAuthPolicyManager {
+	return &defaultIAMAuthPolicyManager{
+		log:   log,
+		cloud: cloud,
+	}
+}
+
+// Put switches the target resource to AWS_IAM auth type and then attaches the auth policy to it.
+// The auth type has to be set first, otherwise VPC Lattice keeps the policy in INACTIVE state.
+func (m *defaultIAMAuthPolicyManager) Put(
+	ctx context.Context,
+	policy *lattice.IAMAuthPolicy,
+) (lattice.IAMAuthPolicyStatus, error) {
+	m.log.Debugf("Putting IAM auth policy for %s %s", policy.Type, policy.ResourceId)
+
+	if err := m.setAuthType(ctx, policy.Type, policy.ResourceId, vpclattice.AuthTypeAwsIam); err != nil {
+		return lattice.IAMAuthPolicyStatus{}, err
+	}
+
+	putAuthPolicyOutput, err := m.cloud.Lattice().PutAuthPolicyWithContext(ctx, &vpclattice.PutAuthPolicyInput{
+		Policy:             aws.String(policy.Policy),
+		ResourceIdentifier: aws.String(policy.ResourceId),
+	})
+	if err != nil {
+		return lattice.IAMAuthPolicyStatus{}, err
+	}
+
+	return lattice.IAMAuthPolicyStatus{
+		ResourceId: policy.ResourceId,
+		State:      aws.StringValue(putAuthPolicyOutput.State),
+	}, nil
+}
+
+// Delete detaches the auth policy from the target resource and switches it back to NONE auth type.
+// A policy which is already detached still has its resource switched back, as an earlier attempt may have
+// failed in between. Resources which are already gone from VPC Lattice are ignored.
+func (m *defaultIAMAuthPolicyManager) Delete(
+	ctx context.Context,
+	targetType lattice.IAMAuthPolicyTargetType,
+	resourceId string,
+) error {
+	m.log.Debugf("Deleting IAM auth policy for %s %s", targetType, resourceId)
+
+	_, err := m.cloud.Lattice().DeleteAuthPolicyWithContext(ctx, &vpclattice.DeleteAuthPolicyInput{
+		ResourceIdentifier: aws.String(resourceId),
+	})
+	if err != nil {
+		if _, ok := err.(*vpclattice.ResourceNotFoundException); !ok {
+			return err
+		}
+	}
+
+	err = m.setAuthType(ctx, targetType, resourceId, vpclattice.AuthTypeNone)
+	if err != nil {
+		if _, ok := err.(*vpclattice.ResourceNotFoundException); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (m *defaultIAMAuthPolicyManager) setAuthType(
+	ctx context.Context,
+	targetType lattice.IAMAuthPolicyTargetType,
+	resourceId string,
+	authType string,
+) error {
+	vpcLatticeSess := m.cloud.Lattice()
+
+	switch targetType {
+	case lattice.ServiceNetworkIAMAuthPolicyTarget:
+		_, err := vpcLatticeSess.UpdateServiceNetworkWithContext(ctx, &vpclattice.UpdateServiceNetworkInput{
+			AuthType:                 aws.String(authType),
+			ServiceNetworkIdentifier: aws.String(resourceId),
+		})
+		return err
+	case lattice.ServiceIAMAuthPolicyTarget:
+		_, err := vpcLatticeSess.UpdateServiceWithContext(ctx, &vpclattice.UpdateServiceInput{
+			AuthType:          aws.String(authType),
+			ServiceIdentifier: aws.String(resourceId),
+		})
+		return err
+	default:
+		return fmt.Errorf("unsupported IAM auth policy target type: %s", targetType)
+	}
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/aws/aws-application-networking-k8s/pkg/deploy/lattice (interfaces: IAMAuthPolicyManager)
+
+// Package lattice is a generated GoMock package.
+package lattice
+
+import (
+	context "context"
+	reflect "reflect"
+
+	lattice "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAMAuthPolicyManager is a mock of IAMAuthPolicyManager interface.
+type MockIAMAuthPolicyManager struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAMAuthPolicyManagerMockRecorder
+}
+
+// MockIAMAuthPolicyManagerMockRecorder is the mock recorder for MockIAMAuthPolicyManager.
+type MockIAMAuthPolicyManagerMockRecorder struct {
+	mock *MockIAMAuthPolicyManager
+}
+
+// NewMockIAMAuthPolicyManager creates a new mock instance.
+func NewMockIAMAuthPolicyManager(ctrl *gomock.Controller) *MockIAMAuthPolicyManager {
+	mock := &MockIAMAuthPolicyManager{ctrl: ctrl}
+	mock.recorder = &MockIAMAuthPolicyManagerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAMAuthPolicyManager) EXPECT() *MockIAMAuthPolicyManagerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIAMAuthPolicyManager) Delete(arg0 context.Context, arg1 lattice.IAMAuthPolicyTargetType, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIAMAuthPolicyManagerMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIAMAuthPolicyManager)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Put mocks base method.
+func (m *MockIAMAuthPolicyManager) Put(arg0 context.Context, arg1 *lattice.IAMAuthPolicy) (lattice.IAMAuthPolicyStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", arg0, arg1)
+	ret0, _ := ret[0].(lattice.IAMAuthPolicyStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockIAMAuthPolicyManagerMockRecorder) Put(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockIAMAuthPolicyManager)(nil).Put), arg0, arg1)
+}
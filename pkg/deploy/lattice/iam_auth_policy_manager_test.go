@@ -0,0 +1,144 @@
+package lattice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	an_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+const testAuthPolicy = `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"vpc-lattice-svcs:Invoke","Resource":"*"}]}`
+
+func Test_IAMAuthPolicyManager_Put(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := services.NewMockLattice(c)
+	cloud := an_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	m := NewIAMAuthPolicyManager(gwlog.FallbackLogger, cloud)
+
+	t.Run("service network", func(t *testing.T) {
+		gomock.InOrder(
+			mockLattice.EXPECT().UpdateServiceNetworkWithContext(ctx, &vpclattice.UpdateServiceNetworkInput{
+				AuthType:                 aws.String(vpclattice.AuthTypeAwsIam),
+				ServiceNetworkIdentifier: aws.String("sn-id"),
+			}).Return(&vpclattice.UpdateServiceNetworkOutput{}, nil),
+			mockLattice.EXPECT().PutAuthPolicyWithContext(ctx, &vpclattice.PutAuthPolicyInput{
+				Policy:             aws.String(testAuthPolicy),
+				ResourceIdentifier: aws.String("sn-id"),
+			}).Return(&vpclattice.PutAuthPolicyOutput{State: aws.String(vpclattice.AuthPolicyStateActive)}, nil),
+		)
+
+		status, err := m.Put(ctx, &lattice.IAMAuthPolicy{
+			Type:       lattice.ServiceNetworkIAMAuthPolicyTarget,
+			ResourceId: "sn-id",
+			Policy:     testAuthPolicy,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, lattice.IAMAuthPolicyStatus{ResourceId: "sn-id", State: vpclattice.AuthPolicyStateActive}, status)
+	})
+
+	t.Run("service", func(t *testing.T) {
+		gomock.InOrder(
+			mockLattice.EXPECT().UpdateServiceWithContext(ctx, &vpclattice.UpdateServiceInput{
+				AuthType:          aws.String(vpclattice.AuthTypeAwsIam),
+				ServiceIdentifier: aws.String("svc-id"),
+			}).Return(&vpclattice.UpdateServiceOutput{}, nil),
+			mockLattice.EXPECT().PutAuthPolicyWithContext(ctx, &vpclattice.PutAuthPolicyInput{
+				Policy:             aws.String(testAuthPolicy),
+				ResourceIdentifier: aws.String("svc-id"),
+			}).Return(&vpclattice.PutAuthPolicyOutput{State: aws.String(vpclattice.AuthPolicyStateActive)}, nil),
+		)
+
+		status, err := m.Put(ctx, &lattice.IAMAuthPolicy{
+			Type:       lattice.ServiceIAMAuthPolicyTarget,
+			ResourceId: "svc-id",
+			Policy:     testAuthPolicy,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "svc-id", status.ResourceId)
+	})
+
+	t.Run("update auth type fails", func(t *testing.T) {
+		mockLattice.EXPECT().UpdateServiceWithContext(ctx, gomock.Any()).Return(nil, errors.New("error"))
+
+		_, err := m.Put(ctx, &lattice.IAMAuthPolicy{
+			Type:       lattice.ServiceIAMAuthPolicyTarget,
+			ResourceId: "svc-id",
+			Policy:     testAuthPolicy,
+		})
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_IAMAuthPolicyManager_Delete(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := services.NewMockLattice(c)
+	cloud := an_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	m := NewIAMAuthPolicyManager(gwlog.FallbackLogger, cloud)
+
+	t.Run("service network", func(t *testing.T) {
+		gomock.InOrder(
+			mockLattice.EXPECT().DeleteAuthPolicyWithContext(ctx, &vpclattice.DeleteAuthPolicyInput{
+				ResourceIdentifier: aws.String("sn-id"),
+			}).Return(&vpclattice.DeleteAuthPolicyOutput{}, nil),
+			mockLattice.EXPECT().UpdateServiceNetworkWithContext(ctx, &vpclattice.UpdateServiceNetworkInput{
+				AuthType:                 aws.String(vpclattice.AuthTypeNone),
+				ServiceNetworkIdentifier: aws.String("sn-id"),
+			}).Return(&vpclattice.UpdateServiceNetworkOutput{}, nil),
+		)
+
+		err := m.Delete(ctx, lattice.ServiceNetworkIAMAuthPolicyTarget, "sn-id")
+		assert.Nil(t, err)
+	})
+
+	t.Run("service", func(t *testing.T) {
+		gomock.InOrder(
+			mockLattice.EXPECT().DeleteAuthPolicyWithContext(ctx, gomock.Any()).Return(&vpclattice.DeleteAuthPolicyOutput{}, nil),
+			mockLattice.EXPECT().UpdateServiceWithContext(ctx, &vpclattice.UpdateServiceInput{
+				AuthType:          aws.String(vpclattice.AuthTypeNone),
+				ServiceIdentifier: aws.String("svc-id"),
+			}).Return(&vpclattice.UpdateServiceOutput{}, nil),
+		)
+
+		err := m.Delete(ctx, lattice.ServiceIAMAuthPolicyTarget, "svc-id")
+		assert.Nil(t, err)
+	})
+
+	t.Run("policy already deleted", func(t *testing.T) {
+		gomock.InOrder(
+			mockLattice.EXPECT().DeleteAuthPolicyWithContext(ctx, gomock.Any()).
+				Return(nil, &vpclattice.ResourceNotFoundException{}),
+			mockLattice.EXPECT().UpdateServiceWithContext(ctx, &vpclattice.UpdateServiceInput{
+				AuthType:          aws.String(vpclattice.AuthTypeNone),
+				ServiceIdentifier: aws.String("svc-id"),
+			}).Return(&vpclattice.UpdateServiceOutput{}, nil),
+		)
+
+		err := m.Delete(ctx, lattice.ServiceIAMAuthPolicyTarget, "svc-id")
+		assert.Nil(t, err)
+	})
+
+	t.Run("resource already deleted", func(t *testing.T) {
+		gomock.InOrder(
+			mockLattice.EXPECT().DeleteAuthPolicyWithContext(ctx, gomock.Any()).
+				Return(nil, &vpclattice.ResourceNotFoundException{}),
+			mockLattice.EXPECT().UpdateServiceWithContext(ctx, gomock.Any()).
+				Return(nil, &vpclattice.ResourceNotFoundException{}),
+		)
+
+		err := m.Delete(ctx, lattice.ServiceIAMAuthPolicyTarget, "svc-id")
+		assert.Nil(t, err)
+	})
+}
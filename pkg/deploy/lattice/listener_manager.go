@@ -74,9 +74,18 @@ func (d *defaultListenerManager) Create(
 		}
 	}
 
+	defaultAction, err := d.buildDefaultAction(listener)
+	if err != nil {
+		return model.ListenerStatus{}, err
+	}
+
 	lis, err2 := d.findListenerByNamePort(ctx, *svc.Id, listener.Spec.Port)
 	if err2 == nil {
 		// update Listener
+		if err := d.updateDefaultAction(ctx, aws.StringValue(svc.Id), aws.StringValue(lis.Id), defaultAction); err != nil {
+			return model.ListenerStatus{}, err
+		}
+
 		k8sName, k8sNamespace := latticeName2k8s(aws.StringValue(lis.Name))
 		return model.ListenerStatus{
 			Name:        k8sName,
@@ -89,17 +98,9 @@ func (d *defaultListenerManager) Create(
 		}, nil
 	}
 
-	defaultStatus := aws.Int64(404)
-
-	defaultResp := vpclattice.FixedResponseAction{
-		StatusCode: defaultStatus,
-	}
-
 	listenerInput := vpclattice.CreateListenerInput{
-		ClientToken: nil,
-		DefaultAction: &vpclattice.RuleAction{
-			FixedResponse: &defaultResp,
-		},
+		ClientToken:       nil,
+		DefaultAction:     defaultAction,
 		Name:              aws.String(k8sLatticeListenerName(listener.Spec.Name, listener.Spec.Namespace, int(listener.Spec.Port), listener.Spec.Protocol)),
 		Port:              aws.Int64(listener.Spec.Port),
 		Protocol:          aws.String(listener.Spec.Protocol),
@@ -123,8 +124,67 @@ func (d *defaultListenerManager) Create(
 	}, nil
 }
 
+// buildDefaultAction forwards to the target groups of the model default action if it has any,
+// otherwise it replies with its fixed response status code
+func (d *defaultListenerManager) buildDefaultAction(listener *model.Listener) (*vpclattice.RuleAction, error) {
+	action := listener.Spec.DefaultAction
+
+	if action.Forward != nil {
+		latticeTGs, err := buildLatticeTargetGroups(d.latticeDataStore, action.Forward.TargetGroups)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build default action of listener %s-%s, %w",
+				listener.Spec.Name, listener.Spec.Namespace, err)
+		}
+		return &vpclattice.RuleAction{
+			Forward: &vpclattice.ForwardAction{
+				TargetGroups: latticeTGs,
+			},
+		}, nil
+	}
+
+	statusCode := action.FixedResponseStatusCode
+	if statusCode == 0 {
+		statusCode = 404
+	}
+	return &vpclattice.RuleAction{
+		FixedResponse: &vpclattice.FixedResponseAction{
+			StatusCode: aws.Int64(statusCode),
+		},
+	}, nil
+}
+
+// updateDefaultAction updates the default action of an existing listener when it drifted from the desired one
+func (d *defaultListenerManager) updateDefaultAction(
+	ctx context.Context,
+	serviceId string,
+	listenerId string,
+	defaultAction *vpclattice.RuleAction,
+) error {
+	resp, err := d.cloud.Lattice().GetListenerWithContext(ctx, &vpclattice.GetListenerInput{
+		ServiceIdentifier:  aws.String(serviceId),
+		ListenerIdentifier: aws.String(listenerId),
+	})
+	if err != nil {
+		return err
+	}
+
+	if isRuleActionSame(defaultAction, resp.DefaultAction) {
+		return nil
+	}
+
+	d.log.Infof("Updating default action of listener %s in service %s", listenerId, serviceId)
+	_, err = d.cloud.Lattice().UpdateListenerWithContext(ctx, &vpclattice.UpdateListenerInput{
+		DefaultAction:      defaultAction,
+		ServiceIdentifier:  aws.String(serviceId),
+		ListenerIdentifier: aws.String(listenerId),
+	})
+	return err
+}
+
 func k8sLatticeListenerName(name string, namespace string, port int, protocol string) string {
-	listenerName := fmt.Sprintf("%s-%s-%d-%s", utils.Truncate(name, 20), utils.Truncate(namespace, 18), port, strings.ToLower(protocol))
+	// lattice names cannot contain underscores, as in TLS_PASSTHROUGH
+	protocol = strings.ReplaceAll(strings.ToLower(protocol), "_", "-")
+	listenerName := fmt.Sprintf("%s-%s-%d-%s", utils.Truncate(name, 20), utils.Truncate(namespace, 18), port, protocol)
 	return listenerName
 }
 func latticeName2k8s(name string) (string, string) {
@@ -120,8 +120,7 @@ func Test_AddListener(t *testing.T) {
 			stack := core.NewDefaultStack(core.StackID(namespaceName))
 
 			action := model.DefaultAction{
-				BackendServiceName:      "tg-test",
-				BackendServiceNamespace: "tg-default",
+				FixedResponseStatusCode: 404,
 			}
 
 			listenerResourceName := fmt.Sprintf("%s-%s-%d-%s", namespaceName.Name, namespaceName.Namespace,
@@ -189,6 +188,14 @@ func Test_AddListener(t *testing.T) {
 
 				mockLattice.EXPECT().ListListenersWithContext(ctx, &listenerListInput).Return(&listenerOutput, nil)
 			}
+
+			if tt.isUpdate {
+				// default action did not drift, no update expected
+				mockLattice.EXPECT().GetListenerWithContext(ctx, &vpclattice.GetListenerInput{
+					ServiceIdentifier:  aws.String(serviceID),
+					ListenerIdentifier: aws.String(listenerSummaries[0].Id),
+				}).Return(&vpclattice.GetListenerOutput{DefaultAction: &defaultAction}, nil)
+			}
 			resp, err := listenerManager.Create(ctx, listener)
 
 			if !tt.noServiceID {
@@ -287,3 +294,69 @@ func Test_DeleteListener(t *testing.T) {
 	err := listenerManager.Delete(ctx, listenerID, serviceID)
 	assert.Nil(t, err)
 }
+
+func Test_AddListener_UpdateDefaultAction(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	latticeDataStore.AddTargetGroup(latticestore.TargetGroupName("tg-test", "default"), "vpc", "tg-arn", "tg-id", false, "test")
+	listenerManager := NewListenerManager(gwlog.FallbackLogger, cloud, latticeDataStore)
+
+	stack := core.NewDefaultStack(core.StackID(namespaceName))
+	action := model.DefaultAction{
+		Forward: &model.RuleAction{
+			TargetGroups: []*model.RuleTargetGroup{
+				{Name: "tg-test", Namespace: "default", RouteName: "test", Weight: 1},
+			},
+		},
+	}
+	listener := model.NewListener(stack, "test-default-80-HTTP", listenerSummaries[0].Port, "HTTP",
+		namespaceName.Name, namespaceName.Namespace, action)
+
+	mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(
+		&vpclattice.ServiceSummary{
+			Arn: aws.String("serviceARN"),
+			Id:  aws.String("serviceID"),
+			DnsEntry: &vpclattice.DnsEntry{
+				DomainName:   aws.String("DNS-test"),
+				HostedZoneId: aws.String("my-favourite-zone"),
+			},
+		}, nil)
+	mockLattice.EXPECT().ListListenersWithContext(ctx, gomock.Any()).Return(&listenerList, nil)
+
+	// the listener still replies with the fixed response it was created with
+	mockLattice.EXPECT().GetListenerWithContext(ctx, &vpclattice.GetListenerInput{
+		ServiceIdentifier:  aws.String("serviceID"),
+		ListenerIdentifier: aws.String(listenerSummaries[0].Id),
+	}).Return(&vpclattice.GetListenerOutput{
+		DefaultAction: &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)},
+		},
+	}, nil)
+	mockLattice.EXPECT().UpdateListenerWithContext(ctx, &vpclattice.UpdateListenerInput{
+		ServiceIdentifier:  aws.String("serviceID"),
+		ListenerIdentifier: aws.String(listenerSummaries[0].Id),
+		DefaultAction: &vpclattice.RuleAction{
+			Forward: &vpclattice.ForwardAction{
+				TargetGroups: []*vpclattice.WeightedTargetGroup{
+					{TargetGroupIdentifier: aws.String("tg-id"), Weight: aws.Int64(1)},
+				},
+			},
+		},
+	}).Return(&vpclattice.UpdateListenerOutput{}, nil)
+
+	resp, err := listenerManager.Create(ctx, listener)
+	assert.NoError(t, err)
+	assert.Equal(t, listenerSummaries[0].Id, resp.ListenerID)
+}
+
+func Test_k8sLatticeListenerName(t *testing.T) {
+	assert.Equal(t, "route-default-80-http", k8sLatticeListenerName("route", "default", 80, "HTTP"))
+	assert.Equal(t, "route-default-443-tls-passthrough",
+		k8sLatticeListenerName("route", "default", 443, model.ListenerProtocolTLSPassthrough))
+}
@@ -133,8 +133,7 @@ func Test_SynthesizeListener(t *testing.T) {
 			}
 
 			action := model.DefaultAction{
-				BackendServiceName:      "test",
-				BackendServiceNamespace: "default",
+				FixedResponseStatusCode: 404,
 			}
 
 			stackService := model.NewLatticeService(stack, "", spec)
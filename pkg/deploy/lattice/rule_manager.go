@@ -2,8 +2,7 @@ package lattice
 
 import (
 	"context"
-	"errors"
-	"fmt"
+	"encoding/hex"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -22,9 +21,10 @@ import (
 
 type RuleManager interface {
 	Cloud() pkg_aws.Cloud
-	Create(ctx context.Context, rule *model.Rule) (model.RuleStatus, error)
+	Create(ctx context.Context, rule *model.Rule, serviceId string, listenerId string, priority int64) (model.RuleStatus, error)
+	UpdateAction(ctx context.Context, rule *model.Rule, ruleStatus *model.RuleStatus) error
+	UpdatePriority(ctx context.Context, ruleStatus *model.RuleStatus, priority int64) error
 	Delete(ctx context.Context, ruleId string, listenerId string, serviceId string) error
-	Update(ctx context.Context, rules []*model.Rule) error
 	List(ctx context.Context, serviceId string, listenerId string) ([]*model.RuleStatus, error)
 	Get(ctx context.Context, serviceId string, listenerId string, ruleId string) (*vpclattice.GetRuleOutput, error)
 }
@@ -88,7 +88,10 @@ func (r *defaultRuleManager) List(ctx context.Context, service string, listener
 	for _, ruleSum := range resp.Items {
 		if !aws.BoolValue(ruleSum.IsDefault) {
 			sdkRules = append(sdkRules, &model.RuleStatus{
+				RuleARN:    aws.StringValue(ruleSum.Arn),
 				RuleID:     aws.StringValue(ruleSum.Id),
+				Name:       aws.StringValue(ruleSum.Name),
+				Priority:   aws.Int64Value(ruleSum.Priority),
 				ServiceID:  service,
 				ListenerID: listener,
 			})
@@ -98,87 +101,142 @@ func (r *defaultRuleManager) List(ctx context.Context, service string, listener
 	return sdkRules, nil
 }
 
-// today, it only batch update the priority
-func (r *defaultRuleManager) Update(ctx context.Context, rules []*model.Rule) error {
-	firstRuleSpec := rules[0].Spec
-	var ruleUpdateList []*vpclattice.RuleUpdate
+// Create creates the lattice rule at the given priority, which has to be free on the listener
+func (r *defaultRuleManager) Create(
+	ctx context.Context,
+	rule *model.Rule,
+	serviceId string,
+	listenerId string,
+	priority int64,
+) (model.RuleStatus, error) {
+	r.log.Debugf("Creating rule %s for service %s-%s and listener port %d and protocol %s at priority %d",
+		rule.Spec.RuleID, rule.Spec.ServiceName, rule.Spec.ServiceNamespace,
+		rule.Spec.ListenerPort, rule.Spec.ListenerProtocol, priority)
 
-	latticeService, err := r.cloud.Lattice().FindService(ctx, &RuleLSNProvider{rules[0]})
+	action, err := buildLatticeRuleAction(r.latticeDataStore, rule.Spec.Action)
 	if err != nil {
-		return fmt.Errorf("service %s-%s not found during rule creation",
-			firstRuleSpec.ServiceName, firstRuleSpec.ServiceNamespace)
+		return model.RuleStatus{}, err
 	}
 
-	listener, err := r.latticeDataStore.GetlListener(firstRuleSpec.ServiceName, firstRuleSpec.ServiceNamespace,
-		firstRuleSpec.ListenerPort, firstRuleSpec.ListenerProtocol)
+	httpMatch := vpclattice.HttpMatch{}
 
+	updateSDKhttpMatch(&httpMatch, rule)
+
+	ruleName := latticeRuleName(rule)
+	ruleInput := vpclattice.CreateRuleInput{
+		Action:             action,
+		ClientToken:        nil,
+		ListenerIdentifier: aws.String(listenerId),
+		Match: &vpclattice.RuleMatch{
+			HttpMatch: &httpMatch,
+		},
+		Name:              aws.String(ruleName),
+		Priority:          aws.Int64(priority),
+		ServiceIdentifier: aws.String(serviceId),
+		Tags:              r.cloud.DefaultTags(),
+	}
+
+	resp, err := r.cloud.Lattice().CreateRule(&ruleInput)
 	if err != nil {
-		return fmt.Errorf("listener not found during rule creation for service %s-%s, port %d, protocol %s",
-			firstRuleSpec.ServiceName, firstRuleSpec.ServiceNamespace, firstRuleSpec.ListenerPort, firstRuleSpec.ListenerProtocol)
+		return model.RuleStatus{}, err
 	}
 
-	for _, rule := range rules {
-		priority, _ := ruleID2Priority(rule.Spec.RuleID)
-		ruleUpdate := vpclattice.RuleUpdate{
-			RuleIdentifier: aws.String(rule.Status.RuleID),
-			Priority:       aws.Int64(priority),
-		}
+	return model.RuleStatus{
+		RuleARN:    aws.StringValue(resp.Arn),
+		RuleID:     aws.StringValue(resp.Id),
+		Name:       ruleName,
+		Priority:   priority,
+		ListenerID: listenerId,
+		ServiceID:  serviceId,
+	}, nil
+}
 
-		ruleUpdateList = append(ruleUpdateList, &ruleUpdate)
+// UpdateAction sets the action of an existing lattice rule to the action of the model rule,
+// its match and priority stay untouched
+func (r *defaultRuleManager) UpdateAction(ctx context.Context, rule *model.Rule, ruleStatus *model.RuleStatus) error {
+	r.log.Debugf("Updating action of rule %s for service %s-%s",
+		rule.Spec.RuleID, rule.Spec.ServiceName, rule.Spec.ServiceNamespace)
+
+	action, err := buildLatticeRuleAction(r.latticeDataStore, rule.Spec.Action)
+	if err != nil {
+		return err
 	}
 
-	// BatchUpdate rules using right priority
-	batchRuleInput := vpclattice.BatchUpdateRuleInput{
-		ListenerIdentifier: aws.String(listener.ID),
-		ServiceIdentifier:  aws.String(*latticeService.Id),
-		Rules:              ruleUpdateList,
+	updateRuleInput := vpclattice.UpdateRuleInput{
+		Action:             action,
+		ListenerIdentifier: aws.String(ruleStatus.ListenerID),
+		ServiceIdentifier:  aws.String(ruleStatus.ServiceID),
+		RuleIdentifier:     aws.String(ruleStatus.RuleID),
 	}
 
-	_, err = r.cloud.Lattice().BatchUpdateRule(&batchRuleInput)
+	_, err = r.cloud.Lattice().UpdateRule(&updateRuleInput)
 	return err
 }
 
-func (r *defaultRuleManager) Create(ctx context.Context, rule *model.Rule) (model.RuleStatus, error) {
-	r.log.Debugf("Creating rule %s for service %s-%s and listener port %d and protocol %s",
-		rule.Spec.RuleID, rule.Spec.ServiceName, rule.Spec.ServiceNamespace,
-		rule.Spec.ListenerPort, rule.Spec.ListenerProtocol)
+// UpdatePriority moves an existing lattice rule to the given priority, which has to be free on the listener
+func (r *defaultRuleManager) UpdatePriority(ctx context.Context, ruleStatus *model.RuleStatus, priority int64) error {
+	r.log.Debugf("Updating priority of rule %s from %d to %d", ruleStatus.RuleID, ruleStatus.Priority, priority)
 
-	latticeService, err := r.cloud.Lattice().FindService(ctx, &RuleLSNProvider{rule})
-	if err != nil {
-		return model.RuleStatus{}, err
+	updateRuleInput := vpclattice.UpdateRuleInput{
+		ListenerIdentifier: aws.String(ruleStatus.ListenerID),
+		ServiceIdentifier:  aws.String(ruleStatus.ServiceID),
+		RuleIdentifier:     aws.String(ruleStatus.RuleID),
+		Priority:           aws.Int64(priority),
 	}
 
-	listener, err := r.latticeDataStore.GetlListener(rule.Spec.ServiceName, rule.Spec.ServiceNamespace,
-		rule.Spec.ListenerPort, rule.Spec.ListenerProtocol)
+	_, err := r.cloud.Lattice().UpdateRule(&updateRuleInput)
 	if err != nil {
-		return model.RuleStatus{}, err
+		return err
 	}
+	ruleStatus.Priority = priority
+	return nil
+}
 
-	priority, err := ruleID2Priority(rule.Spec.RuleID)
-	if err != nil {
-		return model.RuleStatus{}, fmt.Errorf("failed to create rule due to invalid ruleId, err: %s", err)
+// buildLatticeRuleAction replies with the fixed response of the model action if it has one,
+// otherwise it forwards to its target groups
+func buildLatticeRuleAction(store *latticestore.LatticeDataStore, action model.RuleAction) (*vpclattice.RuleAction, error) {
+	if action.FixedResponseStatusCode != 0 {
+		return &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{
+				StatusCode: aws.Int64(action.FixedResponseStatusCode),
+			},
+		}, nil
 	}
-	r.log.Debugf("Converted rule id %s to priority %d", rule.Spec.RuleID, priority)
 
-	ruleStatus, err := r.findMatchingRule(ctx, rule, *latticeService.Id, listener.ID)
-	if err == nil && !ruleStatus.UpdateTGsNeeded {
-		if ruleStatus.Priority != priority {
-			r.log.Debugf("Need to BatchUpdate priority for rule %s", rule.Spec.RuleID)
-			ruleStatus.UpdatePriorityNeeded = true
-		}
-		return ruleStatus, nil
+	latticeTGs, err := buildLatticeTargetGroups(store, action.TargetGroups)
+	if err != nil {
+		return nil, err
 	}
+	return &vpclattice.RuleAction{
+		Forward: &vpclattice.ForwardAction{
+			TargetGroups: latticeTGs,
+		},
+	}, nil
+}
 
-	// if not found, ruleStatus contains the next available priority
-
+func buildLatticeTargetGroups(
+	store *latticestore.LatticeDataStore,
+	targetGroups []*model.RuleTargetGroup,
+) ([]*vpclattice.WeightedTargetGroup, error) {
 	var latticeTGs []*vpclattice.WeightedTargetGroup
 
-	for _, tgRule := range rule.Spec.Action.TargetGroups {
+	for _, tgRule := range targetGroups {
+		if tgRule.LatticeTargetGroupID != "" {
+			latticeTGs = append(latticeTGs, &vpclattice.WeightedTargetGroup{
+				TargetGroupIdentifier: aws.String(tgRule.LatticeTargetGroupID),
+				Weight:                aws.Int64(tgRule.Weight),
+			})
+			continue
+		}
+
 		tgName := latticestore.TargetGroupName(tgRule.Name, tgRule.Namespace)
+		if tgRule.IsLambdaFunction {
+			tgName = latticestore.LambdaTargetGroupName(tgRule.Name, tgRule.Namespace)
+		}
 
-		tg, err := r.latticeDataStore.GetTargetGroup(tgName, tgRule.RouteName, tgRule.IsServiceImport)
+		tg, err := store.GetTargetGroup(tgName, tgRule.RouteName, tgRule.IsServiceImport)
 		if err != nil {
-			return model.RuleStatus{}, err
+			return nil, err
 		}
 
 		latticeTG := vpclattice.WeightedTargetGroup{
@@ -188,75 +246,61 @@ func (r *defaultRuleManager) Create(ctx context.Context, rule *model.Rule) (mode
 
 		latticeTGs = append(latticeTGs, &latticeTG)
 	}
+	return latticeTGs, nil
+}
 
-	ruleName := fmt.Sprintf("k8s-%d-%s", rule.Spec.CreateTime.Unix(), rule.Spec.RuleID)
-
-	if ruleStatus.UpdateTGsNeeded {
-		httpMatch := vpclattice.HttpMatch{}
+// isRuleActionSame compares the fixed response or the forward action of two lattice actions
+func isRuleActionSame(desired *vpclattice.RuleAction, actual *vpclattice.RuleAction) bool {
+	if desired.Forward != nil {
+		return isForwardActionSame(desired.Forward.TargetGroups, actual)
+	}
+	if actual == nil || actual.FixedResponse == nil {
+		return false
+	}
+	return aws.Int64Value(desired.FixedResponse.StatusCode) == aws.Int64Value(actual.FixedResponse.StatusCode)
+}
 
-		updateSDKhttpMatch(&httpMatch, rule)
+// isForwardActionSame compares the target groups and weights a lattice action forwards to
+func isForwardActionSame(latticeTGs []*vpclattice.WeightedTargetGroup, action *vpclattice.RuleAction) bool {
+	if action == nil || action.Forward == nil {
+		return false
+	}
 
-		updateRuleInput := vpclattice.UpdateRuleInput{
-			Action: &vpclattice.RuleAction{
-				Forward: &vpclattice.ForwardAction{
-					TargetGroups: latticeTGs,
-				},
-			},
-			ListenerIdentifier: aws.String(listener.ID),
-			Match: &vpclattice.RuleMatch{
-				HttpMatch: &httpMatch,
-			},
-			Priority:          aws.Int64(ruleStatus.Priority),
-			ServiceIdentifier: aws.String(*latticeService.Id),
-			RuleIdentifier:    aws.String(ruleStatus.RuleID),
-		}
+	sdkTGs := action.Forward.TargetGroups
+	if len(sdkTGs) != len(latticeTGs) {
+		return false
+	}
 
-		resp, err := r.cloud.Lattice().UpdateRule(&updateRuleInput)
-		if err != nil {
-			r.log.Errorf("Error updating rule, %s", err)
+	weights := make(map[string]int64)
+	for _, tg := range sdkTGs {
+		weights[aws.StringValue(tg.TargetGroupIdentifier)] = aws.Int64Value(tg.Weight)
+	}
+	for _, tg := range latticeTGs {
+		weight, ok := weights[aws.StringValue(tg.TargetGroupIdentifier)]
+		if !ok || weight != aws.Int64Value(tg.Weight) {
+			return false
 		}
-
-		return model.RuleStatus{
-			RuleID:               aws.StringValue(resp.Id),
-			UpdatePriorityNeeded: ruleStatus.UpdatePriorityNeeded,
-			ServiceID:            aws.StringValue(latticeService.Id),
-			ListenerID:           listener.ID,
-		}, nil
 	}
+	return true
+}
 
-	httpMatch := vpclattice.HttpMatch{}
+// The lattice rule name records the model rule id, so the rule of a match is found again
+// after the route rules are reordered. Names of rules created before that are not recognized.
+const latticeRuleNamePrefix = "k8s-"
 
-	updateSDKhttpMatch(&httpMatch, rule)
+func latticeRuleName(rule *model.Rule) string {
+	return latticeRuleNamePrefix + rule.Spec.RuleID
+}
 
-	ruleInput := vpclattice.CreateRuleInput{
-		Action: &vpclattice.RuleAction{
-			Forward: &vpclattice.ForwardAction{
-				TargetGroups: latticeTGs,
-			},
-		},
-		ClientToken:        nil,
-		ListenerIdentifier: aws.String(listener.ID),
-		Match: &vpclattice.RuleMatch{
-			HttpMatch: &httpMatch,
-		},
-		Name:              aws.String(ruleName),
-		Priority:          aws.Int64(ruleStatus.Priority),
-		ServiceIdentifier: aws.String(*latticeService.Id),
-		Tags:              r.cloud.DefaultTags(),
+func ruleIDFromLatticeRuleName(name string) (string, bool) {
+	if !strings.HasPrefix(name, latticeRuleNamePrefix+"rule-") {
+		return "", false
 	}
-
-	resp, err := r.cloud.Lattice().CreateRule(&ruleInput)
-	if err != nil {
-		return model.RuleStatus{}, err
+	ruleId := strings.TrimPrefix(name, latticeRuleNamePrefix)
+	if _, err := hex.DecodeString(strings.TrimPrefix(ruleId, "rule-")); err != nil {
+		return "", false
 	}
-
-	return model.RuleStatus{
-		RuleID:               *resp.Id,
-		ListenerID:           listener.ID,
-		ServiceID:            aws.StringValue(latticeService.Id),
-		UpdatePriorityNeeded: ruleStatus.UpdatePriorityNeeded,
-		UpdateTGsNeeded:      ruleStatus.UpdatePriorityNeeded,
-	}, nil
+	return ruleId, true
 }
 
 func updateSDKhttpMatch(httpMatch *vpclattice.HttpMatch, rule *model.Rule) {
@@ -280,8 +324,9 @@ func updateSDKhttpMatch(httpMatch *vpclattice.HttpMatch, rule *model.Rule) {
 	if rule.Spec.NumOfHeaderMatches > 0 {
 		for i := 0; i < rule.Spec.NumOfHeaderMatches; i++ {
 			headerMatch := vpclattice.HeaderMatch{
-				Match: rule.Spec.MatchedHeaders[i].Match,
-				Name:  rule.Spec.MatchedHeaders[i].Name,
+				Match:         rule.Spec.MatchedHeaders[i].Match,
+				Name:          rule.Spec.MatchedHeaders[i].Name,
+				CaseSensitive: rule.Spec.MatchedHeaders[i].CaseSensitive,
 			}
 			httpMatch.HeaderMatches = append(httpMatch.HeaderMatches, &headerMatch)
 		}
@@ -340,6 +385,18 @@ func isRulesSame(log gwlog.Logger, modelRule *model.Rule, sdkRuleDetail *vpclatt
 		return false
 	}
 
+	// Fixed response
+	if modelRule.Spec.Action.FixedResponseStatusCode != 0 {
+		if sdkRuleDetail.Action == nil || sdkRuleDetail.Action.FixedResponse == nil ||
+			aws.Int64Value(sdkRuleDetail.Action.FixedResponse.StatusCode) != modelRule.Spec.Action.FixedResponseStatusCode {
+			log.Debugf("fixed response mismatch")
+			return false
+		}
+	} else if sdkRuleDetail.Action != nil && sdkRuleDetail.Action.FixedResponse != nil {
+		log.Debugf("no model fixed response")
+		return false
+	}
+
 	// Header Match
 	if modelRule.Spec.NumOfHeaderMatches > 0 {
 		if len(sdkRuleDetail.Match.HttpMatch.HeaderMatches) != modelRule.Spec.NumOfHeaderMatches {
@@ -355,10 +412,7 @@ func isRulesSame(log gwlog.Logger, modelRule *model.Rule, sdkRuleDetail *vpclatt
 			// check if this is in module
 			for i := 0; i < modelRule.Spec.NumOfHeaderMatches; i++ {
 				// compare header
-				if aws.StringValue(modelRule.Spec.MatchedHeaders[i].Name) ==
-					aws.StringValue(sdkHeader.Name) &&
-					aws.StringValue(modelRule.Spec.MatchedHeaders[i].Match.Exact) ==
-						aws.StringValue(sdkHeader.Match.Exact) {
+				if isHeaderMatchSame(&modelRule.Spec.MatchedHeaders[i], sdkHeader) {
 					matchFound = true
 					break
 				}
@@ -381,145 +435,19 @@ func isRulesSame(log gwlog.Logger, modelRule *model.Rule, sdkRuleDetail *vpclatt
 	return true
 }
 
-// Determine if rule spec is same
-// If rule spec is same, then determine if there is any changes on target groups
-func (r *defaultRuleManager) findMatchingRule(
-	ctx context.Context,
-	rule *model.Rule,
-	serviceId string,
-	listenerId string,
-) (model.RuleStatus, error) {
-	var priorityMap [100]bool
-
-	for i := 1; i < 100; i++ {
-		priorityMap[i] = false
-
-	}
-
-	ruleListInput := vpclattice.ListRulesInput{
-		ListenerIdentifier: aws.String(listenerId),
-		ServiceIdentifier:  aws.String(serviceId),
-	}
-
-	var resp *vpclattice.ListRulesOutput
-	resp, err := r.cloud.Lattice().ListRules(&ruleListInput)
-	if err != nil {
-		return model.RuleStatus{}, err
+func isHeaderMatchSame(modelHeader *vpclattice.HeaderMatch, sdkHeader *vpclattice.HeaderMatch) bool {
+	if aws.StringValue(modelHeader.Name) != aws.StringValue(sdkHeader.Name) {
+		return false
 	}
-
-	var matchRule *vpclattice.GetRuleOutput = nil
-	var updateTGsNeeded = false
-	for _, ruleSum := range resp.Items {
-		if aws.BoolValue(ruleSum.IsDefault) {
-			// Ignore the default
-			continue
-		}
-
-		// retrieve action
-		ruleInput := vpclattice.GetRuleInput{
-			ListenerIdentifier: &listenerId,
-			ServiceIdentifier:  &serviceId,
-			RuleIdentifier:     ruleSum.Id,
-		}
-
-		var ruleResp *vpclattice.GetRuleOutput
-
-		ruleResp, err := r.cloud.Lattice().GetRule(&ruleInput)
-		if err != nil {
-			r.log.Debugf("Matching rule not found, err %s", err)
-			continue
-		}
-
-		priorityMap[aws.Int64Value(ruleResp.Priority)] = true
-
-		ruleIsSame := isRulesSame(r.log, rule, ruleResp)
-		if !ruleIsSame {
-			continue
-		}
-
-		matchRule = ruleResp
-
-		if len(ruleResp.Action.Forward.TargetGroups) != len(rule.Spec.Action.TargetGroups) {
-			r.log.Debugf("Skipping rule due to mismatched number of target groups to forward to")
-			updateTGsNeeded = true
-			continue
-		}
-
-		if len(ruleResp.Action.Forward.TargetGroups) == 0 {
-			r.log.Debugf("Skipping rule due to 0 targetGroups to forward to")
-			continue
-		}
-
-		for _, tg := range ruleResp.Action.Forward.TargetGroups {
-			for _, k8sTG := range rule.Spec.Action.TargetGroups {
-				// get k8sTG id
-				tgName := latticestore.TargetGroupName(k8sTG.Name, k8sTG.Namespace)
-				k8sTGinStore, err := r.latticeDataStore.GetTargetGroup(tgName, rule.Spec.ServiceName, k8sTG.IsServiceImport)
-
-				if err != nil {
-					r.log.Debugf("Failed to find k8s tg %s-%s in datastore", k8sTG.Name, k8sTG.Namespace)
-					updateTGsNeeded = true
-					continue
-				}
-
-				if aws.StringValue(tg.TargetGroupIdentifier) != k8sTGinStore.ID {
-					r.log.Debugf("target group id mismatch in datastore, %s vs. %s",
-						aws.StringValue(tg.TargetGroupIdentifier), k8sTGinStore.ID)
-					updateTGsNeeded = true
-					continue
-
-				}
-
-				if k8sTG.Weight != aws.Int64Value(tg.Weight) {
-					r.log.Debugf("Weight has changed for tg %s, old %d vs. new %d",
-						aws.StringValue(tg.TargetGroupIdentifier), aws.Int64Value(tg.Weight), k8sTG.Weight)
-					updateTGsNeeded = true
-					continue
-				}
-
-				break
-			}
-
-			if updateTGsNeeded {
-				break
-			}
-		}
+	if aws.BoolValue(modelHeader.CaseSensitive) != aws.BoolValue(sdkHeader.CaseSensitive) {
+		return false
 	}
-
-	if matchRule != nil {
-		inputRulePriority, _ := ruleID2Priority(rule.Spec.RuleID)
-
-		UpdatePriority := false
-		if inputRulePriority != aws.Int64Value(matchRule.Priority) {
-			UpdatePriority = true
-		}
-
-		return model.RuleStatus{
-			RuleARN:              aws.StringValue(matchRule.Arn),
-			RuleID:               aws.StringValue(matchRule.Id),
-			Priority:             aws.Int64Value(matchRule.Priority),
-			UpdateTGsNeeded:      updateTGsNeeded,
-			UpdatePriorityNeeded: UpdatePriority,
-		}, nil
-	} else {
-		var nextPriority int64 = 0
-		// find available priority
-		for i := 1; i < 100; i++ {
-			if !priorityMap[i] {
-				nextPriority = int64(i)
-				break
-			}
-
-		}
-		return model.RuleStatus{Priority: nextPriority}, errors.New("rule not found")
+	if modelHeader.Match == nil || sdkHeader.Match == nil {
+		return modelHeader.Match == sdkHeader.Match
 	}
-}
-
-func ruleID2Priority(ruleId string) (int64, error) {
-	var priority int
-	ruleIDName := strings.NewReader(ruleId)
-	_, err := fmt.Fscanf(ruleIDName, "rule-%d", &priority)
-	return int64(priority), err
+	return aws.StringValue(modelHeader.Match.Exact) == aws.StringValue(sdkHeader.Match.Exact) &&
+		aws.StringValue(modelHeader.Match.Prefix) == aws.StringValue(sdkHeader.Match.Prefix) &&
+		aws.StringValue(modelHeader.Match.Contains) == aws.StringValue(sdkHeader.Match.Contains)
 }
 
 func (r *defaultRuleManager) Delete(ctx context.Context, ruleId string, listenerId string, serviceId string) error {
@@ -52,18 +52,18 @@ func (mr *MockRuleManagerMockRecorder) Cloud() *gomock.Call {
 }
 
 // Create mocks base method.
-func (m *MockRuleManager) Create(ctx context.Context, rule *lattice.Rule) (lattice.RuleStatus, error) {
+func (m *MockRuleManager) Create(ctx context.Context, rule *lattice.Rule, serviceId, listenerId string, priority int64) (lattice.RuleStatus, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, rule)
+	ret := m.ctrl.Call(m, "Create", ctx, rule, serviceId, listenerId, priority)
 	ret0, _ := ret[0].(lattice.RuleStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockRuleManagerMockRecorder) Create(ctx, rule interface{}) *gomock.Call {
+func (mr *MockRuleManagerMockRecorder) Create(ctx, rule, serviceId, listenerId, priority interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRuleManager)(nil).Create), ctx, rule)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRuleManager)(nil).Create), ctx, rule, serviceId, listenerId, priority)
 }
 
 // Delete mocks base method.
@@ -110,16 +110,30 @@ func (mr *MockRuleManagerMockRecorder) List(ctx, serviceId, listenerId interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRuleManager)(nil).List), ctx, serviceId, listenerId)
 }
 
-// Update mocks base method.
-func (m *MockRuleManager) Update(ctx context.Context, rules []*lattice.Rule) error {
+// UpdateAction mocks base method.
+func (m *MockRuleManager) UpdateAction(ctx context.Context, rule *lattice.Rule, ruleStatus *lattice.RuleStatus) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, rules)
+	ret := m.ctrl.Call(m, "UpdateAction", ctx, rule, ruleStatus)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockRuleManagerMockRecorder) Update(ctx, rules interface{}) *gomock.Call {
+// UpdateAction indicates an expected call of UpdateAction.
+func (mr *MockRuleManagerMockRecorder) UpdateAction(ctx, rule, ruleStatus interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRuleManager)(nil).Update), ctx, rules)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAction", reflect.TypeOf((*MockRuleManager)(nil).UpdateAction), ctx, rule, ruleStatus)
+}
+
+// UpdatePriority mocks base method.
+func (m *MockRuleManager) UpdatePriority(ctx context.Context, ruleStatus *lattice.RuleStatus, priority int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePriority", ctx, ruleStatus, priority)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePriority indicates an expected call of UpdatePriority.
+func (mr *MockRuleManagerMockRecorder) UpdatePriority(ctx, ruleStatus, priority interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePriority", reflect.TypeOf((*MockRuleManager)(nil).UpdatePriority), ctx, ruleStatus, priority)
 }
@@ -2,6 +2,7 @@ package lattice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -352,172 +353,54 @@ func Test_CreateRule(t *testing.T) {
 	headerRule_1_2_path_exact.Spec.PathMatchExact = true
 
 	tests := []struct {
-		name                 string
-		oldRule              *model.Rule
-		newRule              *model.Rule
-		listRuleOuput        []*model.Rule
-		createRule           bool
-		updateRule           bool
-		noServiceID          bool
-		noListenerID         bool
-		noTargetGroupID      bool
-		updatePriorityNeeded bool
+		name            string
+		newRule         *model.Rule
+		noTargetGroupID bool
 	}{
-
-		{
-			name:                 "create header-based + path prefix rule with 1 TG",
-			oldRule:              nil,
-			newRule:              &headerRule_1,
-			createRule:           true,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
-		},
-
 		{
-			name:                 "create header-based + path prefix rule with 2 TG",
-			oldRule:              &headerRule_1,
-			newRule:              &headerRule_1_2,
-			createRule:           false,
-			updateRule:           true,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create header-based + path prefix rule with 1 TG",
+			newRule: &headerRule_1,
 		},
-
 		{
-			name:                 "create header-based + path exact rule with 1 TG",
-			oldRule:              nil,
-			newRule:              &headerRule_1_path_exact,
-			createRule:           true,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create header-based + path prefix rule with 2 TG",
+			newRule: &headerRule_1_2,
 		},
-
 		{
-			name:                 "create header-based + path prefix rule with 2 TG",
-			oldRule:              &headerRule_1_path_exact,
-			newRule:              &headerRule_1_2_path_exact,
-			createRule:           false,
-			updateRule:           true,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create header-based + path exact rule with 1 TG",
+			newRule: &headerRule_1_path_exact,
 		},
-
 		{
-			name:                 "test1, create weighted rule with 1 TG",
-			oldRule:              nil,
-			newRule:              &WeightedRule_1,
-			createRule:           true,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create header-based + path exact rule with 2 TG",
+			newRule: &headerRule_1_2_path_exact,
 		},
-
 		{
-			name:                 "create weighted rule with 2 TGs",
-			oldRule:              &WeightedRule_1,
-			newRule:              &WeightedRule_1_2,
-			createRule:           false,
-			updateRule:           true,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create weighted rule with 1 TG",
+			newRule: &WeightedRule_1,
 		},
-
 		{
-			name:                 "update weighted rule with 2 TGs",
-			oldRule:              &WeightedRule_1_2,
-			newRule:              &WeightedRule_2_1,
-			createRule:           false,
-			updateRule:           true,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create weighted rule with 2 TGs",
+			newRule: &WeightedRule_1_2,
 		},
-
 		{
-			name:                 "create path-based rule, no need to update priority",
-			oldRule:              nil,
-			newRule:              &pathRule_1,
-			createRule:           true,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create weighted rule with 2 other weighted TGs",
+			newRule: &WeightedRule_2_1,
 		},
-
 		{
-
-			name:                 "create path-based rule, need to update priority",
-			oldRule:              &pathRule_1,
-			newRule:              &pathRule_2,
-			createRule:           true,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: true,
+			name:    "create path-based rule",
+			newRule: &pathRule_1,
 		},
-
 		{
-			name:                 "update path-based rule with a different TG",
-			oldRule:              &pathRule_1,
-			newRule:              &pathRule_11,
-			createRule:           false,
-			updateRule:           true,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create path-based rule with a different TG",
+			newRule: &pathRule_11,
 		},
-
 		{
-			name:                 "no serviceID",
-			oldRule:              nil,
-			newRule:              &pathRule_1,
-			createRule:           false,
-			updateRule:           false,
-			noServiceID:          true,
-			noListenerID:         false,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
+			name:    "create path-based rule with a different path",
+			newRule: &pathRule_2,
 		},
 		{
-			name:                 "no listenerID",
-			oldRule:              nil,
-			newRule:              &pathRule_1,
-			createRule:           false,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         true,
-			noTargetGroupID:      false,
-			updatePriorityNeeded: false,
-		},
-
-		{
-			name:                 "no TG IDs",
-			oldRule:              nil,
-			newRule:              &pathRule_1,
-			createRule:           false,
-			updateRule:           false,
-			noServiceID:          false,
-			noListenerID:         false,
-			noTargetGroupID:      true,
-			updatePriorityNeeded: false,
+			name:            "no TG IDs",
+			newRule:         &pathRule_1,
+			noTargetGroupID: true,
 		},
 	}
 
@@ -534,287 +417,212 @@ func Test_CreateRule(t *testing.T) {
 
 			ruleManager := NewRuleManager(gwlog.FallbackLogger, cloud, latticeDataStore)
 
-			if !tt.noServiceID {
-				mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(
-					&vpclattice.ServiceSummary{
-						Name: aws.String((&RuleLSNProvider{tt.newRule}).LatticeServiceName()),
-						Arn:  aws.String("serviceARN"),
-						Id:   aws.String(tt.newRule.Status.ServiceID),
-						DnsEntry: &vpclattice.DnsEntry{
-							DomainName:   aws.String("test-dns"),
-							HostedZoneId: aws.String("my-favourite-zone"),
-						},
-					}, nil).Times(1)
-			} else {
-				mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(nil, &mocks.NotFoundError{}).Times(1)
-			}
-
-			if !tt.noListenerID {
-				latticeDataStore.AddListener(tt.newRule.Spec.ServiceName, tt.newRule.Spec.ServiceNamespace,
-					tt.newRule.Spec.ListenerPort, "HTTP",
-					"listernerARN", tt.newRule.Status.ListenerID)
-			}
-
 			if !tt.noTargetGroupID {
+				latticeTGs := []*vpclattice.WeightedTargetGroup{}
 				for _, tg := range tt.newRule.Spec.Action.TargetGroups {
 					tgName := latticestore.TargetGroupName(tg.Name, tg.Namespace)
 					latticeDataStore.AddTargetGroup(tgName, "vpc", "arn", "tg-id", tg.IsServiceImport, "")
-				}
-
-			}
-
-			if !tt.noListenerID && !tt.noServiceID {
-				ruleInput := vpclattice.ListRulesInput{
-					ListenerIdentifier: aws.String(tt.newRule.Status.ListenerID),
-					ServiceIdentifier:  aws.String(tt.newRule.Status.ServiceID),
-				}
-
-				ruleOutput := vpclattice.ListRulesOutput{}
-
-				if tt.oldRule != nil {
-					items := []*vpclattice.RuleSummary{}
 
-					items = append(items, &vpclattice.RuleSummary{
-						Id: aws.String(tt.oldRule.Spec.RuleID),
-					})
-					ruleOutput = vpclattice.ListRulesOutput{
-						Items: items,
-					}
-				}
-				mockLattice.EXPECT().ListRules(&ruleInput).Return(&ruleOutput, nil)
-
-				if tt.oldRule != nil {
-					ruleGetInput := vpclattice.GetRuleInput{
-						ListenerIdentifier: aws.String(ListenerID),
-						ServiceIdentifier:  aws.String(ServiceID),
-						RuleIdentifier:     aws.String(tt.oldRule.Spec.RuleID),
-					}
-
-					//				listenerID := tt.oldRule.Status.ListenerID
-					latticeTGs := []*vpclattice.WeightedTargetGroup{}
-					//	ruleName := fmt.Sprintf("rule-%d-%s", tt.oldRule.Spec.CreateTime.Unix(), tt.oldRule.Spec.RuleID)
-					priority, _ := ruleID2Priority(tt.oldRule.Spec.RuleID)
-
-					for _, tg := range tt.oldRule.Spec.Action.TargetGroups {
-						latticeTG := vpclattice.WeightedTargetGroup{
-							TargetGroupIdentifier: aws.String("tg-id"),
-							Weight:                aws.Int64(tg.Weight),
-						}
-						latticeTGs = append(latticeTGs, &latticeTG)
-					}
-
-					httpMatch := vpclattice.HttpMatch{}
-					updateSDKhttpMatch(&httpMatch, tt.oldRule)
-					ruleGetOutput := vpclattice.GetRuleOutput{
-						Id:       aws.String(tt.oldRule.Spec.RuleID),
-						Priority: aws.Int64(priority),
-						Action: &vpclattice.RuleAction{
-							Forward: &vpclattice.ForwardAction{
-								TargetGroups: latticeTGs,
-							},
-						},
-						Match: &vpclattice.RuleMatch{
-							HttpMatch: &httpMatch,
-						},
-					}
-
-					mockLattice.EXPECT().GetRule(&ruleGetInput).Return(&ruleGetOutput, nil)
-
-				}
-			}
-
-			if tt.createRule || tt.updateRule {
-				listenerID := tt.newRule.Status.ListenerID
-				latticeTGs := []*vpclattice.WeightedTargetGroup{}
-				ruleName := fmt.Sprintf("k8s-%d-%s", tt.newRule.Spec.CreateTime.Unix(), tt.newRule.Spec.RuleID)
-				priority, _ := ruleID2Priority(tt.newRule.Spec.RuleID)
-
-				if tt.updatePriorityNeeded {
-					priority, _ = ruleID2Priority(tt.oldRule.Spec.RuleID)
-					priority++
-				}
-
-				for _, tg := range tt.newRule.Spec.Action.TargetGroups {
-					latticeTG := vpclattice.WeightedTargetGroup{
+					latticeTGs = append(latticeTGs, &vpclattice.WeightedTargetGroup{
 						TargetGroupIdentifier: aws.String("tg-id"),
 						Weight:                aws.Int64(tg.Weight),
-					}
-					latticeTGs = append(latticeTGs, &latticeTG)
+					})
 				}
 
-				if tt.createRule {
-					httpMatch := vpclattice.HttpMatch{}
-					updateSDKhttpMatch(&httpMatch, tt.newRule)
-					ruleInput := vpclattice.CreateRuleInput{
-						Action: &vpclattice.RuleAction{
-							Forward: &vpclattice.ForwardAction{
-								TargetGroups: latticeTGs,
-							},
-						},
-
-						ListenerIdentifier: aws.String(listenerID),
-						Name:               aws.String(ruleName),
-						Priority:           aws.Int64(priority),
-						ServiceIdentifier:  aws.String(ServiceID),
-						Match: &vpclattice.RuleMatch{
-							HttpMatch: &httpMatch,
+				httpMatch := vpclattice.HttpMatch{}
+				updateSDKhttpMatch(&httpMatch, tt.newRule)
+				ruleInput := vpclattice.CreateRuleInput{
+					Action: &vpclattice.RuleAction{
+						Forward: &vpclattice.ForwardAction{
+							TargetGroups: latticeTGs,
 						},
-						Tags: cloud.DefaultTags(),
-					}
-					ruleOutput := vpclattice.CreateRuleOutput{
-						Id: aws.String(ruleID),
-					}
-					mockLattice.EXPECT().CreateRule(&ruleInput).Return(&ruleOutput, nil)
+					},
+					ListenerIdentifier: aws.String(ListenerID),
+					Name:               aws.String("k8s-" + tt.newRule.Spec.RuleID),
+					Priority:           aws.Int64(10),
+					ServiceIdentifier:  aws.String(ServiceID),
+					Match: &vpclattice.RuleMatch{
+						HttpMatch: &httpMatch,
+					},
+					Tags: cloud.DefaultTags(),
 				}
-
-				if tt.updateRule {
-					httpMatch := vpclattice.HttpMatch{}
-					updateSDKhttpMatch(&httpMatch, tt.newRule)
-					ruleInput := vpclattice.UpdateRuleInput{
-						Action: &vpclattice.RuleAction{
-							Forward: &vpclattice.ForwardAction{
-								TargetGroups: latticeTGs,
-							},
-						},
-
-						ListenerIdentifier: aws.String(listenerID),
-						//Name:               aws.String(ruleName),
-						RuleIdentifier:    aws.String(tt.newRule.Spec.RuleID),
-						Priority:          aws.Int64(priority),
-						ServiceIdentifier: aws.String(ServiceID),
-						Match: &vpclattice.RuleMatch{
-							HttpMatch: &httpMatch,
-						},
-						/*
-							Match: &vpclattice.RuleMatch{
-								HttpMatch: &vpclattice.HttpMatch{
-									// TODO, what if not specfied this
-									//Method: aws.String(vpclattice.HttpMethodGet),
-									PathMatch: &vpclattice.PathMatch{
-										CaseSensitive: nil,
-										Match: &vpclattice.PathMatchType{
-											Exact:  nil,
-											Prefix: aws.String(tt.newRule.Spec.PathMatchValue),
-										},
-									},
-								},
-							},
-						*/
-					}
-					ruleOutput := vpclattice.UpdateRuleOutput{
-						Id: aws.String(ruleID),
-					}
-					mockLattice.EXPECT().UpdateRule(&ruleInput).Return(&ruleOutput, nil)
+				ruleOutput := vpclattice.CreateRuleOutput{
+					Arn: aws.String("rule-arn"),
+					Id:  aws.String(ruleID),
 				}
+				mockLattice.EXPECT().CreateRule(&ruleInput).Return(&ruleOutput, nil)
 			}
 
-			resp, err := ruleManager.Create(ctx, tt.newRule)
+			resp, err := ruleManager.Create(ctx, tt.newRule, ServiceID, ListenerID, 10)
 
-			if !tt.noListenerID && !tt.noServiceID && !tt.noTargetGroupID {
-				assert.NoError(t, err)
-
-				assert.Equal(t, resp.ListenerID, ListenerID)
-				assert.Equal(t, resp.ServiceID, ServiceID)
-				assert.Equal(t, resp.RuleID, ruleID)
+			if tt.noTargetGroupID {
+				assert.Error(t, err)
+				return
 			}
-
-			fmt.Printf(" rulemanager.Create :%v, err %d\n", resp, err)
+			assert.NoError(t, err)
+			assert.Equal(t, ListenerID, resp.ListenerID)
+			assert.Equal(t, ServiceID, resp.ServiceID)
+			assert.Equal(t, ruleID, resp.RuleID)
+			assert.Equal(t, "rule-arn", resp.RuleARN)
+			assert.Equal(t, int64(10), resp.Priority)
+			assert.Equal(t, "k8s-"+tt.newRule.Spec.RuleID, resp.Name)
 		})
 	}
 }
 
-func Test_UpdateRule(t *testing.T) {
-	tests := []struct {
-		name         string
-		noServiceID  bool
-		noListenerID bool
-	}{
-		{
-			name:         "update",
-			noServiceID:  false,
-			noListenerID: false,
-		},
+func Test_UpdateRuleAction(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
 
-		{
-			name:         "update -- no service",
-			noServiceID:  true,
-			noListenerID: false,
-		},
-		{
-			name:         "update -- no listenerID",
-			noServiceID:  false,
-			noListenerID: true,
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	ruleManager := NewRuleManager(gwlog.FallbackLogger, cloud, latticeDataStore)
+
+	rule := &model.Rule{
+		Spec: model.RuleSpec{
+			RuleID: "rule-1",
+			Action: model.RuleAction{
+				TargetGroups: []*model.RuleTargetGroup{
+					{Name: "tg1", Namespace: "default", Weight: 90},
+					{Name: "tg2", Namespace: "default", IsServiceImport: true, Weight: 10},
+				},
+			},
 		},
 	}
+	ruleStatus := &model.RuleStatus{
+		RuleID:     "rule-ID-1",
+		ListenerID: "listenerID1",
+		ServiceID:  "serviceID1",
+		Priority:   3,
+	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c := gomock.NewController(t)
-			defer c.Finish()
-			ctx := context.TODO()
+	latticeDataStore.AddTargetGroup(latticestore.TargetGroupName("tg1", "default"), "vpc", "arn1", "tg1-id", false, "")
+	latticeDataStore.AddTargetGroup(latticestore.TargetGroupName("tg2", "default"), "vpc", "arn2", "tg2-id", true, "")
 
-			mockLattice := mocks.NewMockLattice(c)
-			cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+	mockLattice.EXPECT().UpdateRule(&vpclattice.UpdateRuleInput{
+		Action: &vpclattice.RuleAction{
+			Forward: &vpclattice.ForwardAction{
+				TargetGroups: []*vpclattice.WeightedTargetGroup{
+					{TargetGroupIdentifier: aws.String("tg1-id"), Weight: aws.Int64(90)},
+					{TargetGroupIdentifier: aws.String("tg2-id"), Weight: aws.Int64(10)},
+				},
+			},
+		},
+		ListenerIdentifier: aws.String("listenerID1"),
+		ServiceIdentifier:  aws.String("serviceID1"),
+		RuleIdentifier:     aws.String("rule-ID-1"),
+	}).Return(&vpclattice.UpdateRuleOutput{}, nil)
 
-			latticeDataStore := latticestore.NewLatticeDataStore()
+	err := ruleManager.UpdateAction(ctx, rule, ruleStatus)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), ruleStatus.Priority)
+}
 
-			ruleManager := NewRuleManager(gwlog.FallbackLogger, cloud, latticeDataStore)
+func Test_CreateFixedResponseRule(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
 
-			var i = 0
-			if !tt.noServiceID {
-				mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(
-					&vpclattice.ServiceSummary{
-						Name: aws.String((&RuleLSNProvider{rules[i]}).LatticeServiceName()),
-						Arn:  aws.String("serviceARN"),
-						Id:   aws.String(rules[i].Status.ServiceID),
-						DnsEntry: &vpclattice.DnsEntry{
-							DomainName:   aws.String("test-dns"),
-							HostedZoneId: aws.String("my-favourite-zone"),
-						},
-					}, nil).Times(1)
-			} else {
-				mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(nil, &mocks.NotFoundError{}).Times(1)
-			}
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
 
-			if !tt.noListenerID {
-				latticeDataStore.AddListener(rules[i].Spec.ServiceName, rules[i].Spec.ServiceNamespace,
-					rules[i].Spec.ListenerPort, "HTTP",
-					"listenerARN", rules[i].Status.ListenerID)
-			}
+	ruleManager := NewRuleManager(gwlog.FallbackLogger, cloud, latticestore.NewLatticeDataStore())
 
-			var ruleUpdateList []*vpclattice.RuleUpdate
+	rule := &model.Rule{
+		Spec: model.RuleSpec{
+			PathMatchPrefix: true,
+			PathMatchValue:  "/maintenance",
+			RuleID:          "rule-1",
+			Action: model.RuleAction{
+				FixedResponseStatusCode: 503,
+			},
+		},
+	}
 
-			for _, rule := range rules {
-				priority, _ := ruleID2Priority(rule.Spec.RuleID)
-				ruleupdate := vpclattice.RuleUpdate{
-					RuleIdentifier: aws.String(rule.Status.RuleID),
-					Priority:       aws.Int64(priority),
-				}
+	httpMatch := vpclattice.HttpMatch{}
+	updateSDKhttpMatch(&httpMatch, rule)
+	mockLattice.EXPECT().CreateRule(&vpclattice.CreateRuleInput{
+		Action: &vpclattice.RuleAction{
+			FixedResponse: &vpclattice.FixedResponseAction{
+				StatusCode: aws.Int64(503),
+			},
+		},
+		ListenerIdentifier: aws.String("listenerID1"),
+		Name:               aws.String("k8s-rule-1"),
+		Priority:           aws.Int64(5),
+		ServiceIdentifier:  aws.String("serviceID1"),
+		Match: &vpclattice.RuleMatch{
+			HttpMatch: &httpMatch,
+		},
+		Tags: cloud.DefaultTags(),
+	}).Return(&vpclattice.CreateRuleOutput{
+		Arn: aws.String("rule-arn"),
+		Id:  aws.String("rule-ID-1"),
+	}, nil)
 
-				ruleUpdateList = append(ruleUpdateList, &ruleupdate)
+	resp, err := ruleManager.Create(ctx, rule, "serviceID1", "listenerID1", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, "rule-ID-1", resp.RuleID)
 
-			}
+	// switching back to forwarding replaces the fixed response
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	ruleManager = NewRuleManager(gwlog.FallbackLogger, cloud, latticeDataStore)
+	latticeDataStore.AddTargetGroup(latticestore.TargetGroupName("tg1", "default"), "vpc", "arn1", "tg1-id", false, "")
+	rule.Spec.Action = model.RuleAction{
+		TargetGroups: []*model.RuleTargetGroup{
+			{Name: "tg1", Namespace: "default", Weight: 1},
+		},
+	}
 
-			batchRuleInput := vpclattice.BatchUpdateRuleInput{
-				ListenerIdentifier: aws.String(rules[0].Status.ListenerID),
-				ServiceIdentifier:  aws.String(rules[0].Status.ServiceID),
-				Rules:              ruleUpdateList,
-			}
+	mockLattice.EXPECT().UpdateRule(&vpclattice.UpdateRuleInput{
+		Action: &vpclattice.RuleAction{
+			Forward: &vpclattice.ForwardAction{
+				TargetGroups: []*vpclattice.WeightedTargetGroup{
+					{TargetGroupIdentifier: aws.String("tg1-id"), Weight: aws.Int64(1)},
+				},
+			},
+		},
+		ListenerIdentifier: aws.String("listenerID1"),
+		ServiceIdentifier:  aws.String("serviceID1"),
+		RuleIdentifier:     aws.String("rule-ID-1"),
+	}).Return(&vpclattice.UpdateRuleOutput{}, nil)
 
-			if !tt.noListenerID && !tt.noServiceID {
-				var batchRuleOutput vpclattice.BatchUpdateRuleOutput
-				mockLattice.EXPECT().BatchUpdateRule(&batchRuleInput).Return(&batchRuleOutput, nil)
-			}
+	err = ruleManager.UpdateAction(ctx, rule, &resp)
+	assert.NoError(t, err)
+}
 
-			err := ruleManager.Update(ctx, rules)
+func Test_UpdateRulePriority(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
 
-			if !tt.noListenerID && !tt.noServiceID {
-				assert.NoError(t, err)
-			} else {
-				assert.NotNil(t, err)
-			}
-		})
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	ruleManager := NewRuleManager(gwlog.FallbackLogger, cloud, latticestore.NewLatticeDataStore())
+
+	ruleStatus := *rules[0].Status
+	ruleStatus.Priority = 3
+
+	updateRuleInput := &vpclattice.UpdateRuleInput{
+		ListenerIdentifier: aws.String(ruleStatus.ListenerID),
+		ServiceIdentifier:  aws.String(ruleStatus.ServiceID),
+		RuleIdentifier:     aws.String(ruleStatus.RuleID),
+		Priority:           aws.Int64(7),
 	}
+
+	mockLattice.EXPECT().UpdateRule(updateRuleInput).Return(&vpclattice.UpdateRuleOutput{}, nil)
+	err := ruleManager.UpdatePriority(ctx, &ruleStatus, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), ruleStatus.Priority)
+
+	mockLattice.EXPECT().UpdateRule(gomock.Any()).Return(nil, errors.New("conflict"))
+	err = ruleManager.UpdatePriority(ctx, &ruleStatus, 9)
+	assert.Error(t, err)
+	assert.Equal(t, int64(7), ruleStatus.Priority)
 }
 
 func Test_List(t *testing.T) {
@@ -838,11 +646,15 @@ func Test_List(t *testing.T) {
 				Arn:       &ruleList[0].Arn,
 				Id:        &ruleList[0].Id,
 				IsDefault: &ruleList[0].IsDefault,
+				Name:      &ruleList[0].Name,
+				Priority:  aws.Int64(1),
 			},
 			{
 				Arn:       &ruleList[1].Arn,
 				Id:        &ruleList[1].Id,
 				IsDefault: &ruleList[1].IsDefault,
+				Name:      &ruleList[1].Name,
+				Priority:  aws.Int64(2),
 			},
 		},
 	}
@@ -861,6 +673,8 @@ func Test_List(t *testing.T) {
 		assert.Equal(t, resp[i].ListenerID, listenerID)
 		assert.Equal(t, resp[i].RuleID, ruleList[i].Id)
 		assert.Equal(t, resp[i].ServiceID, serviceID)
+		assert.Equal(t, resp[i].Name, ruleList[i].Name)
+		assert.Equal(t, resp[i].Priority, int64(i+1))
 	}
 	fmt.Printf("rule Manager List resp %v\n", resp)
 
@@ -1495,6 +1309,80 @@ func Test_isRulesSame(t *testing.T) {
 			},
 			ruleMatched: false,
 		},
+		{
+			name: "fixed response match",
+			k8sRule: &model.Rule{
+				Spec: model.RuleSpec{
+					PathMatchPrefix: true,
+					PathMatchValue:  path1,
+					Action:          model.RuleAction{FixedResponseStatusCode: 503},
+				},
+			},
+			sdkRule: &vpclattice.GetRuleOutput{
+				Action: &vpclattice.RuleAction{
+					FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(503)},
+				},
+				Match: &vpclattice.RuleMatch{
+					HttpMatch: &vpclattice.HttpMatch{
+						PathMatch: &vpclattice.PathMatch{
+							Match: &vpclattice.PathMatchType{
+								Prefix: &path1,
+							},
+						},
+					},
+				},
+			},
+			ruleMatched: true,
+		},
+		{
+			name: "fixed response status code mismatch",
+			k8sRule: &model.Rule{
+				Spec: model.RuleSpec{
+					PathMatchPrefix: true,
+					PathMatchValue:  path1,
+					Action:          model.RuleAction{FixedResponseStatusCode: 503},
+				},
+			},
+			sdkRule: &vpclattice.GetRuleOutput{
+				Action: &vpclattice.RuleAction{
+					FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)},
+				},
+				Match: &vpclattice.RuleMatch{
+					HttpMatch: &vpclattice.HttpMatch{
+						PathMatch: &vpclattice.PathMatch{
+							Match: &vpclattice.PathMatchType{
+								Prefix: &path1,
+							},
+						},
+					},
+				},
+			},
+			ruleMatched: false,
+		},
+		{
+			name: "fixed response no longer in model",
+			k8sRule: &model.Rule{
+				Spec: model.RuleSpec{
+					PathMatchPrefix: true,
+					PathMatchValue:  path1,
+				},
+			},
+			sdkRule: &vpclattice.GetRuleOutput{
+				Action: &vpclattice.RuleAction{
+					FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(503)},
+				},
+				Match: &vpclattice.RuleMatch{
+					HttpMatch: &vpclattice.HttpMatch{
+						PathMatch: &vpclattice.PathMatch{
+							Match: &vpclattice.PathMatchType{
+								Prefix: &path1,
+							},
+						},
+					},
+				},
+			},
+			ruleMatched: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1509,3 +1397,126 @@ func Test_isRulesSame(t *testing.T) {
 		})
 	}
 }
+
+func Test_ruleIDFromLatticeRuleName(t *testing.T) {
+	rule := &model.Rule{Spec: model.RuleSpec{RuleID: "rule-0123456789abcdef"}}
+
+	ruleId, ok := ruleIDFromLatticeRuleName(latticeRuleName(rule))
+	assert.True(t, ok)
+	assert.Equal(t, rule.Spec.RuleID, ruleId)
+
+	// rules created before their name recorded the rule id
+	_, ok = ruleIDFromLatticeRuleName("k8s-1690000000-rule-1")
+	assert.False(t, ok)
+	_, ok = ruleIDFromLatticeRuleName("k8s-rule-not-hex")
+	assert.False(t, ok)
+	_, ok = ruleIDFromLatticeRuleName("my-rule")
+	assert.False(t, ok)
+}
+
+func Test_buildLatticeTargetGroups(t *testing.T) {
+	ds := latticestore.NewLatticeDataStore()
+	tgName := latticestore.TargetGroupName("svc", "default")
+	assert.NoError(t, ds.AddTargetGroup(tgName, "vpc-id", "tg1-arn", "tg1-id", false, "route"))
+	// a LambdaFunction of the same name as the Service has its own target group
+	lambdaTGName := latticestore.LambdaTargetGroupName("svc", "default")
+	assert.NoError(t, ds.AddTargetGroup(lambdaTGName, "", "tg3-arn", "tg3-id", false, "route"))
+
+	latticeTGs, err := buildLatticeTargetGroups(ds, []*model.RuleTargetGroup{
+		{Name: "svc", Namespace: "default", RouteName: "route", Weight: 80},
+		// existing target groups are not in the datastore
+		{Name: "external", Namespace: "default", RouteName: "route", Weight: 10, LatticeTargetGroupID: "tg2-id"},
+		{Name: "svc", Namespace: "default", RouteName: "route", Weight: 10, IsLambdaFunction: true},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*vpclattice.WeightedTargetGroup{
+		{TargetGroupIdentifier: aws.String("tg1-id"), Weight: aws.Int64(80)},
+		{TargetGroupIdentifier: aws.String("tg2-id"), Weight: aws.Int64(10)},
+		{TargetGroupIdentifier: aws.String("tg3-id"), Weight: aws.Int64(10)},
+	}, latticeTGs)
+
+	_, err = buildLatticeTargetGroups(ds, []*model.RuleTargetGroup{
+		{Name: "unknown", Namespace: "default", RouteName: "route", Weight: 1},
+	})
+	assert.Error(t, err)
+}
+
+func Test_isForwardActionSame(t *testing.T) {
+	latticeTGs := []*vpclattice.WeightedTargetGroup{
+		{TargetGroupIdentifier: aws.String("tg1-id"), Weight: aws.Int64(90)},
+		{TargetGroupIdentifier: aws.String("tg2-id"), Weight: aws.Int64(10)},
+	}
+	forward := func(tgs ...*vpclattice.WeightedTargetGroup) *vpclattice.RuleAction {
+		return &vpclattice.RuleAction{Forward: &vpclattice.ForwardAction{TargetGroups: tgs}}
+	}
+
+	assert.True(t, isForwardActionSame(latticeTGs, forward(latticeTGs[1], latticeTGs[0])))
+	assert.False(t, isForwardActionSame(latticeTGs, forward(latticeTGs[0])))
+	assert.False(t, isForwardActionSame(latticeTGs, forward(latticeTGs[0],
+		&vpclattice.WeightedTargetGroup{TargetGroupIdentifier: aws.String("tg2-id"), Weight: aws.Int64(20)})))
+	assert.False(t, isForwardActionSame(latticeTGs, forward(latticeTGs[0],
+		&vpclattice.WeightedTargetGroup{TargetGroupIdentifier: aws.String("tg3-id"), Weight: aws.Int64(10)})))
+	assert.False(t, isForwardActionSame(latticeTGs, &vpclattice.RuleAction{
+		FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(404)},
+	}))
+	assert.False(t, isForwardActionSame(latticeTGs, nil))
+}
+
+func Test_isRuleActionSame(t *testing.T) {
+	fixed := func(code int64) *vpclattice.RuleAction {
+		return &vpclattice.RuleAction{FixedResponse: &vpclattice.FixedResponseAction{StatusCode: aws.Int64(code)}}
+	}
+	forward := &vpclattice.RuleAction{
+		Forward: &vpclattice.ForwardAction{
+			TargetGroups: []*vpclattice.WeightedTargetGroup{
+				{TargetGroupIdentifier: aws.String("tg-id"), Weight: aws.Int64(1)},
+			},
+		},
+	}
+
+	assert.True(t, isRuleActionSame(fixed(404), fixed(404)))
+	assert.False(t, isRuleActionSame(fixed(404), fixed(503)))
+	assert.False(t, isRuleActionSame(fixed(404), forward))
+	assert.False(t, isRuleActionSame(forward, fixed(404)))
+	assert.True(t, isRuleActionSame(forward, forward))
+}
+
+func Test_isHeaderMatchSame(t *testing.T) {
+	tests := []struct {
+		name        string
+		modelHeader *vpclattice.HeaderMatch
+		sdkHeader   *vpclattice.HeaderMatch
+		same        bool
+	}{
+		{
+			name:        "same prefix",
+			modelHeader: &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Prefix: aws.String("te")}, CaseSensitive: aws.Bool(true)},
+			sdkHeader:   &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Prefix: aws.String("te")}, CaseSensitive: aws.Bool(true)},
+			same:        true,
+		},
+		{
+			name:        "prefix vs contains",
+			modelHeader: &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Prefix: aws.String("te")}},
+			sdkHeader:   &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Contains: aws.String("te")}},
+			same:        false,
+		},
+		{
+			name:        "contains value changed",
+			modelHeader: &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Contains: aws.String("te")}},
+			sdkHeader:   &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Contains: aws.String("test")}},
+			same:        false,
+		},
+		{
+			name:        "case sensitivity changed",
+			modelHeader: &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Exact: aws.String("test")}, CaseSensitive: aws.Bool(false)},
+			sdkHeader:   &vpclattice.HeaderMatch{Name: aws.String("env"), Match: &vpclattice.HeaderMatchType{Exact: aws.String("test")}, CaseSensitive: aws.Bool(true)},
+			same:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, isHeaderMatchSame(tt.modelHeader, tt.sdkHeader))
+		})
+	}
+}
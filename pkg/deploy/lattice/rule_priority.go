@@ -0,0 +1,241 @@
+package lattice
+
+import (
+	"fmt"
+	"sort"
+
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+type rulePriorityMove struct {
+	ruleId   string
+	priority int64
+}
+
+// rulePriorityPlan describes how to bring the rules of a listener into the desired order.
+// Rule ids are the model rule ids, which are stable for a given match.
+type rulePriorityPlan struct {
+	// priority each new rule is created with
+	creates map[string]int64
+	// priority updates to apply once new rules are created and stale rules are deleted, in this order
+	moves []rulePriorityMove
+}
+
+// planRulePriorities assigns lattice priorities to ruleIds, which are given in evaluation order.
+// current holds the priority of rules which already exist in lattice and reserved the priorities
+// of stale rules, which are only deleted after new rules are created.
+//
+// Existing rules keep their priority as long as they are already in the right order relative to
+// each other, the others are placed into free priorities in the gaps between them. Since each move
+// goes to a priority which is free from the start, moving right-moving rules from the highest
+// priority down and then left-moving rules from the lowest priority up keeps every pair of rules
+// either in their old or their new relative order, so requests are never routed by a rule that
+// should not see them. Only when the gaps are exhausted rules are respread over all priorities,
+// which might need temporary priorities to break cycles.
+func planRulePriorities(ruleIds []string, current map[string]int64, reserved []int64) (*rulePriorityPlan, error) {
+	if len(ruleIds) > model.MAX_RULE_PRIORITY {
+		return nil, fmt.Errorf("%d rules exceed the maximum of %d rules per listener", len(ruleIds), model.MAX_RULE_PRIORITY)
+	}
+
+	occupied := make(map[int64]bool)
+	for _, priority := range current {
+		occupied[priority] = true
+	}
+	for _, priority := range reserved {
+		occupied[priority] = true
+	}
+
+	if finals, ok := placeInGaps(ruleIds, keptRules(ruleIds, current), current, occupied); ok {
+		return orderedPlan(ruleIds, current, finals), nil
+	}
+	if finals, ok := placeInGaps(ruleIds, nil, current, occupied); ok {
+		return orderedPlan(ruleIds, current, finals), nil
+	}
+	return respreadPlan(ruleIds, current, occupied)
+}
+
+// keptRules returns the longest sequence of existing rules whose priorities already follow the desired order
+func keptRules(ruleIds []string, current map[string]int64) map[string]bool {
+	var existing []string
+	for _, ruleId := range ruleIds {
+		if _, ok := current[ruleId]; ok {
+			existing = append(existing, ruleId)
+		}
+	}
+
+	length := make([]int, len(existing))
+	prev := make([]int, len(existing))
+	best := -1
+	for i := range existing {
+		length[i], prev[i] = 1, -1
+		for j := 0; j < i; j++ {
+			if current[existing[j]] < current[existing[i]] && length[j]+1 > length[i] {
+				length[i], prev[i] = length[j]+1, j
+			}
+		}
+		if best < 0 || length[i] > length[best] {
+			best = i
+		}
+	}
+
+	kept := make(map[string]bool)
+	for i := best; i >= 0; i = prev[i] {
+		kept[existing[i]] = true
+	}
+	return kept
+}
+
+// placeInGaps keeps the priority of kept rules and spreads the other rules over the free priorities
+// between their kept neighbours, leaving room for rules added later
+func placeInGaps(ruleIds []string, kept map[string]bool, current map[string]int64, occupied map[int64]bool) (map[string]int64, bool) {
+	finals := make(map[string]int64)
+	var run []string
+	var low int64 = 0
+
+	place := func(high int64) bool {
+		var free []int64
+		for priority := low + 1; priority < high; priority++ {
+			if !occupied[priority] {
+				free = append(free, priority)
+			}
+		}
+		if len(free) < len(run) {
+			return false
+		}
+		for i, ruleId := range run {
+			finals[ruleId] = free[(i+1)*len(free)/(len(run)+1)]
+		}
+		run = nil
+		return true
+	}
+
+	for _, ruleId := range ruleIds {
+		if !kept[ruleId] {
+			run = append(run, ruleId)
+			continue
+		}
+		if !place(current[ruleId]) {
+			return nil, false
+		}
+		finals[ruleId] = current[ruleId]
+		low = current[ruleId]
+	}
+	if !place(model.MAX_RULE_PRIORITY + 1) {
+		return nil, false
+	}
+	return finals, true
+}
+
+// orderedPlan creates new rules at their final priority and moves existing rules in an order which
+// never inverts two rules that keep their relative order, see planRulePriorities
+func orderedPlan(ruleIds []string, current map[string]int64, finals map[string]int64) *rulePriorityPlan {
+	plan := &rulePriorityPlan{creates: make(map[string]int64)}
+
+	var right, left []string
+	for _, ruleId := range ruleIds {
+		priority, ok := current[ruleId]
+		switch {
+		case !ok:
+			plan.creates[ruleId] = finals[ruleId]
+		case finals[ruleId] > priority:
+			right = append(right, ruleId)
+		case finals[ruleId] < priority:
+			left = append(left, ruleId)
+		}
+	}
+
+	sort.Slice(right, func(i, j int) bool { return current[right[i]] > current[right[j]] })
+	sort.Slice(left, func(i, j int) bool { return current[left[i]] < current[left[j]] })
+	for _, ruleId := range append(right, left...) {
+		plan.moves = append(plan.moves, rulePriorityMove{ruleId: ruleId, priority: finals[ruleId]})
+	}
+	return plan
+}
+
+// respreadPlan spreads all rules evenly over the whole priority range. Rules which are in the way of
+// each other are moved through a temporary free priority, so the order is only eventually right.
+func respreadPlan(ruleIds []string, current map[string]int64, occupied map[int64]bool) (*rulePriorityPlan, error) {
+	plan := &rulePriorityPlan{creates: make(map[string]int64)}
+
+	freePriority := func(inUse map[int64]bool) (int64, error) {
+		for priority := int64(1); priority <= model.MAX_RULE_PRIORITY; priority++ {
+			if !inUse[priority] {
+				return priority, nil
+			}
+		}
+		return 0, fmt.Errorf("no free rule priority left to reorder %d rules", len(ruleIds))
+	}
+
+	finals := make(map[string]int64)
+	positions := make(map[string]int64)
+	for i, ruleId := range ruleIds {
+		finals[ruleId] = int64((i+1)*model.MAX_RULE_PRIORITY/(len(ruleIds)+1)) + 1
+		if priority, ok := current[ruleId]; ok {
+			positions[ruleId] = priority
+		}
+	}
+
+	for _, ruleId := range ruleIds {
+		if _, ok := current[ruleId]; ok {
+			continue
+		}
+		priority := finals[ruleId]
+		if occupied[priority] {
+			var err error
+			if priority, err = freePriority(occupied); err != nil {
+				return nil, err
+			}
+		}
+		occupied[priority] = true
+		plan.creates[ruleId] = priority
+		positions[ruleId] = priority
+	}
+
+	// stale rules are gone by the time rules are moved
+	inUse := make(map[int64]bool)
+	var pending []string
+	for _, ruleId := range ruleIds {
+		inUse[positions[ruleId]] = true
+		if positions[ruleId] != finals[ruleId] {
+			pending = append(pending, ruleId)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		iRight, jRight := finals[pending[i]] > positions[pending[i]], finals[pending[j]] > positions[pending[j]]
+		if iRight != jRight {
+			return iRight
+		}
+		if iRight {
+			return positions[pending[i]] > positions[pending[j]]
+		}
+		return positions[pending[i]] < positions[pending[j]]
+	})
+
+	move := func(ruleId string, priority int64) {
+		delete(inUse, positions[ruleId])
+		inUse[priority] = true
+		positions[ruleId] = priority
+		plan.moves = append(plan.moves, rulePriorityMove{ruleId: ruleId, priority: priority})
+	}
+
+	for len(pending) > 0 {
+		var blocked []string
+		for _, ruleId := range pending {
+			if inUse[finals[ruleId]] {
+				blocked = append(blocked, ruleId)
+			} else {
+				move(ruleId, finals[ruleId])
+			}
+		}
+		if len(blocked) == len(pending) {
+			// every pending rule waits for another one, park one of them aside
+			priority, err := freePriority(inUse)
+			if err != nil {
+				return nil, err
+			}
+			move(blocked[0], priority)
+		}
+		pending = blocked
+	}
+	return plan, nil
+}
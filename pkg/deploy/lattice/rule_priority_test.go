@@ -0,0 +1,190 @@
+package lattice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+// applyRulePriorityPlan replays plan and returns the final priorities. It fails the test whenever two
+// rules share a priority and, if checkOrder is set, whenever two rules are in neither their old nor
+// their new relative order.
+func applyRulePriorityPlan(
+	t *testing.T,
+	ruleIds []string,
+	current map[string]int64,
+	reserved []int64,
+	plan *rulePriorityPlan,
+	checkOrder bool,
+) map[string]int64 {
+	positions := make(map[string]int64)
+	inUse := make(map[int64]string)
+	for ruleId, priority := range current {
+		positions[ruleId] = priority
+		inUse[priority] = ruleId
+	}
+	for _, priority := range reserved {
+		inUse[priority] = "stale"
+	}
+	for ruleId, priority := range plan.creates {
+		assert.Empty(t, inUse[priority], "rule %s created at used priority %d", ruleId, priority)
+		positions[ruleId] = priority
+		inUse[priority] = ruleId
+	}
+	for _, priority := range reserved {
+		delete(inUse, priority)
+	}
+
+	finals := make(map[string]int64)
+	for ruleId, priority := range positions {
+		finals[ruleId] = priority
+	}
+	for _, move := range plan.moves {
+		finals[move.ruleId] = move.priority
+	}
+
+	for _, move := range plan.moves {
+		assert.Empty(t, inUse[move.priority], "rule %s moved to used priority %d", move.ruleId, move.priority)
+		delete(inUse, positions[move.ruleId])
+		positions[move.ruleId] = move.priority
+		inUse[move.priority] = move.ruleId
+
+		if !checkOrder {
+			continue
+		}
+		for x, oldX := range current {
+			for y, oldY := range current {
+				if x == y {
+					continue
+				}
+				now := positions[x] < positions[y]
+				assert.True(t, now == (oldX < oldY) || now == (finals[x] < finals[y]),
+					"rules %s and %s are out of order after moving %s", x, y, move.ruleId)
+			}
+		}
+	}
+
+	for i := 1; i < len(ruleIds); i++ {
+		assert.Less(t, positions[ruleIds[i-1]], positions[ruleIds[i]], "rules are not in the desired order")
+	}
+	return positions
+}
+
+func Test_planRulePriorities(t *testing.T) {
+	tests := []struct {
+		name      string
+		ruleIds   []string
+		current   map[string]int64
+		reserved  []int64
+		wantMoves int
+		want      map[string]int64
+	}{
+		{
+			name:    "new listener",
+			ruleIds: []string{"a", "b", "c"},
+			want:    map[string]int64{"a": 26, "b": 51, "c": 76},
+		},
+		{
+			name:    "nothing changed",
+			ruleIds: []string{"a", "b", "c"},
+			current: map[string]int64{"a": 1, "b": 2, "c": 3},
+			want:    map[string]int64{"a": 1, "b": 2, "c": 3},
+		},
+		{
+			name:    "rule inserted between existing rules",
+			ruleIds: []string{"a", "b", "c"},
+			current: map[string]int64{"a": 10, "c": 20},
+			want:    map[string]int64{"a": 10, "b": 15, "c": 20},
+		},
+		{
+			name:     "rule inserted next to a stale rule",
+			ruleIds:  []string{"a", "b", "c"},
+			current:  map[string]int64{"a": 10, "c": 13},
+			reserved: []int64{11},
+			want:     map[string]int64{"a": 10, "b": 12, "c": 13},
+		},
+		{
+			name:      "rule moved to the front of packed rules",
+			ruleIds:   []string{"c", "a", "b"},
+			current:   map[string]int64{"a": 1, "b": 2, "c": 3},
+			wantMoves: 3,
+		},
+		{
+			name:      "rule moved to the front",
+			ruleIds:   []string{"c", "a", "b"},
+			current:   map[string]int64{"a": 25, "b": 50, "c": 75},
+			wantMoves: 1,
+			want:      map[string]int64{"a": 25, "b": 50, "c": 13},
+		},
+		{
+			name:      "rules swapped",
+			ruleIds:   []string{"b", "a"},
+			current:   map[string]int64{"a": 1, "b": 2},
+			wantMoves: 1,
+		},
+		{
+			name:      "rules reversed",
+			ruleIds:   []string{"e", "d", "c", "b", "a"},
+			current:   map[string]int64{"a": 1, "b": 2, "c": 3, "d": 4, "e": 5},
+			wantMoves: 4,
+		},
+		{
+			name:      "no room between kept rules",
+			ruleIds:   []string{"a", "c", "b"},
+			current:   map[string]int64{"a": 1, "b": 2, "c": 3},
+			wantMoves: 1,
+		},
+		{
+			name:      "no room left to insert a rule",
+			ruleIds:   []string{"a", "x", "b"},
+			current:   map[string]int64{"a": 1, "b": 2},
+			wantMoves: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := planRulePriorities(tt.ruleIds, tt.current, tt.reserved)
+			assert.NoError(t, err)
+
+			priorities := applyRulePriorityPlan(t, tt.ruleIds, tt.current, tt.reserved, plan, true)
+			assert.Equal(t, tt.wantMoves, len(plan.moves))
+			if tt.want != nil {
+				assert.Equal(t, tt.want, priorities)
+			}
+		})
+	}
+}
+
+func Test_planRulePriorities_fullListener(t *testing.T) {
+	var ruleIds []string
+	current := make(map[string]int64)
+	for i := 0; i < model.MAX_RULE_PRIORITY; i++ {
+		ruleId := fmt.Sprintf("rule-%d", i)
+		current[ruleId] = int64(i + 1)
+		ruleIds = append([]string{ruleId}, ruleIds...)
+	}
+
+	// there is no free priority at all to reverse a full listener
+	_, err := planRulePriorities(ruleIds, current, nil)
+	assert.Error(t, err)
+
+	// once a rule is removed, the others are reordered through the free priority
+	ruleIds = ruleIds[1:]
+	delete(current, "rule-99")
+	plan, err := planRulePriorities(ruleIds, current, []int64{100})
+	assert.NoError(t, err)
+	applyRulePriorityPlan(t, ruleIds, current, []int64{100}, plan, false)
+}
+
+func Test_planRulePriorities_tooManyRules(t *testing.T) {
+	var ruleIds []string
+	for i := 0; i <= model.MAX_RULE_PRIORITY; i++ {
+		ruleIds = append(ruleIds, fmt.Sprintf("rule-%d", i))
+	}
+	_, err := planRulePriorities(ruleIds, nil, nil)
+	assert.Error(t, err)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -42,80 +44,191 @@ func (r *ruleSynthesizer) Synthesize(ctx context.Context) error {
 		r.log.Debugf("Error while listing rules %s", err)
 	}
 
-	updatePriority := false
+	// rules are synthesized per listener, in the order they are evaluated
+	sort.SliceStable(resRule, func(i, j int) bool { return resRule[i].Spec.Order < resRule[j].Spec.Order })
+
+	var listenerIds []string
+	rulesByListener := make(map[string][]*model.Rule)
+	serviceIds := make(map[string]string)
+	latticeServices := make(map[string]*vpclattice.ServiceSummary)
 
 	for _, rule := range resRule {
-		ruleResp, err := r.rule.Create(ctx, rule)
+		lsnProvider := &RuleLSNProvider{rule}
+		latticeService, ok := latticeServices[lsnProvider.LatticeServiceName()]
+		if !ok {
+			latticeService, err = r.rule.Cloud().Lattice().FindService(ctx, lsnProvider)
+			if err != nil {
+				return err
+			}
+			latticeServices[lsnProvider.LatticeServiceName()] = latticeService
+		}
+
+		listener, err := r.latticestore.GetlListener(rule.Spec.ServiceName, rule.Spec.ServiceNamespace,
+			rule.Spec.ListenerPort, rule.Spec.ListenerProtocol)
 		if err != nil {
 			return err
 		}
 
-		if ruleResp.UpdatePriorityNeeded {
-			updatePriority = true
+		if _, ok := rulesByListener[listener.ID]; !ok {
+			listenerIds = append(listenerIds, listener.ID)
+			serviceIds[listener.ID] = aws.StringValue(latticeService.Id)
 		}
+		rulesByListener[listener.ID] = append(rulesByListener[listener.ID], rule)
+	}
 
-		r.log.Debugf("Synthesise rule %s, ruleResp: %+v", rule.Spec.RuleID, ruleResp)
-		rule.Status = &ruleResp
+	for _, listenerId := range listenerIds {
+		err := r.synthesizeListenerRules(ctx, serviceIds[listenerId], listenerId, rulesByListener[listenerId])
+		if err != nil {
+			return err
+		}
 	}
 
-	// handle delete
-	sdkRules, err := r.getSDKRules(ctx)
+	// handle delete of rules on listeners without any rule left
+	sdkRules, err := r.getSDKRules(ctx, rulesByListener)
 	if err != nil {
 		r.log.Debugf("Error while getting rules due to %s", err)
 	}
 
 	for _, sdkRule := range sdkRules {
-		_, err := r.findMatchedRule(ctx, sdkRule.RuleID, sdkRule.ListenerID, sdkRule.ServiceID, resRule)
+		err := r.rule.Delete(ctx, sdkRule.RuleID, sdkRule.ListenerID, sdkRule.ServiceID)
 		if err != nil {
-			r.log.Debugf("Error while finding matching rule for service %s, listener %s, rule %s. %s",
+			r.log.Debugf("Error while deleting rule for service %s, listener %s, rule %s. %s",
 				sdkRule.ServiceID, sdkRule.ListenerID, sdkRule.RuleID, err)
-			err := r.rule.Delete(ctx, sdkRule.RuleID, sdkRule.ListenerID, sdkRule.ServiceID)
-			if err != nil {
-				r.log.Debugf("Error while deleting rule for service %s, listener %s, rule %s. %s",
-					sdkRule.ServiceID, sdkRule.ListenerID, sdkRule.RuleID, err)
-			}
 		}
 	}
 
-	if updatePriority {
-		err := r.rule.Update(ctx, resRule)
+	return nil
+}
+
+// synthesizeListenerRules brings the lattice rules of a listener in line with the model rules,
+// which are sorted in evaluation order. Lattice rules are matched to model rules by the rule id
+// recorded in their name, or by their match for rules which do not record it yet.
+func (r *ruleSynthesizer) synthesizeListenerRules(
+	ctx context.Context,
+	serviceId string,
+	listenerId string,
+	rules []*model.Rule,
+) error {
+	sdkRules, err := r.rule.List(ctx, serviceId, listenerId)
+	if err != nil {
+		return err
+	}
+
+	modelRules := make(map[string]*model.Rule)
+	for _, rule := range rules {
+		modelRules[rule.Spec.RuleID] = rule
+	}
+
+	existing := make(map[string]*model.RuleStatus)
+	details := make(map[string]*vpclattice.GetRuleOutput)
+	var unnamed, stale []*model.RuleStatus
+
+	for _, sdkRule := range sdkRules {
+		ruleId, ok := ruleIDFromLatticeRuleName(sdkRule.Name)
+		if ok && modelRules[ruleId] != nil && existing[ruleId] == nil {
+			existing[ruleId] = sdkRule
+		} else if ok {
+			stale = append(stale, sdkRule)
+		} else {
+			unnamed = append(unnamed, sdkRule)
+		}
+	}
+
+	for _, sdkRule := range unnamed {
+		sdkRuleDetail, err := r.rule.Get(ctx, serviceId, listenerId, sdkRule.RuleID)
 		if err != nil {
-			r.log.Debugf("Error while updating rule priority for rules %+v. %s", resRule, err)
+			return err
+		}
+
+		matched := false
+		if sdkRuleDetail.Match != nil && sdkRuleDetail.Match.HttpMatch != nil {
+			for _, rule := range rules {
+				if existing[rule.Spec.RuleID] == nil && isRulesSame(r.log, rule, sdkRuleDetail) {
+					existing[rule.Spec.RuleID] = sdkRule
+					details[rule.Spec.RuleID] = sdkRuleDetail
+					matched = true
+					break
+				}
+			}
+		}
+		if !matched {
+			stale = append(stale, sdkRule)
 		}
 	}
 
-	return nil
-}
+	var ruleIds []string
+	current := make(map[string]int64)
+	for _, rule := range rules {
+		ruleIds = append(ruleIds, rule.Spec.RuleID)
+		if sdkRule, ok := existing[rule.Spec.RuleID]; ok {
+			current[rule.Spec.RuleID] = sdkRule.Priority
+		}
+	}
+	var reserved []int64
+	for _, sdkRule := range stale {
+		reserved = append(reserved, sdkRule.Priority)
+	}
 
-func (r *ruleSynthesizer) findMatchedRule(
-	ctx context.Context,
-	sdkRuleId string,
-	listener string,
-	service string,
-	resRule []*model.Rule,
-) (*model.Rule, error) {
-	var modelRule *model.Rule = nil
-	sdkRuleDetail, err := r.rule.Get(ctx, service, listener, sdkRuleId)
+	plan, err := planRulePriorities(ruleIds, current, reserved)
 	if err != nil {
-		return modelRule, err
+		return err
+	}
+
+	for _, rule := range rules {
+		ruleId := rule.Spec.RuleID
+
+		if sdkRule, ok := existing[ruleId]; ok {
+			sdkRuleDetail := details[ruleId]
+			if sdkRuleDetail == nil {
+				sdkRuleDetail, err = r.rule.Get(ctx, serviceId, listenerId, sdkRule.RuleID)
+				if err != nil {
+					return err
+				}
+			}
+
+			action, err := buildLatticeRuleAction(r.latticestore, rule.Spec.Action)
+			if err != nil {
+				return err
+			}
+			if !isRuleActionSame(action, sdkRuleDetail.Action) {
+				if err := r.rule.UpdateAction(ctx, rule, sdkRule); err != nil {
+					return err
+				}
+			}
+			rule.Status = sdkRule
+			continue
+		}
+
+		ruleStatus, err := r.rule.Create(ctx, rule, serviceId, listenerId, plan.creates[ruleId])
+		if err != nil {
+			return err
+		}
+		r.log.Debugf("Synthesise rule %s, ruleResp: %+v", ruleId, ruleStatus)
+		rule.Status = &ruleStatus
 	}
 
-	if sdkRuleDetail.Match == nil ||
-		sdkRuleDetail.Match.HttpMatch == nil {
-		return modelRule, errors.New("rule not found, no HTTPMatch")
+	for _, sdkRule := range stale {
+		err := r.rule.Delete(ctx, sdkRule.RuleID, sdkRule.ListenerID, sdkRule.ServiceID)
+		if err != nil {
+			return err
+		}
 	}
 
-	for _, modelRule := range resRule {
-		sameRule := isRulesSame(r.log, modelRule, sdkRuleDetail)
-		if sameRule {
-			return modelRule, nil
+	for _, move := range plan.moves {
+		rule := modelRules[move.ruleId]
+		if err := r.rule.UpdatePriority(ctx, rule.Status, move.priority); err != nil {
+			return err
 		}
 	}
 
-	return modelRule, fmt.Errorf("failed to find matching rule in model for rule %s", sdkRuleId)
+	return nil
 }
 
-func (r *ruleSynthesizer) getSDKRules(ctx context.Context) ([]*model.RuleStatus, error) {
+// getSDKRules lists the rules of all listeners of the stack services, except the listeners in skipListeners
+func (r *ruleSynthesizer) getSDKRules(
+	ctx context.Context,
+	skipListeners map[string][]*model.Rule,
+) ([]*model.RuleStatus, error) {
 	var sdkRules []*model.RuleStatus
 	var resService []*model.Service
 	var resListener []*model.Listener
@@ -153,6 +266,9 @@ func (r *ruleSynthesizer) getSDKRules(ctx context.Context) ([]*model.RuleStatus,
 		}
 
 		for _, listener := range listeners {
+			if _, ok := skipListeners[listener.ID]; ok {
+				continue
+			}
 			rules, _ := r.rule.List(ctx, aws.StringValue(latticeService.Id), listener.ID)
 			sdkRules = append(sdkRules, rules...)
 		}
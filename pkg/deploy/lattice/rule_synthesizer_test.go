@@ -68,6 +68,7 @@ func Test_SynthesizeRule(t *testing.T) {
 		serviceARN     string
 		serviceID      string
 		rulespec       []model.RuleSpec
+		existingRules  bool
 		updatedTGs     bool
 		mgrErr         error
 		wantErrIsNil   bool
@@ -145,7 +146,7 @@ func Test_SynthesizeRule(t *testing.T) {
 			wantErrIsNil:  true,
 		},
 		{
-			name:           "Test2: Add Rule",
+			name:           "Test2: Update rule target groups",
 			gwListenerPort: *PortNumberPtr(80),
 			httpRoute: &gwv1beta1.HTTPRoute{
 				ObjectMeta: metav1.ObjectMeta{
@@ -210,6 +211,7 @@ func Test_SynthesizeRule(t *testing.T) {
 			listenerID:    "1234",
 			serviceARN:    "arn56789",
 			serviceID:     "56789",
+			existingRules: true,
 			updatedTGs:    true,
 			mgrErr:        nil,
 			wantIsDeleted: false,
@@ -229,11 +231,24 @@ func Test_SynthesizeRule(t *testing.T) {
 			stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(tt.httpRoute)))
 
 			mockRuleManager := NewMockRuleManager(c)
+			mockCloud := mocks_aws.NewMockCloud(c)
+			mockLattice := mocks.NewMockLattice(c)
 
-			var ruleID = 1
+			mockRuleManager.EXPECT().Cloud().Return(mockCloud).AnyTimes()
+			mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+			mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(
+				&vpclattice.ServiceSummary{
+					Arn: aws.String(tt.serviceARN),
+					Id:  aws.String(tt.serviceID),
+				}, nil)
+
+			ds.AddListener(tt.httpRoute.Name, tt.httpRoute.Namespace, int64(tt.gwListenerPort), protocol,
+				tt.listenerARN, tt.listenerID)
+
+			var sdkRules []*model.RuleStatus
 			for i, httpRule := range tt.httpRoute.Spec.Rules {
-				//var ruleValue string
 				tgList := []*model.RuleTargetGroup{}
+				latticeTGs := []*vpclattice.WeightedTargetGroup{}
 
 				for _, httpBackendRef := range httpRule.BackendRefs {
 					ruleTG := model.RuleTargetGroup{}
@@ -246,36 +261,62 @@ func Test_SynthesizeRule(t *testing.T) {
 					}
 
 					tgList = append(tgList, &ruleTG)
+
+					tgID := fmt.Sprintf("tg-id-%s", ruleTG.Name)
+					ds.AddTargetGroup(latticestore.TargetGroupName(ruleTG.Name, ruleTG.Namespace), "vpc", "arn",
+						tgID, false, "")
+
+					weight := ruleTG.Weight
+					if tt.updatedTGs {
+						weight++
+					}
+					latticeTGs = append(latticeTGs, &vpclattice.WeightedTargetGroup{
+						TargetGroupIdentifier: aws.String(tgID),
+						Weight:                aws.Int64(weight),
+					})
 				}
 
-				ruleIDName := fmt.Sprintf("rule-%d", ruleID)
+				ruleSpec := tt.rulespec[i]
+				ruleSpec.Order = i + 1
+				ruleIDName := fmt.Sprintf("rule-%s", model.RuleMatchKey(int64(tt.gwListenerPort), protocol, &ruleSpec))
 				ruleAction := model.RuleAction{
 					TargetGroups: tgList,
 				}
 				rule := model.NewRule(stack, ruleIDName, tt.httpRoute.Name, tt.httpRoute.Namespace, int64(tt.gwListenerPort),
-					protocol, ruleAction, tt.rulespec[i])
+					protocol, ruleAction, ruleSpec)
+
+				if !tt.existingRules {
+					// new rules are spread over the free priorities
+					priority := int64((i+1)*100/(len(tt.httpRoute.Spec.Rules)+1) + 1)
+					mockRuleManager.EXPECT().Create(ctx, rule, tt.serviceID, tt.listenerID, priority).Return(
+						model.RuleStatus{RuleID: ruleIDName, Priority: priority}, nil)
+					continue
+				}
 
-				var ruleResp model.RuleStatus
+				sdkRule := &model.RuleStatus{
+					RuleID:     fmt.Sprintf("sdk-rule-%d", i),
+					Name:       "k8s-" + ruleIDName,
+					Priority:   int64(i + 1),
+					ListenerID: tt.listenerID,
+					ServiceID:  tt.serviceID,
+				}
+				sdkRules = append(sdkRules, sdkRule)
+
+				mockRuleManager.EXPECT().Get(ctx, tt.serviceID, tt.listenerID, sdkRule.RuleID).Return(
+					&vpclattice.GetRuleOutput{
+						Id:       aws.String(sdkRule.RuleID),
+						Priority: aws.Int64(sdkRule.Priority),
+						Action: &vpclattice.RuleAction{
+							Forward: &vpclattice.ForwardAction{TargetGroups: latticeTGs},
+						},
+					}, nil)
 
 				if tt.updatedTGs {
-					ruleResp.UpdatePriorityNeeded = true
-				} else {
-					ruleResp.UpdatePriorityNeeded = false
+					mockRuleManager.EXPECT().UpdateAction(ctx, rule, sdkRule)
 				}
-				mockRuleManager.EXPECT().Create(ctx, rule).Return(ruleResp, nil)
-
-				ruleID++
-
 			}
 
-			var resRule []*model.Rule
-			stack.ListResources(&resRule)
-
-			if tt.updatedTGs {
-				// TODO, resRule return from stack.ListResources is not consistent with the ordering
-				// so we use gomock.Any() instead of resRule below
-				mockRuleManager.EXPECT().Update(ctx, gomock.Any())
-			}
+			mockRuleManager.EXPECT().List(ctx, tt.serviceID, tt.listenerID).Return(sdkRules, nil)
 
 			synthesizer := NewRuleSynthesizer(gwlog.FallbackLogger, mockRuleManager, stack, ds)
 
@@ -396,10 +437,8 @@ func Test_SynthesizeDeleteRule(t *testing.T) {
 
 		mockRuleManager.EXPECT().List(ctx, serviceID, listener.listenerID).Return(listener.rulelist, nil)
 
+		// no rule of the model is left on the listener, so all of its rules are deleted
 		for _, rule := range listener.rulelist {
-			sdkRuleDetail := vpclattice.GetRuleOutput{}
-
-			mockRuleManager.EXPECT().Get(ctx, serviceID, listener.listenerID, rule.RuleID).Return(&sdkRuleDetail, nil)
 			mockRuleManager.EXPECT().Delete(ctx, rule.RuleID, listener.listenerID, serviceID)
 		}
 
@@ -410,3 +449,92 @@ func Test_SynthesizeDeleteRule(t *testing.T) {
 	err := synthesizer.Synthesize(ctx)
 	assert.Nil(t, err)
 }
+
+func Test_SynthesizeReorderRule(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	ds := latticestore.NewLatticeDataStore()
+
+	mockRuleManager := NewMockRuleManager(c)
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	mockRuleManager.EXPECT().Cloud().Return(mockCloud).AnyTimes()
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	serviceID := "service1-id"
+	listenerID := "listener1-id"
+	httpRoute := gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service1",
+			Namespace: "default",
+		},
+	}
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(&httpRoute.ObjectMeta)))
+
+	mockLattice.EXPECT().FindService(gomock.Any(), gomock.Any()).Return(
+		&vpclattice.ServiceSummary{Id: aws.String(serviceID)}, nil)
+	ds.AddListener(httpRoute.Name, httpRoute.Namespace, 80, "HTTP", "listener1-arn", listenerID)
+	ds.AddTargetGroup(latticestore.TargetGroupName("tg1", "default"), "vpc", "arn", "tg1-id", false, "")
+
+	action := model.RuleAction{
+		TargetGroups: []*model.RuleTargetGroup{{Name: "tg1", Namespace: "default", Weight: 1}},
+	}
+	latticeAction := &vpclattice.RuleAction{
+		Forward: &vpclattice.ForwardAction{
+			TargetGroups: []*vpclattice.WeightedTargetGroup{
+				{TargetGroupIdentifier: aws.String("tg1-id"), Weight: aws.Int64(1)},
+			},
+		},
+	}
+
+	newRule := func(path string, order int) *model.Rule {
+		spec := model.RuleSpec{PathMatchPrefix: true, PathMatchValue: path, Order: order}
+		ruleId := fmt.Sprintf("rule-%s", model.RuleMatchKey(80, "HTTP", &spec))
+		return model.NewRule(stack, ruleId, httpRoute.Name, httpRoute.Namespace, 80, "HTTP", action, spec)
+	}
+
+	// /b moves in front of /a and /c is added last
+	ruleB := newRule("/b", 1)
+	ruleA := newRule("/a", 2)
+	ruleC := newRule("/c", 3)
+
+	sdkRuleA := &model.RuleStatus{RuleID: "sdk-a", Name: "k8s-" + ruleA.Spec.RuleID, Priority: 1,
+		ServiceID: serviceID, ListenerID: listenerID}
+	// created before rule names recorded the rule id, found by its match instead
+	sdkRuleB := &model.RuleStatus{RuleID: "sdk-b", Name: "k8s-1690000000-rule-2", Priority: 2,
+		ServiceID: serviceID, ListenerID: listenerID}
+	sdkRuleStale := &model.RuleStatus{RuleID: "sdk-stale", Name: "k8s-rule-0123456789abcdef", Priority: 3,
+		ServiceID: serviceID, ListenerID: listenerID}
+
+	httpMatchB := vpclattice.HttpMatch{}
+	updateSDKhttpMatch(&httpMatchB, ruleB)
+
+	mockRuleManager.EXPECT().List(ctx, serviceID, listenerID).Return(
+		[]*model.RuleStatus{sdkRuleA, sdkRuleB, sdkRuleStale}, nil)
+	mockRuleManager.EXPECT().Get(ctx, serviceID, listenerID, "sdk-b").Return(
+		&vpclattice.GetRuleOutput{
+			Id:     aws.String("sdk-b"),
+			Match:  &vpclattice.RuleMatch{HttpMatch: &httpMatchB},
+			Action: latticeAction,
+		}, nil)
+	mockRuleManager.EXPECT().Get(ctx, serviceID, listenerID, "sdk-a").Return(
+		&vpclattice.GetRuleOutput{Id: aws.String("sdk-a"), Action: latticeAction}, nil)
+
+	// /b keeps its priority, /a moves behind it only after /c is created and the stale rule is gone
+	gomock.InOrder(
+		mockRuleManager.EXPECT().Create(ctx, ruleC, serviceID, listenerID, int64(68)).Return(
+			model.RuleStatus{RuleID: "sdk-c", Priority: 68}, nil),
+		mockRuleManager.EXPECT().Delete(ctx, "sdk-stale", listenerID, serviceID),
+		mockRuleManager.EXPECT().UpdatePriority(ctx, sdkRuleA, int64(36)),
+	)
+
+	synthesizer := NewRuleSynthesizer(gwlog.FallbackLogger, mockRuleManager, stack, ds)
+
+	err := synthesizer.Synthesize(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, sdkRuleB, ruleB.Status)
+	assert.Equal(t, "sdk-c", ruleC.Status.RuleID)
+}
@@ -48,6 +48,23 @@ func getLatticeTGName(targetGroup *model.TargetGroup) string {
 		namePrefix = latticestore.TargetGroupLongName(namePrefix,
 			targetGroup.Spec.Config.K8SHTTPRouteName, config.VpcID)
 	}
+	if targetGroup.Spec.Type == model.TargetGroupTypeLambda {
+		// LAMBDA target groups have no protocol, their name already tells them apart from the ones of Services
+		return namePrefix
+	}
+	if targetGroup.Spec.Type == model.TargetGroupTypeInstance {
+		// the type of a target group cannot change, INSTANCE target groups replace the IP ones
+		namePrefix = fmt.Sprintf("%s-instance", namePrefix)
+	}
+	if targetGroup.Spec.Config.SecondaryIpFamily {
+		// the IP address type of a target group cannot change either, dual-stack Services switching
+		// to their secondary family get a new target group, the target group of the primary family keeps its name
+		namePrefix = fmt.Sprintf("%s-%s", namePrefix, strings.ToLower(targetGroup.Spec.Config.IpAddressType))
+	}
+	if protocolVersion == "" {
+		// TCP target groups have no protocol version
+		return fmt.Sprintf("%s-%s", namePrefix, protocol)
+	}
 	return fmt.Sprintf("%s-%s-%s", namePrefix, protocol, protocolVersion)
 }
 
@@ -98,13 +115,26 @@ func (s *defaultTargetGroupManager) Create(
 		ipAddressType = nil
 	}
 
+	protocolVersion := &targetGroup.Spec.Config.ProtocolVersion
+	healthCheckConfig := targetGroup.Spec.Config.HealthCheckConfig
+	if targetGroup.Spec.Config.Protocol == model.TargetGroupProtocolTCP {
+		protocolVersion = nil
+		if healthCheckConfig == nil {
+			healthCheckConfig = s.getDefaultTCPHealthCheckConfig()
+		}
+	}
+
 	tgConfig := &vpclattice.TargetGroupConfig{
 		Port:            &port,
 		Protocol:        &targetGroup.Spec.Config.Protocol,
-		ProtocolVersion: &targetGroup.Spec.Config.ProtocolVersion,
+		ProtocolVersion: protocolVersion,
 		VpcIdentifier:   &targetGroup.Spec.Config.VpcID,
 		IpAddressType:   ipAddressType,
-		HealthCheck:     targetGroup.Spec.Config.HealthCheckConfig,
+		HealthCheck:     healthCheckConfig,
+	}
+	if targetGroup.Spec.Type == model.TargetGroupTypeLambda {
+		// LAMBDA target groups are created without config
+		tgConfig = nil
 	}
 
 	targetGroupType := string(targetGroup.Spec.Type)
@@ -175,10 +205,19 @@ func (s *defaultTargetGroupManager) update(ctx context.Context, targetGroup *mod
 		TargetGroupID:  aws.StringValue(tgSummary.Id),
 	}
 
+	if targetGroup.Spec.Type == model.TargetGroupTypeLambda {
+		// the health check is the only setting to update, which LAMBDA target groups do not have
+		return targetGroupStatus, nil
+	}
+
 	if healthCheckConfig == nil {
 		s.log.Debugf("HealthCheck is empty. Resetting to default settings")
-		targetGroupProtocolVersion := targetGroup.Spec.Config.ProtocolVersion
-		healthCheckConfig = s.getDefaultHealthCheckConfig(targetGroupProtocolVersion)
+		if targetGroup.Spec.Config.Protocol == model.TargetGroupProtocolTCP {
+			healthCheckConfig = s.getDefaultTCPHealthCheckConfig()
+		} else {
+			targetGroupProtocolVersion := targetGroup.Spec.Config.ProtocolVersion
+			healthCheckConfig = s.getDefaultHealthCheckConfig(targetGroupProtocolVersion)
+		}
 	}
 
 	_, err := vpcLatticeSess.UpdateTargetGroupWithContext(ctx, &vpclattice.UpdateTargetGroupInput{
@@ -297,7 +336,9 @@ func (s *defaultTargetGroupManager) List(ctx context.Context) ([]targetGroupOutp
 			continue
 		}
 
-		if tgOutput.Config != nil && aws.StringValue(tgOutput.Config.VpcIdentifier) == config.VpcID {
+		// LAMBDA target groups have no VPC, the ones of this controller are told by their managedBy tag below
+		isLambda := aws.StringValue(tgOutput.Type) == vpclattice.TargetGroupTypeLambda
+		if isLambda || tgOutput.Config != nil && aws.StringValue(tgOutput.Config.VpcIdentifier) == config.VpcID {
 			// retrieve target group tags
 			//ListTagsForResourceWithContext
 			tagsInput := vpclattice.ListTagsForResourceInput{
@@ -310,6 +351,9 @@ func (s *defaultTargetGroupManager) List(ctx context.Context) ([]targetGroupOutp
 				// setting it to nil, so the caller knows there is tag resource associated to this target group
 				tagsOutput = nil
 			}
+			if isLambda && (tagsOutput == nil || !s.cloud.ContainsManagedBy(tagsOutput.Tags)) {
+				continue
+			}
 			tgOutput := targetGroupOutput{
 				getTargetGroupOutput: *tgOutput,
 				targetGroupTags:      tagsOutput,
@@ -321,7 +365,7 @@ func (s *defaultTargetGroupManager) List(ctx context.Context) ([]targetGroupOutp
 }
 
 func isNameOfTargetGroup(targetGroup *model.TargetGroup, name string) bool {
-	if targetGroup.Spec.Config.IsServiceImport {
+	if targetGroup.Spec.Config.IsServiceImport && targetGroup.Spec.Config.Protocol != model.TargetGroupProtocolTCP {
 		// We are missing protocol info for ServiceImport, but we do know the RouteType.
 		// Relying on the assumption that we have one TG per (RouteType, Service),
 		// do a simple guess to find the matching TG.
@@ -337,19 +381,40 @@ func isNameOfTargetGroup(targetGroup *model.TargetGroup, name string) bool {
 			validProtocolVersions = []string{vpclattice.TargetGroupProtocolVersionGrpc}
 		}
 
-		for _, p := range validProtocols {
-			for _, pv := range validProtocolVersions {
-				candidate := &model.TargetGroup{
-					Spec: model.TargetGroupSpec{
-						Name: targetGroup.Spec.Name,
-						Config: model.TargetGroupConfig{
-							Protocol:        p,
-							ProtocolVersion: pv,
-						},
-					},
-				}
-				if name == getLatticeTGName(candidate) {
-					return true
+		// The exporting cluster may register instances instead of IPs
+		validTypes := []model.TargetGroupType{
+			model.TargetGroupTypeIP,
+			model.TargetGroupTypeInstance,
+		}
+
+		// and may export a Service of either IP family, or the secondary family of a dual-stack Service
+		validIpAddressTypes := []string{
+			vpclattice.IpAddressTypeIpv4,
+			vpclattice.IpAddressTypeIpv6,
+		}
+
+		for _, tgType := range validTypes {
+			for _, ipAddressType := range validIpAddressTypes {
+				for _, secondaryIpFamily := range []bool{false, true} {
+					for _, p := range validProtocols {
+						for _, pv := range validProtocolVersions {
+							candidate := &model.TargetGroup{
+								Spec: model.TargetGroupSpec{
+									Name: targetGroup.Spec.Name,
+									Type: tgType,
+									Config: model.TargetGroupConfig{
+										Protocol:          p,
+										ProtocolVersion:   pv,
+										IpAddressType:     ipAddressType,
+										SecondaryIpFamily: secondaryIpFamily,
+									},
+								},
+							}
+							if name == getLatticeTGName(candidate) {
+								return true
+							}
+						}
+					}
 				}
 			}
 		}
@@ -428,3 +493,11 @@ func (s *defaultTargetGroupManager) getDefaultHealthCheckConfig(targetGroupProto
 		HealthCheckTimeoutSeconds:  &intResetValue,
 	}
 }
+
+// Targets of TCP target groups, such as the ones behind TLS passthrough listeners, need not speak HTTP,
+// so unless a TargetGroupPolicy configures one, their health check is disabled.
+func (s *defaultTargetGroupManager) getDefaultTCPHealthCheckConfig() *vpclattice.HealthCheckConfig {
+	return &vpclattice.HealthCheckConfig{
+		Enabled: aws.Bool(false),
+	}
+}
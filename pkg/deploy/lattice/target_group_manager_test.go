@@ -125,6 +125,220 @@ func Test_CreateTargetGroup_TGNotExist_Active(t *testing.T) {
 	}
 }
 
+// TCP target group has no protocol version and its health check is disabled by default
+func Test_CreateTargetGroup_TCP(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	tgCreateInput := model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Name: "test",
+			Config: model.TargetGroupConfig{
+				Port:     int32(80),
+				Protocol: model.TargetGroupProtocolTCP,
+				VpcID:    config.VpcID,
+			},
+		},
+	}
+
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{}, nil)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, opts ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
+			assert.Equal(t, "test-tcp", aws.StringValue(input.Name))
+			assert.Equal(t, "TCP", aws.StringValue(input.Config.Protocol))
+			assert.Nil(t, input.Config.ProtocolVersion)
+			assert.False(t, aws.BoolValue(input.Config.HealthCheck.Enabled))
+			return &vpclattice.CreateTargetGroupOutput{
+				Arn:    aws.String("arn"),
+				Id:     aws.String("id"),
+				Status: aws.String(vpclattice.TargetGroupStatusActive),
+			}, nil
+		})
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Create(ctx, &tgCreateInput)
+	assert.Nil(t, err)
+	assert.Equal(t, "id", resp.TargetGroupID)
+}
+
+func Test_CreateTargetGroup_Lambda(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	tgCreateInput := model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Name: "lambda-fn-default",
+			Type: model.TargetGroupTypeLambda,
+			Config: model.TargetGroupConfig{
+				VpcID:               config.VpcID,
+				K8SServiceName:      "fn",
+				K8SServiceNamespace: "default",
+			},
+		},
+	}
+
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{}, nil)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, opts ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
+			assert.Equal(t, "lambda-fn-default", aws.StringValue(input.Name))
+			assert.Equal(t, vpclattice.TargetGroupTypeLambda, aws.StringValue(input.Type))
+			assert.Nil(t, input.Config)
+			return &vpclattice.CreateTargetGroupOutput{
+				Arn:    aws.String("arn"),
+				Id:     aws.String("id"),
+				Status: aws.String(vpclattice.TargetGroupStatusActive),
+			}, nil
+		})
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Create(ctx, &tgCreateInput)
+	assert.Nil(t, err)
+	assert.Equal(t, "id", resp.TargetGroupID)
+
+	// existing LAMBDA target groups have no health check to update
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{{
+		Arn:    aws.String("arn"),
+		Id:     aws.String("id"),
+		Name:   aws.String("lambda-fn-default"),
+		Status: aws.String(vpclattice.TargetGroupStatusActive),
+		Type:   aws.String(vpclattice.TargetGroupTypeLambda),
+	}}, nil)
+	resp, err = tgManager.Create(ctx, &tgCreateInput)
+	assert.Nil(t, err)
+	assert.Equal(t, "id", resp.TargetGroupID)
+}
+
+func Test_CreateTargetGroup_Instance(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockLattice := mocks.NewMockLattice(c)
+	cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+	tgCreateInput := model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Name: "test",
+			Type: model.TargetGroupTypeInstance,
+			Config: model.TargetGroupConfig{
+				Port:                80,
+				Protocol:            "HTTP",
+				ProtocolVersion:     vpclattice.TargetGroupProtocolVersionHttp1,
+				VpcID:               config.VpcID,
+				K8SServiceName:      "svc",
+				K8SServiceNamespace: "default",
+			},
+		},
+	}
+
+	mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{}, nil)
+	mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, opts ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
+			assert.Equal(t, "test-instance-http-http1", aws.StringValue(input.Name))
+			assert.Equal(t, vpclattice.TargetGroupTypeInstance, aws.StringValue(input.Type))
+			assert.Nil(t, input.Config.IpAddressType)
+			assert.Equal(t, int64(80), aws.Int64Value(input.Config.Port))
+			return &vpclattice.CreateTargetGroupOutput{
+				Arn:    aws.String("arn"),
+				Id:     aws.String("id"),
+				Status: aws.String(vpclattice.TargetGroupStatusActive),
+			}, nil
+		})
+
+	tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+	resp, err := tgManager.Create(ctx, &tgCreateInput)
+	assert.Nil(t, err)
+	assert.Equal(t, "id", resp.TargetGroupID)
+}
+
+func Test_CreateTargetGroup_IPv6(t *testing.T) {
+	tests := []struct {
+		name              string
+		secondaryIpFamily bool
+		wantName          string
+	}{
+		{
+			name:     "single-stack IPv6 Services keep the name of their target group",
+			wantName: "test-http-http1",
+		},
+		{
+			name:              "switching to the secondary family of a dual-stack Service creates a new target group",
+			secondaryIpFamily: true,
+			wantName:          "test-ipv6-http-http1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.TODO()
+			mockLattice := mocks.NewMockLattice(c)
+			cloud := pkg_aws.NewDefaultCloud(mockLattice, TestCloudConfig)
+
+			tgCreateInput := model.TargetGroup{
+				Spec: model.TargetGroupSpec{
+					Name: "test",
+					Type: model.TargetGroupTypeIP,
+					Config: model.TargetGroupConfig{
+						Port:                80,
+						Protocol:            "HTTP",
+						ProtocolVersion:     vpclattice.TargetGroupProtocolVersionHttp1,
+						VpcID:               config.VpcID,
+						K8SServiceName:      "svc",
+						K8SServiceNamespace: "default",
+						IpAddressType:       vpclattice.IpAddressTypeIpv6,
+						SecondaryIpFamily:   tt.secondaryIpFamily,
+					},
+				},
+			}
+
+			mockLattice.EXPECT().ListTargetGroupsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetGroupSummary{}, nil)
+			mockLattice.EXPECT().CreateTargetGroupWithContext(ctx, gomock.Any()).DoAndReturn(
+				func(ctx context.Context, input *vpclattice.CreateTargetGroupInput, opts ...interface{}) (*vpclattice.CreateTargetGroupOutput, error) {
+					assert.Equal(t, tt.wantName, aws.StringValue(input.Name))
+					assert.Equal(t, vpclattice.IpAddressTypeIpv6, aws.StringValue(input.Config.IpAddressType))
+					return &vpclattice.CreateTargetGroupOutput{
+						Arn:    aws.String("arn"),
+						Id:     aws.String("id"),
+						Status: aws.String(vpclattice.TargetGroupStatusActive),
+					}, nil
+				})
+
+			tgManager := NewTargetGroupManager(gwlog.FallbackLogger, cloud)
+			resp, err := tgManager.Create(ctx, &tgCreateInput)
+			assert.Nil(t, err)
+			assert.Equal(t, "id", resp.TargetGroupID)
+		})
+	}
+}
+
+func Test_isNameOfTargetGroup_ServiceImport(t *testing.T) {
+	tg := &model.TargetGroup{
+		Spec: model.TargetGroupSpec{
+			Name: "test",
+			Config: model.TargetGroupConfig{
+				IsServiceImport: true,
+				Protocol:        "HTTP",
+				ProtocolVersion: vpclattice.TargetGroupProtocolVersionHttp1,
+				IpAddressType:   vpclattice.IpAddressTypeIpv4,
+			},
+		},
+	}
+
+	assert.True(t, isNameOfTargetGroup(tg, "test-http-http1"))
+	assert.True(t, isNameOfTargetGroup(tg, "test-https-http2"), "the exporting cluster may export an IPv6 service")
+	assert.True(t, isNameOfTargetGroup(tg, "test-ipv6-https-http2"), "or the secondary family of a dual-stack service")
+	assert.True(t, isNameOfTargetGroup(tg, "test-ipv4-http-http1"))
+	assert.True(t, isNameOfTargetGroup(tg, "test-instance-http-http1"))
+	assert.False(t, isNameOfTargetGroup(tg, "other-ipv6-http-http1"))
+}
+
 // target group status is failed, and is active after creation
 func Test_CreateTargetGroup_TGFailed_Active(t *testing.T) {
 	c := gomock.NewController(t)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -13,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
@@ -175,7 +177,8 @@ func (t *TargetGroupSynthesizer) SynthesizeSDKTargetGroups(ctx context.Context)
 	for _, sdkTG := range sdkTGs {
 		tgRouteName := ""
 
-		if *sdkTG.getTargetGroupOutput.Config.VpcIdentifier != config.VpcID {
+		isLambda := aws.StringValue(sdkTG.getTargetGroupOutput.Type) == vpclattice.TargetGroupTypeLambda
+		if !isLambda && aws.StringValue(sdkTG.getTargetGroupOutput.Config.VpcIdentifier) != config.VpcID {
 			t.log.Debugf("Ignoring target group %s (%s) because it is configured for other VPCs",
 				*sdkTG.getTargetGroupOutput.Arn, *sdkTG.getTargetGroupOutput.Name)
 			continue
@@ -258,7 +261,8 @@ func (t *TargetGroupSynthesizer) SynthesizeSDKTargetGroups(ctx context.Context)
 			}
 
 			var route core.Route
-			if *sdkTG.getTargetGroupOutput.Config.ProtocolVersion == vpclattice.TargetGroupProtocolVersionGrpc {
+			// LAMBDA target groups have no config, they are only used by HTTPRoutes
+			if !isLambda && aws.StringValue(sdkTG.getTargetGroupOutput.Config.ProtocolVersion) == vpclattice.TargetGroupProtocolVersionGrpc {
 				if route, err = core.GetGRPCRoute(ctx, t.client, routeName); err != nil {
 					t.log.Errorf("Could not find GRPCRoute for target group %s", err)
 				}
@@ -270,6 +274,9 @@ func (t *TargetGroupSynthesizer) SynthesizeSDKTargetGroups(ctx context.Context)
 
 			if route != nil {
 				tgName := latticestore.TargetGroupName(*srvName, *srvNamespace)
+				if isLambda {
+					tgName = latticestore.LambdaTargetGroupName(*srvName, *srvNamespace)
+				}
 
 				// We have finished rule reconciliation at this point.
 				// If a target group under HTTPRoute does not have any service, it is stale.
@@ -327,7 +334,7 @@ func (t *TargetGroupSynthesizer) SynthesizeSDKTargetGroups(ctx context.Context)
 func (t *TargetGroupSynthesizer) isTargetGroupUsedByRoute(ctx context.Context, tgName string, route core.Route) bool {
 	for _, rule := range route.Spec().Rules() {
 		for _, backendRef := range rule.BackendRefs() {
-			if string(*backendRef.Kind()) != "Service" {
+			if string(*backendRef.Kind()) != "Service" && string(*backendRef.Kind()) != anv1alpha1.LambdaFunctionKind {
 				continue
 			}
 			namespace := route.Namespace()
@@ -335,6 +342,9 @@ func (t *TargetGroupSynthesizer) isTargetGroupUsedByRoute(ctx context.Context, t
 				namespace = string(*backendRef.Namespace())
 			}
 			refTGName := latticestore.TargetGroupName(string(backendRef.Name()), namespace)
+			if string(*backendRef.Kind()) == anv1alpha1.LambdaFunctionKind {
+				refTGName = latticestore.LambdaTargetGroupName(string(backendRef.Name()), namespace)
+			}
 
 			if tgName == refTGName {
 				return true
@@ -3,6 +3,7 @@ package lattice
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
@@ -35,18 +36,50 @@ func NewTargetsManager(
 	}
 }
 
-// Create will try to register targets to the target group
+const (
+	// maxTargetsPerCall is the maximum number of targets of a RegisterTargets or DeregisterTargets call
+	maxTargetsPerCall = 100
+)
+
+// TargetsFailedError lists the targets VPC Lattice failed to register to or deregister from a target group,
+// with their failure codes. It wraps RetryErr, the targets are retried on the next reconcile.
+type TargetsFailedError struct {
+	TargetGroupID  string
+	Registration   []*vpclattice.TargetFailure
+	Deregistration []*vpclattice.TargetFailure
+}
+
+func (e *TargetsFailedError) Error() string {
+	return fmt.Sprintf("failed to register %d and deregister %d targets of target group %s",
+		len(e.Registration), len(e.Deregistration), e.TargetGroupID)
+}
+
+func (e *TargetsFailedError) Unwrap() error {
+	return RetryErr
+}
+
+// Create reconciles the targets of the target group with the targets of the model. Only the targets missing from the
+// target group are registered, and only the stale ones are deregistered, in chunks of maxTargetsPerCall.
 // return Retry when:
 //
-//		Target group does not exist
-//		nonempty unsuccessfully registered targets list
-//	otherwise:
+//	Target group does not exist
+//
+// return err when:
+//
+//	registering or deregistering targets fails
+//
+// return TargetsFailedError when:
+//
+//	some targets are unsuccessfully registered or deregistered
+//
+// otherwise:
+//
 //	nil
 func (s *defaultTargetsManager) Create(ctx context.Context, targets *model.Targets) error {
 	s.log.Debugf("Creating targets for target group %s-%s", targets.Spec.Name, targets.Spec.Namespace)
 
 	// Need to find TargetGroup ID from datastore
-	tgName := latticestore.TargetGroupName(targets.Spec.Name, targets.Spec.Namespace)
+	tgName := targetsTargetGroupName(&targets.Spec)
 	tg, err := s.datastore.GetTargetGroup(tgName, targets.Spec.RouteName, false) // isServiceImport=false
 	if err != nil {
 		s.log.Debugf("Failed to Create targets, service %s-%s was not found, will retry later",
@@ -58,70 +91,168 @@ func (s *defaultTargetsManager) Create(ctx context.Context, targets *model.Targe
 	listTargetsInput := vpclattice.ListTargetsInput{
 		TargetGroupIdentifier: &tg.ID,
 	}
-	var delTargetsList []*vpclattice.Target
 	listTargetsOutput, err := vpcLatticeSess.ListTargetsAsList(ctx, &listTargetsInput)
 	if err != nil {
 		return err
 	}
 
+	// TODO following should be done at model level
+	var desiredKeys []string
+	desiredTargets := make(map[string]*vpclattice.Target)
+	for _, target := range targets.Spec.TargetIPList {
+		port := target.Port
+		targetIP := target.TargetIP
+		t := vpclattice.Target{
+			Id:   &targetIP,
+			Port: &port,
+		}
+		if port == 0 {
+			// Lambda function targets have no port
+			t.Port = nil
+		}
+		key := targetKey(t.Id, t.Port)
+		if _, ok := desiredTargets[key]; !ok {
+			desiredKeys = append(desiredKeys, key)
+		}
+		desiredTargets[key] = &t
+	}
+
+	var delTargetsList []*vpclattice.Target
+	var drainingTargets []latticestore.Target
 	for _, sdkT := range listTargetsOutput {
-		// check if sdkT is in input target list
-		isStale := true
-		for _, t := range targets.Spec.TargetIPList {
-			if (aws.StringValue(sdkT.Id) == t.TargetIP) && (aws.Int64Value(sdkT.Port) == t.Port) {
-				isStale = false
-				break
+		key := targetKey(sdkT.Id, sdkT.Port)
+		draining := aws.StringValue(sdkT.Status) == vpclattice.TargetStatusDraining
+		if _, ok := desiredTargets[key]; ok {
+			if !draining {
+				// already registered
+				delete(desiredTargets, key)
 			}
+			continue
 		}
-		if isStale {
-			delTargetsList = append(delTargetsList, &vpclattice.Target{Id: sdkT.Id, Port: sdkT.Port})
+		if draining {
+			// already deregistered, VPC Lattice removes it once its in-flight requests complete
+			drainingTargets = append(drainingTargets, drainingTarget(sdkT.Id, sdkT.Port))
+			continue
 		}
+		delTargetsList = append(delTargetsList, &vpclattice.Target{Id: sdkT.Id, Port: sdkT.Port})
 	}
 
+	failedErr := &TargetsFailedError{TargetGroupID: tg.ID}
+
+	// a failed deregistration does not hold back the registration of the missing targets, it is returned after it
+	var deregisterErr error
 	if len(delTargetsList) > 0 {
-		deRegisterTargetsInput := vpclattice.DeregisterTargetsInput{
-			TargetGroupIdentifier: &tg.ID,
-			Targets:               delTargetsList,
-		}
-		_, err := vpcLatticeSess.DeregisterTargetsWithContext(ctx, &deRegisterTargetsInput)
+		deregistered, unsuccessful, err := s.updateTargets(delTargetsList,
+			func(chunk []*vpclattice.Target) ([]*vpclattice.Target, []*vpclattice.TargetFailure, error) {
+				resp, err := vpcLatticeSess.DeregisterTargetsWithContext(ctx, &vpclattice.DeregisterTargetsInput{
+					TargetGroupIdentifier: &tg.ID,
+					Targets:               chunk,
+				})
+				if err != nil || resp == nil {
+					return nil, nil, err
+				}
+				return resp.Successful, resp.Unsuccessful, nil
+			})
 		if err != nil {
-			s.log.Errorf("Deregistering targets for target group %s failed due to %s", tg.ID, err)
+			deregisterErr = fmt.Errorf("failed to deregister targets of target group %s: %w", tg.ID, err)
+		}
+		for _, t := range deregistered {
+			drainingTargets = append(drainingTargets, drainingTarget(t.Id, t.Port))
 		}
+		failedErr.Deregistration = unsuccessful
 	}
 
-	// TODO following should be done at model level
-	var targetList []*vpclattice.Target
-	for _, target := range targets.Spec.TargetIPList {
-		port := target.Port
-		targetIP := target.TargetIP
-		t := vpclattice.Target{
-			Id:   &targetIP,
-			Port: &port,
+	if err := s.datastore.UpdateDrainingTargetsForTargetGroup(tgName, targets.Spec.RouteName, drainingTargets); err != nil {
+		s.log.Debugf("Failed to update draining targets of target group %s due to %s", tgName, err)
+	}
+
+	if len(desiredTargets) > 0 {
+		var targetList []*vpclattice.Target
+		for _, key := range desiredKeys {
+			if t, ok := desiredTargets[key]; ok {
+				targetList = append(targetList, t)
+			}
+		}
+		_, unsuccessful, err := s.updateTargets(targetList,
+			func(chunk []*vpclattice.Target) ([]*vpclattice.Target, []*vpclattice.TargetFailure, error) {
+				resp, err := vpcLatticeSess.RegisterTargetsWithContext(ctx, &vpclattice.RegisterTargetsInput{
+					TargetGroupIdentifier: &tg.ID,
+					Targets:               chunk,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+				return resp.Successful, resp.Unsuccessful, nil
+			})
+		if err != nil {
+			return err
 		}
-		targetList = append(targetList, &t)
+		failedErr.Registration = unsuccessful
 	}
 
-	// No targets to register
-	if len(targetList) == 0 {
-		return nil
+	if deregisterErr != nil {
+		return deregisterErr
 	}
 
-	registerRouteInput := vpclattice.RegisterTargetsInput{
-		TargetGroupIdentifier: &tg.ID,
-		Targets:               targetList,
+	if len(failedErr.Registration) > 0 || len(failedErr.Deregistration) > 0 {
+		s.log.Debugf("%s, will retry later", failedErr)
+		return failedErr
 	}
 
-	resp, err := vpcLatticeSess.RegisterTargetsWithContext(ctx, &registerRouteInput)
-	if err != nil {
-		return err
+	s.log.Debugf("Successfully reconciled targets for target group %s", tg.ID)
+	return nil
+}
+
+// updateTargets calls update with chunks of targets of at most maxTargetsPerCall. It returns the updated targets,
+// and the failures VPC Lattice reported. Failed targets are not retried right away, they are still missing from
+// or stale in the target group on the next reconcile, which the controller backs off for.
+// An error of update fails the remaining targets.
+func (s *defaultTargetsManager) updateTargets(
+	targets []*vpclattice.Target,
+	update func(chunk []*vpclattice.Target) ([]*vpclattice.Target, []*vpclattice.TargetFailure, error),
+) ([]*vpclattice.Target, []*vpclattice.TargetFailure, error) {
+	var updated []*vpclattice.Target
+	var failures []*vpclattice.TargetFailure
+	for start := 0; start < len(targets); start += maxTargetsPerCall {
+		end := start + maxTargetsPerCall
+		if end > len(targets) {
+			end = len(targets)
+		}
+		successful, unsuccessful, err := update(targets[start:end])
+		if err != nil {
+			return updated, nil, err
+		}
+		updated = append(updated, successful...)
+		failures = append(failures, unsuccessful...)
 	}
 
-	isTargetRegisteredUnsuccessful := len(resp.Unsuccessful) > 0
-	if isTargetRegisteredUnsuccessful {
-		s.log.Debugf("Failed to register targets for target group %s, will retry later", tg.ID)
-		return errors.New(LATTICE_RETRY)
+	for _, failure := range failures {
+		s.log.Debugf("Failed to update target %s:%d due to %s: %s",
+			aws.StringValue(failure.Id), aws.Int64Value(failure.Port),
+			aws.StringValue(failure.FailureCode), aws.StringValue(failure.FailureMessage))
 	}
+	return updated, failures, nil
+}
 
-	s.log.Debugf("Successfully registered targets for target group %s", tg.ID)
-	return nil
+// targetsTargetGroupName is the name the target group of targets is stored under
+func targetsTargetGroupName(spec *model.TargetsSpec) string {
+	if spec.IsLambdaFunction {
+		return latticestore.LambdaTargetGroupName(spec.Name, spec.Namespace)
+	}
+	return latticestore.TargetGroupName(spec.Name, spec.Namespace)
+}
+
+// targetKey identifies a target by its ID and port, Lambda function targets have no port
+func targetKey(id *string, port *int64) string {
+	if port == nil {
+		return aws.StringValue(id)
+	}
+	return fmt.Sprintf("%s:%d", aws.StringValue(id), aws.Int64Value(port))
+}
+
+func drainingTarget(id *string, port *int64) latticestore.Target {
+	return latticestore.Target{
+		TargetIP:   aws.StringValue(id),
+		TargetPort: aws.Int64Value(port),
+	}
 }
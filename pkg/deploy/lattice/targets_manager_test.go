@@ -3,8 +3,10 @@ package lattice
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -74,6 +76,48 @@ func Test_RegisterTargets_RegisterSuccessfully(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func Test_RegisterTargets_LambdaFunction(t *testing.T) {
+	functionArn := "arn:aws:lambda:us-west-2:123456789012:function:fn"
+	createInput := model.Targets{
+		Spec: model.TargetsSpec{
+			Name:             "fn",
+			Namespace:        "default",
+			RouteName:        "route",
+			TargetIPList:     []model.Target{{TargetIP: functionArn}},
+			IsLambdaFunction: true,
+		},
+	}
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	tgName := latticestore.LambdaTargetGroupName("fn", "default")
+	latticeDataStore.AddTargetGroup(tgName, "", "tg-arn", "tg-id", false, "route")
+	// the target group of a Service of the same name is stored apart
+	svcTGName := latticestore.TargetGroupName("fn", "default")
+	latticeDataStore.AddTargetGroup(svcTGName, "vpc-123456789", "svc-tg-arn", "svc-tg-id", false, "route")
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetSummary{}, nil)
+	mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...interface{}) (*vpclattice.RegisterTargetsOutput, error) {
+			assert.Equal(t, "tg-id", *input.TargetGroupIdentifier)
+			assert.Len(t, input.Targets, 1)
+			assert.Equal(t, functionArn, *input.Targets[0].Id)
+			// Lambda function targets have no port
+			assert.Nil(t, input.Targets[0].Port)
+			return &vpclattice.RegisterTargetsOutput{}, nil
+		})
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud, latticeDataStore)
+	err := targetsManager.Create(ctx, &createInput)
+
+	assert.Nil(t, err)
+}
+
 // Target group does not exist, should return Retry
 func Test_RegisterTargets_TGNotExist(t *testing.T) {
 	targetsSpec := model.TargetsSpec{
@@ -158,6 +202,47 @@ func Test_RegisterTargets_Registerfailed(t *testing.T) {
 	assert.Equal(t, err, errors.New("Register_Targets_Failed"))
 }
 
+func Test_RegisterTargets_DeregisterFailed(t *testing.T) {
+	port := int64(8080)
+	listTargetOutput := []*vpclattice.TargetSummary{
+		{Id: aws.String("10.0.0.1"), Port: &port, Status: aws.String(vpclattice.TargetStatusHealthy)},
+	}
+	planToRegister := model.Targets{
+		Spec: model.TargetsSpec{
+			Name:         "test",
+			TargetIPList: []model.Target{{TargetIP: "10.0.0.2", Port: port}},
+		},
+	}
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	tgName := latticestore.TargetGroupName("test", "")
+	latticeDataStore.AddTargetGroup(tgName, "vpc-123456789", "123456789", "123456789", false, "")
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	deregisterErr := errors.New("Deregister_Targets_Failed")
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetOutput, nil)
+	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).Return(nil, deregisterErr)
+	// the missing target is still registered
+	mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...interface{}) (*vpclattice.RegisterTargetsOutput, error) {
+			return &vpclattice.RegisterTargetsOutput{Successful: input.Targets}, nil
+		})
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud, latticeDataStore)
+	err := targetsManager.Create(ctx, &planToRegister)
+	assert.ErrorIs(t, err, deregisterErr)
+
+	// the stale target is not draining as long as it is not deregistered
+	tg, err := latticeDataStore.GetTargetGroup(tgName, "", false)
+	assert.Nil(t, err)
+	assert.Empty(t, tg.DrainingEndPoints)
+}
+
 // case4: register targets Unsuccessfully
 func Test_RegisterTargets_RegisterUnsuccessfully(t *testing.T) {
 	sId := "123.456.7.890"
@@ -239,7 +324,11 @@ func Test_RegisterTargets_RegisterUnsuccessfully(t *testing.T) {
 	err := targetsManager.Create(ctx, &planToRegister)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, err, errors.New(LATTICE_RETRY))
+	assert.True(t, errors.Is(err, RetryErr))
+	var targetsFailedErr *TargetsFailedError
+	assert.True(t, errors.As(err, &targetsFailedErr))
+	assert.Equal(t, unsuccessful, targetsFailedErr.Registration)
+	assert.Empty(t, targetsFailedErr.Deregistration)
 }
 
 func Test_RegisterTargets_NoTargets_NoCallRegisterTargets(t *testing.T) {
@@ -277,3 +366,210 @@ func Test_RegisterTargets_NoTargets_NoCallRegisterTargets(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func Test_RegisterTargets_TracksDrainingTargets(t *testing.T) {
+	port := int64(8080)
+	listTargetOutput := []*vpclattice.TargetSummary{
+		{Id: aws.String("10.0.0.1"), Port: &port, Status: aws.String(vpclattice.TargetStatusHealthy)},
+		{Id: aws.String("10.0.0.2"), Port: &port, Status: aws.String(vpclattice.TargetStatusHealthy)},
+		{Id: aws.String("10.0.0.3"), Port: &port, Status: aws.String(vpclattice.TargetStatusDraining)},
+	}
+	planToRegister := model.Targets{
+		Spec: model.TargetsSpec{
+			Name:         "test",
+			TargetIPList: []model.Target{{TargetIP: "10.0.0.1", Port: port}},
+		},
+	}
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	tgName := latticestore.TargetGroupName("test", "")
+	latticeDataStore.AddTargetGroup(tgName, "vpc-123456789", "123456789", "123456789", false, "")
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetOutput, nil)
+	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.DeregisterTargetsInput, opts ...interface{}) (*vpclattice.DeregisterTargetsOutput, error) {
+			// the draining target is not deregistered again
+			assert.Equal(t, []*vpclattice.Target{{Id: aws.String("10.0.0.2"), Port: &port}}, input.Targets)
+			return &vpclattice.DeregisterTargetsOutput{Successful: input.Targets}, nil
+		})
+	// the healthy target is already registered
+	mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).MaxTimes(0)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud, latticeDataStore)
+	err := targetsManager.Create(ctx, &planToRegister)
+	assert.Nil(t, err)
+
+	tg, err := latticeDataStore.GetTargetGroup(tgName, "", false)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []latticestore.Target{
+		{TargetIP: "10.0.0.2", TargetPort: port},
+		{TargetIP: "10.0.0.3", TargetPort: port},
+	}, tg.DrainingEndPoints)
+}
+
+func Test_RegisterTargets_OnlyMissingTargets(t *testing.T) {
+	port := int64(8080)
+	listTargetOutput := []*vpclattice.TargetSummary{
+		{Id: aws.String("10.0.0.1"), Port: &port, Status: aws.String(vpclattice.TargetStatusHealthy)},
+		{Id: aws.String("10.0.0.2"), Port: &port, Status: aws.String(vpclattice.TargetStatusDraining)},
+		{Id: aws.String("10.0.0.3"), Port: &port, Status: aws.String(vpclattice.TargetStatusHealthy)},
+	}
+	planToRegister := model.Targets{
+		Spec: model.TargetsSpec{
+			Name: "test",
+			TargetIPList: []model.Target{
+				{TargetIP: "10.0.0.1", Port: port},
+				{TargetIP: "10.0.0.2", Port: port},
+				{TargetIP: "10.0.0.4", Port: port},
+			},
+		},
+	}
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	tgName := latticestore.TargetGroupName("test", "")
+	latticeDataStore.AddTargetGroup(tgName, "vpc-123456789", "123456789", "123456789", false, "")
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetOutput, nil)
+	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.DeregisterTargetsInput, opts ...interface{}) (*vpclattice.DeregisterTargetsOutput, error) {
+			assert.Equal(t, []*vpclattice.Target{{Id: aws.String("10.0.0.3"), Port: &port}}, input.Targets)
+			return &vpclattice.DeregisterTargetsOutput{Successful: input.Targets}, nil
+		})
+	mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...interface{}) (*vpclattice.RegisterTargetsOutput, error) {
+			// the draining target is registered again, the healthy one is left alone
+			assert.Equal(t, []*vpclattice.Target{
+				{Id: aws.String("10.0.0.2"), Port: &port},
+				{Id: aws.String("10.0.0.4"), Port: &port},
+			}, input.Targets)
+			return &vpclattice.RegisterTargetsOutput{Successful: input.Targets}, nil
+		})
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud, latticeDataStore)
+	err := targetsManager.Create(ctx, &planToRegister)
+	assert.Nil(t, err)
+}
+
+func Test_RegisterTargets_Chunked(t *testing.T) {
+	port := int64(8080)
+	var listTargetOutput []*vpclattice.TargetSummary
+	var targetIPList []model.Target
+	for i := 0; i < 250; i++ {
+		listTargetOutput = append(listTargetOutput, &vpclattice.TargetSummary{
+			Id:     aws.String(fmt.Sprintf("10.0.1.%d", i)),
+			Port:   &port,
+			Status: aws.String(vpclattice.TargetStatusHealthy),
+		})
+		targetIPList = append(targetIPList, model.Target{TargetIP: fmt.Sprintf("10.0.2.%d", i), Port: port})
+	}
+	planToRegister := model.Targets{
+		Spec: model.TargetsSpec{
+			Name:         "test",
+			TargetIPList: targetIPList,
+		},
+	}
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	tgName := latticestore.TargetGroupName("test", "")
+	latticeDataStore.AddTargetGroup(tgName, "vpc-123456789", "123456789", "123456789", false, "")
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	var deregisterChunks, registerChunks []int
+	mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return(listTargetOutput, nil)
+	mockLattice.EXPECT().DeregisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.DeregisterTargetsInput, opts ...interface{}) (*vpclattice.DeregisterTargetsOutput, error) {
+			deregisterChunks = append(deregisterChunks, len(input.Targets))
+			return &vpclattice.DeregisterTargetsOutput{Successful: input.Targets}, nil
+		}).Times(3)
+	mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...interface{}) (*vpclattice.RegisterTargetsOutput, error) {
+			registerChunks = append(registerChunks, len(input.Targets))
+			return &vpclattice.RegisterTargetsOutput{Successful: input.Targets}, nil
+		}).Times(3)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud, latticeDataStore)
+	err := targetsManager.Create(ctx, &planToRegister)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{100, 100, 50}, deregisterChunks)
+	assert.Equal(t, []int{100, 100, 50}, registerChunks)
+
+	tg, err := latticeDataStore.GetTargetGroup(tgName, "", false)
+	assert.Nil(t, err)
+	assert.Len(t, tg.DrainingEndPoints, 250)
+}
+
+func Test_RegisterTargets_RetriesUnsuccessfulTargets(t *testing.T) {
+	port := int64(8080)
+	planToRegister := model.Targets{
+		Spec: model.TargetsSpec{
+			Name: "test",
+			TargetIPList: []model.Target{
+				{TargetIP: "10.0.0.1", Port: port},
+				{TargetIP: "10.0.0.2", Port: port},
+			},
+		},
+	}
+
+	latticeDataStore := latticestore.NewLatticeDataStore()
+	tgName := latticestore.TargetGroupName("test", "")
+	latticeDataStore.AddTargetGroup(tgName, "vpc-123456789", "123456789", "123456789", false, "")
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+	mockCloud := mocks_aws.NewMockCloud(c)
+	mockLattice := mocks.NewMockLattice(c)
+
+	gomock.InOrder(
+		mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetSummary{}, nil),
+		mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...interface{}) (*vpclattice.RegisterTargetsOutput, error) {
+				assert.Len(t, input.Targets, 2)
+				return &vpclattice.RegisterTargetsOutput{
+					Successful: input.Targets[:1],
+					Unsuccessful: []*vpclattice.TargetFailure{{
+						Id:          input.Targets[1].Id,
+						Port:        input.Targets[1].Port,
+						FailureCode: aws.String("ThrottlingException"),
+					}},
+				}, nil
+			}),
+		mockLattice.EXPECT().ListTargetsAsList(ctx, gomock.Any()).Return([]*vpclattice.TargetSummary{
+			{Id: aws.String("10.0.0.1"), Port: &port, Status: aws.String(vpclattice.TargetStatusHealthy)},
+		}, nil),
+		mockLattice.EXPECT().RegisterTargetsWithContext(ctx, gomock.Any()).DoAndReturn(
+			func(ctx context.Context, input *vpclattice.RegisterTargetsInput, opts ...interface{}) (*vpclattice.RegisterTargetsOutput, error) {
+				// only the unsuccessful target is registered again
+				assert.Equal(t, []*vpclattice.Target{{Id: aws.String("10.0.0.2"), Port: &port}}, input.Targets)
+				return &vpclattice.RegisterTargetsOutput{Successful: input.Targets}, nil
+			}),
+	)
+	mockCloud.EXPECT().Lattice().Return(mockLattice).AnyTimes()
+
+	targetsManager := NewTargetsManager(gwlog.FallbackLogger, mockCloud, latticeDataStore)
+
+	// the failed target is not retried right away, but on the next reconcile
+	err := targetsManager.Create(ctx, &planToRegister)
+	var targetsFailedErr *TargetsFailedError
+	assert.True(t, errors.As(err, &targetsFailedErr))
+	assert.Len(t, targetsFailedErr.Registration, 1)
+
+	err = targetsManager.Create(ctx, &planToRegister)
+	assert.Nil(t, err)
+}
@@ -48,10 +48,10 @@ func (t *targetsSynthesizer) SynthesizeTargets(ctx context.Context, resTargets [
 	for _, targets := range resTargets {
 		err := t.targetsManager.Create(ctx, targets)
 		if err != nil {
-			return fmt.Errorf("failed to synthesize targets due to %s", err)
+			return fmt.Errorf("failed to synthesize targets due to %w", err)
 		}
 
-		tgName := latticestore.TargetGroupName(targets.Spec.Name, targets.Spec.Namespace)
+		tgName := targetsTargetGroupName(&targets.Spec)
 		var targetList []latticestore.Target
 		for _, target := range targets.Spec.TargetIPList {
 			targetList = append(targetList, latticestore.Target{
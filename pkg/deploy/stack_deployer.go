@@ -2,6 +2,7 @@ package deploy
 
 import (
 	"context"
+	"errors"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 
@@ -196,6 +197,7 @@ func NewTargetsStackDeployer(
 	latticeDataStore *latticestore.LatticeDataStore,
 ) *latticeTargetsStackDeployer {
 	return &latticeTargetsStackDeployer{
+		log:              log,
 		k8sClient:        k8sClient,
 		targetsManager:   lattice.NewTargetsManager(log, cloud, latticeDataStore),
 		latticeDataStore: latticeDataStore,
@@ -212,8 +214,13 @@ func (d *latticeTargetsStackDeployer) Deploy(ctx context.Context, stack core.Sta
 		d.log.Errorf("Failed to list targets due to %s", err)
 	}
 
+	var failedErr error
 	for _, targets := range resTargets {
 		err := d.targetsManager.Create(ctx, targets)
+		var targetsFailedErr *lattice.TargetsFailedError
+		if errors.As(err, &targetsFailedErr) {
+			failedErr = err
+		}
 		if err == nil {
 			tgName := latticestore.TargetGroupName(targets.Spec.Name, targets.Spec.Namespace)
 
@@ -232,7 +239,7 @@ func (d *latticeTargetsStackDeployer) Deploy(ctx context.Context, stack core.Sta
 		}
 
 	}
-	return nil
+	return failedErr
 }
 
 type accessLogSubscriptionStackDeployer struct {
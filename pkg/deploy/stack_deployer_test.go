@@ -66,7 +66,9 @@ func Test_latticeServiceStackDeployer_createAllResources(t *testing.T) {
 	mockTargetGroupManager.EXPECT().Create(gomock.Any(), gomock.Any()).AnyTimes()
 	mockTargetsManager.EXPECT().Create(gomock.Any(), gomock.Any())
 	mockListenerManager.EXPECT().Create(gomock.Any(), gomock.Any())
-	mockRuleManager.EXPECT().Create(gomock.Any(), gomock.Any())
+	mockLatticeDataStore.AddListener("fake-rule", "default", 80, "HTTP", "fake-listener-arn", "fake-listener-id")
+	mockRuleManager.EXPECT().List(gomock.Any(), "fake-service", "fake-listener-id")
+	mockRuleManager.EXPECT().Create(gomock.Any(), gomock.Any(), "fake-service", "fake-listener-id", gomock.Any())
 	mockDnsManager.EXPECT().Create(gomock.Any(), gomock.Any())
 
 	deployer := &latticeServiceStackDeployer{
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
@@ -15,7 +17,7 @@ import (
 )
 
 type AccessLogSubscriptionModelBuilder interface {
-	Build(ctx context.Context, alp *anv1alpha1.AccessLogPolicy) (core.Stack, *model.AccessLogSubscription, error)
+	Build(ctx context.Context, alp *anv1alpha1.AccessLogPolicy) (core.Stack, []*model.AccessLogSubscription, error)
 }
 
 type accessLogSubscriptionModelBuilder struct {
@@ -30,14 +32,20 @@ func NewAccessLogSubscriptionModelBuilder(log gwlog.Logger, client client.Client
 	}
 }
 
+// Build returns the Access Log Subscriptions of the destinations of accessLogPolicy, for its targetRef, or for the
+// services of the routes attached to its Gateway with the Services scope. For each of them, the ones recorded in the
+// status of the policy are updated to the destinations with the same ARN, or else of the same type, the others are
+// created, and the recorded ones left without a destination or a source are deleted, as are all of them when the
+// policy is deleted.
 func (b *accessLogSubscriptionModelBuilder) Build(
 	ctx context.Context,
 	accessLogPolicy *anv1alpha1.AccessLogPolicy,
-) (core.Stack, *model.AccessLogSubscription, error) {
+) (core.Stack, []*model.AccessLogSubscription, error) {
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(accessLogPolicy)))
 
 	task := accessLogSubscriptionModelBuildTask{
 		log:             b.log,
+		client:          b.client,
 		stack:           stack,
 		accessLogPolicy: accessLogPolicy,
 	}
@@ -46,64 +54,205 @@ func (b *accessLogSubscriptionModelBuilder) Build(
 		return nil, nil, err
 	}
 
-	return task.stack, task.accessLogSubscription, nil
+	return task.stack, task.accessLogSubscriptions, nil
 }
 
 type accessLogSubscriptionModelBuildTask struct {
-	log                   gwlog.Logger
-	stack                 core.Stack
-	accessLogPolicy       *anv1alpha1.AccessLogPolicy
-	accessLogSubscription *model.AccessLogSubscription
+	log                    gwlog.Logger
+	client                 client.Client
+	stack                  core.Stack
+	accessLogPolicy        *anv1alpha1.AccessLogPolicy
+	accessLogSubscriptions []*model.AccessLogSubscription
+}
+
+// accessLogSource is the VPC Lattice resource of Access Log Subscriptions
+type accessLogSource struct {
+	sourceType model.SourceType
+	sourceName string
+}
+
+// recordedAccessLogSubscription is an Access Log Subscription recorded in the status of the policy, whose
+// serviceName is empty when its source is the targetRef
+type recordedAccessLogSubscription struct {
+	serviceName              string
+	destinationArn           string
+	accessLogSubscriptionArn string
 }
 
 func (t *accessLogSubscriptionModelBuildTask) run(ctx context.Context) error {
-	sourceType := model.ServiceSourceType
-	if t.accessLogPolicy.Spec.TargetRef.Kind == "Gateway" {
-		sourceType = model.ServiceNetworkSourceType
+	targetRef := t.accessLogPolicy.Spec.TargetRef
+	targetSource := accessLogSource{sourceType: model.ServiceSourceType}
+	if targetRef.Kind == "Gateway" {
+		targetSource.sourceType = model.ServiceNetworkSourceType
 	}
-
-	sourceName, err := utils.TargetRefToLatticeResourceName(t.accessLogPolicy.Spec.TargetRef, t.accessLogPolicy.Namespace)
+	var err error
+	targetSource.sourceName, err = utils.TargetRefToLatticeResourceName(targetRef, t.accessLogPolicy.Namespace)
 	if err != nil {
 		return err
 	}
 
-	destinationArn := t.accessLogPolicy.Spec.DestinationArn
-	if destinationArn == nil {
-		return fmt.Errorf("access log policy's destinationArn cannot be nil")
+	recorded := t.recordedAccessLogSubscriptions()
+	if t.accessLogPolicy.DeletionTimestamp == nil {
+		destinationArns := t.accessLogPolicy.GetDestinationArns()
+		if len(destinationArns) == 0 {
+			return fmt.Errorf("access log policy has no destinationArn")
+		}
+		for _, destinationArn := range destinationArns {
+			if _, err := model.GetAccessLogDestinationType(destinationArn); err != nil {
+				return err
+			}
+		}
+
+		if targetRef.Kind == "Gateway" && t.accessLogPolicy.GetScope() == anv1alpha1.AccessLogPolicyScopeServices {
+			serviceNames, err := t.attachedServiceNames(ctx)
+			if err != nil {
+				return err
+			}
+			for _, serviceName := range serviceNames {
+				source := accessLogSource{sourceType: model.ServiceSourceType, sourceName: serviceName}
+				if recorded, err = t.buildSourceAccessLogSubscriptions(source, serviceName, destinationArns, recorded); err != nil {
+					return err
+				}
+			}
+		} else {
+			if recorded, err = t.buildSourceAccessLogSubscriptions(targetSource, "", destinationArns, recorded); err != nil {
+				return err
+			}
+		}
 	}
 
-	var eventType = core.CreateEvent
-	if t.accessLogPolicy.DeletionTimestamp != nil {
-		eventType = core.DeleteEvent
-	} else if _, ok := t.accessLogPolicy.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]; ok {
-		eventType = core.UpdateEvent
+	for _, als := range recorded {
+		source := targetSource
+		if als.serviceName != "" {
+			source = accessLogSource{sourceType: model.ServiceSourceType, sourceName: als.serviceName}
+		}
+		status := &model.AccessLogSubscriptionStatus{Arn: als.accessLogSubscriptionArn}
+		if err := t.addAccessLogSubscription(t.newSpec(source, als.destinationArn, core.DeleteEvent), status); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	var status *model.AccessLogSubscriptionStatus
-	if eventType != core.CreateEvent {
-		value, exists := t.accessLogPolicy.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]
-		if exists {
-			status = &model.AccessLogSubscriptionStatus{
-				Arn: value,
+// buildSourceAccessLogSubscriptions adds the Access Log Subscriptions of source for destinationArns, updating the
+// ones recorded for serviceName, and returns the recorded ones left
+func (t *accessLogSubscriptionModelBuildTask) buildSourceAccessLogSubscriptions(
+	source accessLogSource,
+	serviceName string,
+	destinationArns []string,
+	recorded []recordedAccessLogSubscription,
+) ([]recordedAccessLogSubscription, error) {
+	for _, destinationArn := range destinationArns {
+		destinationType, err := model.GetAccessLogDestinationType(destinationArn)
+		if err != nil {
+			return nil, err
+		}
+		i := slices.IndexFunc(recorded, func(als recordedAccessLogSubscription) bool {
+			return als.serviceName == serviceName && als.destinationArn == destinationArn
+		})
+		if i < 0 {
+			i = slices.IndexFunc(recorded, func(als recordedAccessLogSubscription) bool {
+				recordedType, err := model.GetAccessLogDestinationType(als.destinationArn)
+				return als.serviceName == serviceName && err == nil && recordedType == destinationType
+			})
+		}
+		if i < 0 {
+			if err := t.addAccessLogSubscription(t.newSpec(source, destinationArn, core.CreateEvent), nil); err != nil {
+				return nil, err
 			}
-		} else {
-			t.log.Debugf("access log policy is missing %s annotation during %s event",
-				anv1alpha1.AccessLogSubscriptionAnnotationKey, eventType)
+			continue
 		}
+		status := &model.AccessLogSubscriptionStatus{Arn: recorded[i].accessLogSubscriptionArn}
+		if err := t.addAccessLogSubscription(t.newSpec(source, destinationArn, core.UpdateEvent), status); err != nil {
+			return nil, err
+		}
+		recorded = slices.Delete(recorded, i, i+1)
 	}
+	return recorded, nil
+}
 
-	alsSpec := model.AccessLogSubscriptionSpec{
-		SourceType:        sourceType,
-		SourceName:        sourceName,
-		DestinationArn:    *destinationArn,
+func (t *accessLogSubscriptionModelBuildTask) newSpec(
+	source accessLogSource,
+	destinationArn string,
+	eventType core.EventType,
+) model.AccessLogSubscriptionSpec {
+	return model.AccessLogSubscriptionSpec{
+		SourceType:        source.sourceType,
+		SourceName:        source.sourceName,
+		DestinationArn:    destinationArn,
 		ALPNamespacedName: t.accessLogPolicy.GetNamespacedName(),
 		EventType:         eventType,
 	}
-	t.accessLogSubscription = model.NewAccessLogSubscription(t.stack, alsSpec, status)
-	err = t.stack.AddResource(t.accessLogSubscription)
+}
+
+// attachedServiceNames returns the names of the VPC Lattice services of the routes attached to the Gateway of the
+// policy, the routes which are not being deleted and which the Gateway accepted, once their service was deployed
+func (t *accessLogSubscriptionModelBuildTask) attachedServiceNames(ctx context.Context) ([]string, error) {
+	targetRef := t.accessLogPolicy.Spec.TargetRef
+	gwName := types.NamespacedName{Namespace: t.accessLogPolicy.Namespace, Name: string(targetRef.Name)}
+	if targetRef.Namespace != nil {
+		gwName.Namespace = string(*targetRef.Namespace)
+	}
+
+	routes, err := core.ListAllRoutes(ctx, t.client)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	var serviceNames []string
+	for _, route := range routes {
+		if route.DeletionTimestamp().IsZero() && IsRouteAcceptedByGateway(route, gwName) {
+			serviceNames = append(serviceNames, utils.LatticeServiceName(route.Name(), route.Namespace()))
+		}
+	}
+	slices.Sort(serviceNames)
+	return serviceNames, nil
+}
+
+// recordedAccessLogSubscriptions returns the Access Log Subscriptions recorded in the status of the policy, or the
+// one of its annotation, for the destinationArn of policies annotated by earlier versions
+func (t *accessLogSubscriptionModelBuildTask) recordedAccessLogSubscriptions() []recordedAccessLogSubscription {
+	var recorded []recordedAccessLogSubscription
+	for _, destination := range t.accessLogPolicy.Status.Destinations {
+		if destination.AccessLogSubscriptionArn != "" {
+			recorded = append(recorded, recordedAccessLogSubscription{
+				destinationArn:           destination.DestinationArn,
+				accessLogSubscriptionArn: destination.AccessLogSubscriptionArn,
+			})
+		}
+		for _, service := range destination.Services {
+			recorded = append(recorded, recordedAccessLogSubscription{
+				serviceName:              service.ServiceName,
+				destinationArn:           destination.DestinationArn,
+				accessLogSubscriptionArn: service.AccessLogSubscriptionArn,
+			})
+		}
+	}
+	if len(t.accessLogPolicy.Status.Destinations) > 0 {
+		return recorded
 	}
+	alsArn, ok := t.accessLogPolicy.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if t.accessLogPolicy.Spec.DestinationArn == nil {
+		t.log.Debugf("access log policy %s has the %s annotation but no destinationArn",
+			t.accessLogPolicy.GetNamespacedName(), anv1alpha1.AccessLogSubscriptionAnnotationKey)
+		return nil
+	}
+	return []recordedAccessLogSubscription{{
+		destinationArn:           *t.accessLogPolicy.Spec.DestinationArn,
+		accessLogSubscriptionArn: alsArn,
+	}}
+}
 
+func (t *accessLogSubscriptionModelBuildTask) addAccessLogSubscription(
+	spec model.AccessLogSubscriptionSpec,
+	status *model.AccessLogSubscriptionStatus,
+) error {
+	als := model.NewAccessLogSubscription(t.stack, spec, status)
+	if err := t.stack.AddResource(als); err != nil {
+		return err
+	}
+	t.accessLogSubscriptions = append(t.accessLogSubscriptions, als)
 	return nil
 }
@@ -14,10 +14,13 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils"
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 )
 
@@ -280,12 +283,197 @@ func Test_BuildAccessLogSubscription(t *testing.T) {
 
 	for _, tt := range tests {
 		fmt.Printf("Testing: %s\n", tt.description)
-		_, als, err := modelBuilder.Build(ctx, tt.input)
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, tt.input)
+		assert.Equal(t, tt.expectedError, err, tt.description)
+		assert.Len(t, accessLogSubscriptions, 1, tt.description)
+		als := accessLogSubscriptions[0]
 		if tt.onlyCompareSpecs {
 			assert.Equal(t, tt.expectedOutput.Spec, als.Spec, tt.description)
 		} else {
 			assert.Equal(t, tt.expectedOutput, als, tt.description)
 		}
-		assert.Equal(t, tt.expectedError, err, tt.description)
 	}
 }
+
+func Test_BuildAccessLogSubscriptionDestinations(t *testing.T) {
+	ctx := context.TODO()
+	scheme := runtime.NewScheme()
+	clientgoscheme.AddToScheme(scheme)
+	client := testclient.NewClientBuilder().WithScheme(scheme).Build()
+	modelBuilder := NewAccessLogSubscriptionModelBuilder(gwlog.FallbackLogger, client)
+
+	const (
+		logGroupArn       = "arn:aws:logs:us-west-2:123456789012:log-group:test"
+		otherLogGroupArn  = "arn:aws:logs:us-west-2:123456789012:log-group:other:*"
+		deliveryStreamArn = "arn:aws:firehose:us-west-2:123456789012:deliverystream/test"
+	)
+	newPolicy := func(destinationArns []string, destinations []anv1alpha1.AccessLogDestinationStatus) *anv1alpha1.AccessLogPolicy {
+		return &anv1alpha1.AccessLogPolicy{
+			ObjectMeta: apimachineryv1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: anv1alpha1.AccessLogPolicySpec{
+				DestinationArns: destinationArns,
+				TargetRef: &gwv1alpha2.PolicyTargetReference{
+					Kind: gatewayKind,
+					Name: name,
+				},
+			},
+			Status: anv1alpha1.AccessLogPolicyStatus{
+				Destinations: destinations,
+			},
+		}
+	}
+	recorded := []anv1alpha1.AccessLogDestinationStatus{
+		{DestinationArn: s3DestinationArn, AccessLogSubscriptionArn: "als-s3"},
+		{DestinationArn: logGroupArn, AccessLogSubscriptionArn: "als-logs"},
+		{DestinationArn: deliveryStreamArn, AccessLogSubscriptionArn: "als-firehose"},
+	}
+	summarize := func(accessLogSubscriptions []*lattice.AccessLogSubscription) []string {
+		var summaries []string
+		for _, als := range accessLogSubscriptions {
+			summary := fmt.Sprintf("%s %s", als.Spec.EventType, als.Spec.DestinationArn)
+			if als.Status != nil {
+				summary += " " + als.Status.Arn
+			}
+			summaries = append(summaries, summary)
+		}
+		return summaries
+	}
+
+	t.Run("destinations without subscriptions are created", func(t *testing.T) {
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, newPolicy([]string{s3DestinationArn, logGroupArn}, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			fmt.Sprintf("%s %s", core.CreateEvent, s3DestinationArn),
+			fmt.Sprintf("%s %s", core.CreateEvent, logGroupArn),
+		}, summarize(accessLogSubscriptions))
+	})
+
+	t.Run("subscriptions are updated to destinations of the same type and the others deleted", func(t *testing.T) {
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, newPolicy([]string{s3DestinationArn, otherLogGroupArn}, recorded))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			fmt.Sprintf("%s %s als-s3", core.UpdateEvent, s3DestinationArn),
+			fmt.Sprintf("%s %s als-logs", core.UpdateEvent, otherLogGroupArn),
+			fmt.Sprintf("%s %s als-firehose", core.DeleteEvent, deliveryStreamArn),
+		}, summarize(accessLogSubscriptions))
+	})
+
+	t.Run("subscriptions of deleted policy are deleted", func(t *testing.T) {
+		alp := newPolicy([]string{s3DestinationArn}, recorded)
+		alp.DeletionTimestamp = &apimachineryv1.Time{}
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, alp)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			fmt.Sprintf("%s %s als-s3", core.DeleteEvent, s3DestinationArn),
+			fmt.Sprintf("%s %s als-logs", core.DeleteEvent, logGroupArn),
+			fmt.Sprintf("%s %s als-firehose", core.DeleteEvent, deliveryStreamArn),
+		}, summarize(accessLogSubscriptions))
+	})
+
+	t.Run("destinationArn and destinationArns are combined", func(t *testing.T) {
+		alp := newPolicy([]string{s3DestinationArn, deliveryStreamArn}, nil)
+		alp.Spec.DestinationArn = aws.String(s3DestinationArn)
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, alp)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			fmt.Sprintf("%s %s", core.CreateEvent, s3DestinationArn),
+			fmt.Sprintf("%s %s", core.CreateEvent, deliveryStreamArn),
+		}, summarize(accessLogSubscriptions))
+	})
+
+	t.Run("invalid destination", func(t *testing.T) {
+		_, _, err := modelBuilder.Build(ctx, newPolicy([]string{"arn:aws:s3:us-west-2:123456789012:test"}, nil))
+		assert.Error(t, err)
+	})
+}
+
+func Test_BuildAccessLogSubscriptionServicesScope(t *testing.T) {
+	ctx := context.TODO()
+	newRoute := func(routeName string, gwName string, accepted bool) *gwv1beta1.HTTPRoute {
+		parentRef := gwv1beta1.ParentReference{Name: gwv1beta1.ObjectName(gwName)}
+		route := &gwv1beta1.HTTPRoute{
+			ObjectMeta: apimachineryv1.ObjectMeta{Name: routeName, Namespace: namespace},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+					ParentRefs: []gwv1beta1.ParentReference{parentRef},
+				},
+			},
+		}
+		if accepted {
+			route.Status.Parents = []gwv1beta1.RouteParentStatus{{
+				ParentRef:      parentRef,
+				ControllerName: config.LatticeGatewayControllerName,
+				Conditions: []apimachineryv1.Condition{{
+					Type:   string(gwv1beta1.RouteConditionAccepted),
+					Status: apimachineryv1.ConditionTrue,
+					Reason: string(gwv1beta1.RouteReasonAccepted),
+				}},
+			}}
+		}
+		return route
+	}
+	client := newTargetGroupPolicyTestClient(
+		newRoute("attached", name, true),
+		newRoute("other-gateway", "other", true),
+		newRoute("not-accepted", name, false),
+	)
+	modelBuilder := NewAccessLogSubscriptionModelBuilder(gwlog.FallbackLogger, client)
+
+	attachedServiceName := utils.LatticeServiceName("attached", namespace)
+	scope := anv1alpha1.AccessLogPolicyScopeServices
+	alp := &anv1alpha1.AccessLogPolicy{
+		ObjectMeta: apimachineryv1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+		Spec: anv1alpha1.AccessLogPolicySpec{
+			DestinationArn: aws.String(s3DestinationArn),
+			TargetRef: &gwv1alpha2.PolicyTargetReference{
+				Kind: gatewayKind,
+				Name: name,
+			},
+			Scope: &scope,
+		},
+	}
+	summarize := func(accessLogSubscriptions []*lattice.AccessLogSubscription) []string {
+		var summaries []string
+		for _, als := range accessLogSubscriptions {
+			summary := fmt.Sprintf("%s %s %s", als.Spec.EventType, als.Spec.SourceType, als.Spec.SourceName)
+			if als.Status != nil {
+				summary += " " + als.Status.Arn
+			}
+			summaries = append(summaries, summary)
+		}
+		return summaries
+	}
+
+	t.Run("services of attached routes are subscribed", func(t *testing.T) {
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, alp)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			fmt.Sprintf("%s %s %s", core.CreateEvent, lattice.ServiceSourceType, attachedServiceName),
+		}, summarize(accessLogSubscriptions))
+	})
+
+	t.Run("subscriptions of detached services and of the service network are deleted", func(t *testing.T) {
+		alp := alp.DeepCopy()
+		alp.Status.Destinations = []anv1alpha1.AccessLogDestinationStatus{{
+			DestinationArn:           s3DestinationArn,
+			AccessLogSubscriptionArn: "als-sn",
+			Services: []anv1alpha1.AccessLogServiceStatus{
+				{ServiceName: attachedServiceName, AccessLogSubscriptionArn: "als-attached"},
+				{ServiceName: "detached", AccessLogSubscriptionArn: "als-detached"},
+			},
+		}}
+		_, accessLogSubscriptions, err := modelBuilder.Build(ctx, alp)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			fmt.Sprintf("%s %s %s als-attached", core.UpdateEvent, lattice.ServiceSourceType, attachedServiceName),
+			fmt.Sprintf("%s %s %s als-sn", core.DeleteEvent, lattice.ServiceNetworkSourceType, name),
+			fmt.Sprintf("%s %s detached als-detached", core.DeleteEvent, lattice.ServiceSourceType),
+		}, summarize(accessLogSubscriptions))
+	})
+}
@@ -0,0 +1,92 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+// IsLambdaFunctionBackendRef tells whether backendRef refers to a LambdaFunction instead of a Service or ServiceImport
+func IsLambdaFunctionBackendRef(backendRef core.BackendRef) bool {
+	return backendRef.Group() != nil && string(*backendRef.Group()) == anv1alpha1.GroupName &&
+		backendRef.Kind() != nil && string(*backendRef.Kind()) == anv1alpha1.LambdaFunctionKind
+}
+
+func (t *latticeServiceModelBuildTask) getLambdaFunction(
+	ctx context.Context,
+	backendRef core.BackendRef,
+	namespace string,
+) (*anv1alpha1.LambdaFunction, error) {
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      string(backendRef.Name()),
+	}
+	lambdaFunction := &anv1alpha1.LambdaFunction{}
+	if err := t.client.Get(ctx, key, lambdaFunction); err != nil {
+		return nil, fmt.Errorf("failed to get LambdaFunction %s, %w", key, err)
+	}
+	return lambdaFunction, nil
+}
+
+// buildLambdaTargetGroupSpec builds the spec of a LAMBDA target group, which has no port, protocol or VPC,
+// nor health checks since VPC Lattice invokes the function directly
+func (t *latticeServiceModelBuildTask) buildLambdaTargetGroupSpec(
+	ctx context.Context,
+	backendRef core.BackendRef,
+	namespace string,
+) (model.TargetGroupSpec, error) {
+	isDeleted := !t.route.DeletionTimestamp().IsZero()
+
+	if !isDeleted {
+		if _, ok := t.route.(*core.HTTPRoute); !ok {
+			return model.TargetGroupSpec{}, fmt.Errorf("%s backendRefs are only supported by HTTPRoute, not by %s %s-%s",
+				anv1alpha1.LambdaFunctionKind, core.RouteKind(t.route), t.route.Name(), t.route.Namespace())
+		}
+
+		// Return error for creation request only,
+		// the target group of a deleted route is deleted whether the LambdaFunction exists or not
+		if _, err := t.getLambdaFunction(ctx, backendRef, namespace); err != nil {
+			return model.TargetGroupSpec{}, err
+		}
+	}
+
+	return model.TargetGroupSpec{
+		Name: latticestore.LambdaTargetGroupName(string(backendRef.Name()), namespace),
+		Type: model.TargetGroupTypeLambda,
+		Config: model.TargetGroupConfig{
+			K8SServiceName:        string(backendRef.Name()),
+			K8SServiceNamespace:   namespace,
+			K8SHTTPRouteName:      t.route.Name(),
+			K8SHTTPRouteNamespace: t.route.Namespace(),
+		},
+		IsDeleted: isDeleted,
+	}, nil
+}
+
+// buildLambdaTargets registers the function of the LambdaFunction as the only target of its target group
+func (t *latticeServiceModelBuildTask) buildLambdaTargets(
+	ctx context.Context,
+	backendRef core.BackendRef,
+	namespace string,
+) error {
+	lambdaFunction, err := t.getLambdaFunction(ctx, backendRef, namespace)
+	if err != nil {
+		return err
+	}
+
+	tgName := latticestore.LambdaTargetGroupName(lambdaFunction.Name, namespace)
+	model.NewTargets(t.stack, tgName, model.TargetsSpec{
+		Name:             lambdaFunction.Name,
+		Namespace:        namespace,
+		RouteName:        t.route.Name(),
+		TargetIPList:     []model.Target{{TargetIP: lambdaFunction.Spec.FunctionArn}},
+		IsLambdaFunction: true,
+	})
+	return nil
+}
@@ -0,0 +1,182 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func lambdaFunctionBackendRef(name string) gwv1beta1.BackendObjectReference {
+	return gwv1beta1.BackendObjectReference{
+		Group: (*gwv1beta1.Group)(pointer.String(anv1alpha1.GroupName)),
+		Kind:  (*gwv1beta1.Kind)(pointer.String(anv1alpha1.LambdaFunctionKind)),
+		Name:  gwv1beta1.ObjectName(name),
+	}
+}
+
+func newLambdaRoute(deleted bool) core.Route {
+	route := gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route",
+			Namespace: "default",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{{
+				BackendRefs: []gwv1beta1.HTTPBackendRef{{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: lambdaFunctionBackendRef("fn"),
+						Weight:                 pointer.Int32(10),
+					},
+				}},
+			}},
+		},
+	}
+	if deleted {
+		now := metav1.Now()
+		route.DeletionTimestamp = &now
+		route.Finalizers = []string{"gateway.k8s.aws/resources"}
+	}
+	return core.NewHTTPRoute(route)
+}
+
+func Test_IsLambdaFunctionBackendRef(t *testing.T) {
+	route := newCrossNamespaceRoute(
+		lambdaFunctionBackendRef("fn"),
+		backendObjectRef("Service", "svc", ""),
+		gwv1beta1.BackendObjectReference{
+			Kind: (*gwv1beta1.Kind)(pointer.String(anv1alpha1.LambdaFunctionKind)),
+			Name: "fn",
+		},
+	)
+	backendRefs := route.Spec().Rules()[0].BackendRefs()
+	assert.True(t, IsLambdaFunctionBackendRef(backendRefs[0]))
+	assert.False(t, IsLambdaFunctionBackendRef(backendRefs[1]))
+	assert.False(t, IsLambdaFunctionBackendRef(backendRefs[2]), "kind without group is not a LambdaFunction")
+}
+
+func Test_LambdaFunctionBackendRefs(t *testing.T) {
+	functionArn := "arn:aws:lambda:us-west-2:123456789012:function:fn"
+	tgName := latticestore.LambdaTargetGroupName("fn", "default")
+
+	tests := []struct {
+		name           string
+		route          core.Route
+		lambdaExists   bool
+		wantErr        bool
+		wantIsDeleted  bool
+		wantTargetARNs []string
+	}{
+		{
+			name:           "LAMBDA target group with the function as target",
+			route:          newLambdaRoute(false),
+			lambdaExists:   true,
+			wantTargetARNs: []string{functionArn},
+		},
+		{
+			name:    "LambdaFunction does not exist",
+			route:   newLambdaRoute(false),
+			wantErr: true,
+		},
+		{
+			name:          "deleted route deletes the target group without the LambdaFunction",
+			route:         newLambdaRoute(true),
+			wantIsDeleted: true,
+		},
+		{
+			name: "LambdaFunction backendRefs are not supported by GRPCRoute",
+			route: core.NewGRPCRoute(gwv1alpha2.GRPCRoute{
+				ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "default"},
+				Spec: gwv1alpha2.GRPCRouteSpec{
+					Rules: []gwv1alpha2.GRPCRouteRule{{
+						BackendRefs: []gwv1alpha2.GRPCBackendRef{{
+							BackendRef: gwv1beta1.BackendRef{BackendObjectReference: lambdaFunctionBackendRef("fn")},
+						}},
+					}},
+				},
+			}),
+			lambdaExists: true,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			anv1alpha1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			if tt.lambdaExists {
+				assert.NoError(t, k8sClient.Create(ctx, &anv1alpha1.LambdaFunction{
+					ObjectMeta: metav1.ObjectMeta{Name: "fn", Namespace: "default"},
+					Spec:       anv1alpha1.LambdaFunctionSpec{FunctionArn: functionArn},
+				}))
+			}
+
+			stack := core.NewDefaultStack(core.StackID{Name: "route", Namespace: "default"})
+			task := &latticeServiceModelBuildTask{
+				log:       gwlog.FallbackLogger,
+				client:    k8sClient,
+				route:     tt.route,
+				stack:     stack,
+				datastore: latticestore.NewLatticeDataStore(),
+				tgByResID: make(map[string]*model.TargetGroup),
+			}
+
+			err := task.buildTargetGroupsForRoute(ctx, k8sClient)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			var tgs []*model.TargetGroup
+			stack.ListResources(&tgs)
+			assert.Len(t, tgs, 1)
+			assert.Equal(t, tgName, tgs[0].Spec.Name)
+			assert.Equal(t, model.TargetGroupTypeLambda, tgs[0].Spec.Type)
+			assert.Equal(t, "route", tgs[0].Spec.Config.K8SHTTPRouteName)
+			assert.Equal(t, tt.wantIsDeleted, tgs[0].Spec.IsDeleted)
+
+			dsTG, err := task.datastore.GetTargetGroup(tgName, "route", false)
+			assert.NoError(t, err)
+			assert.Equal(t, !tt.wantIsDeleted, dsTG.ByBackendRef)
+
+			if tt.wantIsDeleted {
+				return
+			}
+
+			assert.NoError(t, task.buildTargetsForRoute(ctx))
+			var targets []*model.Targets
+			stack.ListResources(&targets)
+			assert.Len(t, targets, 1)
+			var targetARNs []string
+			for _, target := range targets[0].Spec.TargetIPList {
+				targetARNs = append(targetARNs, target.TargetIP)
+				assert.Equal(t, int64(0), target.Port)
+			}
+			assert.Equal(t, tt.wantTargetARNs, targetARNs)
+			assert.Equal(t, "route", targets[0].Spec.RouteName)
+			assert.True(t, targets[0].Spec.IsLambdaFunction)
+
+			ruleTGs := task.getTargetGroupsForRuleAction(tt.route.Spec().Rules()[0])
+			assert.Equal(t, []*model.RuleTargetGroup{
+				{Name: "fn", Namespace: "default", RouteName: "route", IsLambdaFunction: true, Weight: 10},
+			}, ruleTGs)
+		})
+	}
+}
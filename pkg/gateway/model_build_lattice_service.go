@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -50,6 +52,11 @@ func (b *LatticeServiceModelBuilder) Build(
 ) (core.Stack, *model.Service, error) {
 	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
 
+	route, err := applyRolloutWeights(ctx, b.client, route)
+	if err != nil {
+		return stack, nil, err
+	}
+
 	task := &latticeServiceModelBuildTask{
 		log:       b.log,
 		route:     route,
@@ -57,6 +64,9 @@ func (b *LatticeServiceModelBuilder) Build(
 		client:    b.client,
 		tgByResID: make(map[string]*model.TargetGroup),
 		datastore: b.datastore,
+		cloud:     b.cloud,
+
+		latticeTargetGroupIDs: make(map[types.NamespacedName]string),
 	}
 
 	if err := task.run(ctx); err != nil {
@@ -76,6 +86,15 @@ func (t *latticeServiceModelBuildTask) buildModel(ctx context.Context) error {
 		return fmt.Errorf("failed to build lattice service due to %w", err)
 	}
 
+	if t.route.DeletionTimestamp().IsZero() {
+		// target groups of backendRefs which are no longer permitted are still cleaned up on delete
+		unpermitted, err := GetUnpermittedBackendRefs(ctx, t.client, t.route)
+		if err != nil {
+			return err
+		}
+		t.unpermittedBackendRefs = unpermitted
+	}
+
 	if err := t.buildTargetGroupsForRoute(ctx, t.client); err != nil {
 		return fmt.Errorf("failed to build target group due to %w", err)
 	}
@@ -102,8 +121,11 @@ func (t *latticeServiceModelBuildTask) buildModel(ctx context.Context) error {
 
 func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context) error {
 	routeType := core.HttpRouteType
-	if _, ok := t.route.(*core.GRPCRoute); ok {
+	switch t.route.(type) {
+	case *core.GRPCRoute:
 		routeType = core.GrpcRouteType
+	case *core.TLSRoute:
+		routeType = core.TlsRouteType
 	}
 
 	spec := model.ServiceSpec{
@@ -112,8 +134,15 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 		RouteType: routeType,
 	}
 
-	for _, parentRef := range t.route.Spec().ParentRefs() {
-		spec.ServiceNetworkNames = append(spec.ServiceNetworkNames, string(parentRef.Name))
+	parents, err := GetRouteParents(ctx, t.client, t.route)
+	if err != nil {
+		return err
+	}
+	for _, parent := range parents {
+		// the service is associated with the service network of every gateway accepting the route
+		if parent.Accepted() && !slices.Contains(spec.ServiceNetworkNames, parent.Gateway.Name) {
+			spec.ServiceNetworkNames = append(spec.ServiceNetworkNames, parent.Gateway.Name)
+		}
 	}
 	defaultGateway, err := config.GetClusterLocalGateway()
 	if err == nil {
@@ -126,6 +155,18 @@ func (t *latticeServiceModelBuildTask) buildLatticeService(ctx context.Context)
 
 		t.log.Infof("Setting customer-domain-name: %s for route %s-%s",
 			spec.CustomerDomainName, t.route.Name(), t.route.Namespace())
+
+		// lattice allows a single custom domain name per service, the other hostnames resolve
+		// to the service through DNS records only
+		for _, hostname := range t.route.Spec().Hostnames()[1:] {
+			if string(hostname) != spec.CustomerDomainName && !slices.Contains(spec.AdditionalDomainNames, string(hostname)) {
+				spec.AdditionalDomainNames = append(spec.AdditionalDomainNames, string(hostname))
+			}
+		}
+	} else if routeType == core.TlsRouteType && t.route.DeletionTimestamp().IsZero() {
+		// VPC Lattice routes TLS passthrough connections by their SNI, which has to be the custom domain name
+		return fmt.Errorf("TLSRoute %s-%s has no hostname, VPC Lattice requires a custom domain name for TLS passthrough",
+			t.route.Name(), t.route.Namespace())
 	} else {
 		t.log.Infof("No custom-domain-name for route %s-%s",
 			t.route.Name(), t.route.Namespace())
@@ -156,4 +197,13 @@ type latticeServiceModelBuildTask struct {
 	stack           core.Stack
 	datastore       *latticestore.LatticeDataStore
 	cloud           pkg_aws.Cloud
+
+	// cross-namespace backendRefs no ReferenceGrant permits, which get no target group
+	unpermittedBackendRefs []core.BackendRef
+	// IDs of the existing target groups of LatticeTargetGroup backendRefs, by LatticeTargetGroup
+	latticeTargetGroupIDs map[types.NamespacedName]string
+}
+
+func (t *latticeServiceModelBuildTask) isBackendRefPermitted(backendRef core.BackendRef) bool {
+	return !slices.ContainsFunc(t.unpermittedBackendRefs, backendRef.Equals)
 }
@@ -16,6 +16,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
 	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 
@@ -58,6 +59,8 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 		wantName      string
 		wantRouteType core.RouteType
 		wantIsDeleted bool
+
+		wantAdditionalDomainNames []string
 	}{
 		{
 			name: "Add LatticeService with hostname",
@@ -85,6 +88,8 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 			wantRouteType: core.HttpRouteType,
 			wantIsDeleted: false,
 			wantErrIsNil:  true,
+
+			wantAdditionalDomainNames: []string{"test2.test.com"},
 		},
 		{
 			name: "Add LatticeService",
@@ -179,7 +184,17 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 
 			k8sSchema := runtime.NewScheme()
 			clientgoscheme.AddToScheme(k8sSchema)
-			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
+			gwv1beta1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema, &gwv1beta1.GatewayClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+				Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+			}, &gwv1beta1.Gateway{
+				ObjectMeta: metav1.ObjectMeta{Name: "gateway1"},
+				Spec: gwv1beta1.GatewaySpec{
+					GatewayClassName: "amazon-vpc-lattice",
+					Listeners:        []gwv1beta1.Listener{{Name: "http", Port: 80, Protocol: gwv1beta1.HTTPProtocolType}},
+				},
+			})
 			ds := latticestore.NewLatticeDataStore()
 
 			//builder := NewLatticeServiceBuilder(k8sClient, ds, nil)
@@ -214,12 +229,14 @@ func Test_LatticeServiceModelBuild(t *testing.T) {
 				assert.Equal(t, false, task.latticeService.Spec.IsDeleted)
 				assert.Equal(t, tt.route.Name(), task.latticeService.Spec.Name)
 				assert.Equal(t, tt.route.Namespace(), task.latticeService.Spec.Namespace)
+				assert.Equal(t, []string{"gateway1"}, task.latticeService.Spec.ServiceNetworkNames)
 
 				if len(tt.route.Spec().Hostnames()) > 0 {
 					assert.Equal(t, string(tt.route.Spec().Hostnames()[0]), task.latticeService.Spec.CustomerDomainName)
 				} else {
 					assert.Equal(t, "", task.latticeService.Spec.CustomerDomainName)
 				}
+				assert.Equal(t, tt.wantAdditionalDomainNames, task.latticeService.Spec.AdditionalDomainNames)
 			}
 
 			if tt.wantErrIsNil {
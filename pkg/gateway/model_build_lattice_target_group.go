@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+// IsLatticeTargetGroupBackendRef tells whether backendRef refers to an existing VPC Lattice target group
+// which is not managed by the controller
+func IsLatticeTargetGroupBackendRef(backendRef core.BackendRef) bool {
+	return backendRef.Group() != nil && string(*backendRef.Group()) == anv1alpha1.GroupName &&
+		backendRef.Kind() != nil && string(*backendRef.Kind()) == anv1alpha1.LatticeTargetGroupKind
+}
+
+// ResolveLatticeTargetGroup returns the VPC Lattice target group a LatticeTargetGroup backendRef of route refers to.
+// It fails when the LatticeTargetGroup or its target group does not exist, or the target group is in another VPC.
+func ResolveLatticeTargetGroup(
+	ctx context.Context,
+	k8sClient client.Client,
+	cloud pkg_aws.Cloud,
+	route core.Route,
+	backendRef core.BackendRef,
+) (*vpclattice.GetTargetGroupOutput, error) {
+	key := latticeTargetGroupKey(route, backendRef)
+	latticeTG := &anv1alpha1.LatticeTargetGroup{}
+	if err := k8sClient.Get(ctx, key, latticeTG); err != nil {
+		return nil, fmt.Errorf("failed to get LatticeTargetGroup %s, %w", key, err)
+	}
+
+	identifier := latticeTG.Spec.TargetGroupIdentifier
+	tg, err := cloud.Lattice().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+		TargetGroupIdentifier: aws.String(identifier),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == vpclattice.ErrCodeResourceNotFoundException {
+			return nil, fmt.Errorf("target group %s of LatticeTargetGroup %s does not exist", identifier, key)
+		}
+		return nil, fmt.Errorf("failed to get target group %s of LatticeTargetGroup %s, %w", identifier, key, err)
+	}
+
+	// LAMBDA target groups are the only ones without VPC
+	if aws.StringValue(tg.Type) != vpclattice.TargetGroupTypeLambda &&
+		(tg.Config == nil || aws.StringValue(tg.Config.VpcIdentifier) != config.VpcID) {
+		return nil, fmt.Errorf("target group %s of LatticeTargetGroup %s is not in VPC %s", identifier, key, config.VpcID)
+	}
+	return tg, nil
+}
+
+// ValidateLatticeTargetGroupBackendRefs checks that the target groups of the LatticeTargetGroup backendRefs of route
+// exist in the VPC of the cluster, backendRefs no ReferenceGrant permits are ignored
+func ValidateLatticeTargetGroupBackendRefs(
+	ctx context.Context,
+	k8sClient client.Client,
+	cloud pkg_aws.Cloud,
+	route core.Route,
+) error {
+	var backendRefs []core.BackendRef
+	for _, rule := range route.Spec().Rules() {
+		for _, backendRef := range rule.BackendRefs() {
+			if IsLatticeTargetGroupBackendRef(backendRef) {
+				backendRefs = append(backendRefs, backendRef)
+			}
+		}
+	}
+	if len(backendRefs) == 0 {
+		return nil
+	}
+
+	unpermitted, err := GetUnpermittedBackendRefs(ctx, k8sClient, route)
+	if err != nil {
+		return err
+	}
+	for _, backendRef := range backendRefs {
+		if slices.ContainsFunc(unpermitted, backendRef.Equals) {
+			continue
+		}
+		if _, err := ResolveLatticeTargetGroup(ctx, k8sClient, cloud, route, backendRef); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveLatticeTargetGroup records the ID of the target group of a LatticeTargetGroup backendRef,
+// rules forward to it directly since the controller does not create a target group for it
+func (t *latticeServiceModelBuildTask) resolveLatticeTargetGroup(ctx context.Context, backendRef core.BackendRef) error {
+	tg, err := ResolveLatticeTargetGroup(ctx, t.client, t.cloud, t.route, backendRef)
+	if err != nil {
+		return err
+	}
+	t.latticeTargetGroupIDs[latticeTargetGroupKey(t.route, backendRef)] = aws.StringValue(tg.Id)
+	return nil
+}
+
+func latticeTargetGroupKey(route core.Route, backendRef core.BackendRef) types.NamespacedName {
+	key := types.NamespacedName{
+		Namespace: route.Namespace(),
+		Name:      string(backendRef.Name()),
+	}
+	if backendRef.Namespace() != nil {
+		key.Namespace = string(*backendRef.Namespace())
+	}
+	return key
+}
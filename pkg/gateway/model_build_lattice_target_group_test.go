@@ -0,0 +1,181 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
+	mocks "github.com/aws/aws-application-networking-k8s/pkg/aws/services"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func latticeTargetGroupBackendRef(name string) gwv1beta1.BackendObjectReference {
+	return gwv1beta1.BackendObjectReference{
+		Group: (*gwv1beta1.Group)(pointer.String(anv1alpha1.GroupName)),
+		Kind:  (*gwv1beta1.Kind)(pointer.String(anv1alpha1.LatticeTargetGroupKind)),
+		Name:  gwv1beta1.ObjectName(name),
+	}
+}
+
+func Test_ResolveLatticeTargetGroup(t *testing.T) {
+	config.VpcID = "vpc-cluster"
+	tgID := "tg-0123456789abcdef0"
+
+	tests := []struct {
+		name          string
+		tgExists      bool
+		tgOutput      *vpclattice.GetTargetGroupOutput
+		tgErr         error
+		wantErr       bool
+		noLatticeCall bool
+	}{
+		{
+			name:     "target group in the VPC of the cluster",
+			tgExists: true,
+			tgOutput: &vpclattice.GetTargetGroupOutput{
+				Id:     aws.String(tgID),
+				Type:   aws.String(vpclattice.TargetGroupTypeIp),
+				Config: &vpclattice.TargetGroupConfig{VpcIdentifier: aws.String("vpc-cluster")},
+			},
+		},
+		{
+			name:     "LAMBDA target group without VPC",
+			tgExists: true,
+			tgOutput: &vpclattice.GetTargetGroupOutput{
+				Id:   aws.String(tgID),
+				Type: aws.String(vpclattice.TargetGroupTypeLambda),
+			},
+		},
+		{
+			name:     "target group in another VPC",
+			tgExists: true,
+			tgOutput: &vpclattice.GetTargetGroupOutput{
+				Id:     aws.String(tgID),
+				Type:   aws.String(vpclattice.TargetGroupTypeIp),
+				Config: &vpclattice.TargetGroupConfig{VpcIdentifier: aws.String("vpc-other")},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "target group does not exist",
+			tgExists: true,
+			tgErr:    awserr.New(vpclattice.ErrCodeResourceNotFoundException, "not found", nil),
+			wantErr:  true,
+		},
+		{
+			name:          "LatticeTargetGroup does not exist",
+			wantErr:       true,
+			noLatticeCall: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+			ctx := context.Background()
+
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			anv1alpha1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			if tt.tgExists {
+				assert.NoError(t, k8sClient.Create(ctx, &anv1alpha1.LatticeTargetGroup{
+					ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "team-a"},
+					Spec:       anv1alpha1.LatticeTargetGroupSpec{TargetGroupIdentifier: tgID},
+				}))
+			}
+
+			mockLattice := mocks.NewMockLattice(c)
+			if !tt.noLatticeCall {
+				mockLattice.EXPECT().GetTargetGroupWithContext(ctx, &vpclattice.GetTargetGroupInput{
+					TargetGroupIdentifier: aws.String(tgID),
+				}).Return(tt.tgOutput, tt.tgErr)
+			}
+			cloud := pkg_aws.NewDefaultCloud(mockLattice, pkg_aws.CloudConfig{VpcId: "vpc-cluster"})
+
+			route := newCrossNamespaceRoute(latticeTargetGroupBackendRef("external"))
+			backendRef := route.Spec().Rules()[0].BackendRefs()[0]
+			tg, err := ResolveLatticeTargetGroup(ctx, k8sClient, cloud, route, backendRef)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tgID, aws.StringValue(tg.Id))
+		})
+	}
+}
+
+func Test_LatticeTargetGroupBackendRefs(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.Background()
+	config.VpcID = "vpc-cluster"
+	tgID := "tg-0123456789abcdef0"
+
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+	assert.NoError(t, k8sClient.Create(ctx, &anv1alpha1.LatticeTargetGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "team-a"},
+		Spec: anv1alpha1.LatticeTargetGroupSpec{
+			TargetGroupIdentifier: "arn:aws:vpc-lattice:us-west-2:123456789012:targetgroup/" + tgID,
+		},
+	}))
+
+	mockLattice := mocks.NewMockLattice(c)
+	mockLattice.EXPECT().GetTargetGroupWithContext(ctx, gomock.Any()).Return(&vpclattice.GetTargetGroupOutput{
+		Id:     aws.String(tgID),
+		Type:   aws.String(vpclattice.TargetGroupTypeInstance),
+		Config: &vpclattice.TargetGroupConfig{VpcIdentifier: aws.String("vpc-cluster")},
+	}, nil)
+
+	route := newCrossNamespaceRoute(latticeTargetGroupBackendRef("external"))
+	stack := core.NewDefaultStack(core.StackID{Name: "route", Namespace: "team-a"})
+	task := &latticeServiceModelBuildTask{
+		log:                   gwlog.FallbackLogger,
+		client:                k8sClient,
+		cloud:                 pkg_aws.NewDefaultCloud(mockLattice, pkg_aws.CloudConfig{VpcId: "vpc-cluster"}),
+		route:                 route,
+		stack:                 stack,
+		datastore:             latticestore.NewLatticeDataStore(),
+		tgByResID:             make(map[string]*model.TargetGroup),
+		latticeTargetGroupIDs: make(map[types.NamespacedName]string),
+	}
+
+	assert.NoError(t, task.buildTargetGroupsForRoute(ctx, k8sClient))
+	assert.NoError(t, task.buildTargetsForRoute(ctx))
+
+	// the existing target group is neither created nor registered targets to
+	var tgs []*model.TargetGroup
+	stack.ListResources(&tgs)
+	assert.Empty(t, tgs)
+	var targets []*model.Targets
+	stack.ListResources(&targets)
+	assert.Empty(t, targets)
+
+	ruleTGs := task.getTargetGroupsForRuleAction(route.Spec().Rules()[0])
+	assert.Equal(t, []*model.RuleTargetGroup{
+		{Name: "external", Namespace: "team-a", RouteName: "route", LatticeTargetGroupID: tgID},
+	}, ruleTGs)
+}
@@ -2,132 +2,147 @@ package gateway
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"strconv"
 
-	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
-
-	"k8s.io/apimachinery/pkg/types"
+	"golang.org/x/exp/slices"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 )
 
 const (
 	awsCustomCertARN = "application-networking.k8s.aws/certificate-arn"
-)
-
-func (t *latticeServiceModelBuildTask) extractListenerInfo(
-	ctx context.Context,
-	parentRef gwv1beta1.ParentReference,
-) (int64, string, string, error) {
-	protocol := gwv1beta1.HTTPProtocolType
-	if parentRef.SectionName != nil {
-		t.log.Debugf("Listener parentRef SectionName is %s", *parentRef.SectionName)
-	}
 
-	t.log.Debugf("Building Listener for Route %s-%s", t.route.Name(), t.route.Namespace())
-	var gwNamespace = t.route.Namespace()
-	if t.route.Spec().ParentRefs()[0].Namespace != nil {
-		gwNamespace = string(*t.route.Spec().ParentRefs()[0].Namespace)
-	}
+	// Status code of the fixed response sent by a listener when the route has no catch-all rule
+	LatticeDefaultActionStatusCodeAnnotation = "application-networking.k8s.aws/default-action-status-code"
+	defaultActionStatusCode                  = 404
+	// Status code the Gateway API requires for requests matching a rule without any backend
+	noBackendStatusCode = 500
+)
 
-	var listenerPort = 0
-	gw := &gwv1beta1.Gateway{}
-	gwName := types.NamespacedName{
-		Namespace: gwNamespace,
-		Name:      string(t.route.Spec().ParentRefs()[0].Name),
-	}
+type routeListener struct {
+	port     int64
+	protocol string
+}
 
-	if err := t.client.Get(ctx, gwName, gw); err != nil {
-		return 0, "", "", fmt.Errorf("failed to build Listener due to unknow http parent ref, Name %s, err %w", gwName, err)
+// getRouteListeners returns the distinct listeners of the parents accepting the route, in parentRef order,
+// along with the certificate of the route
+func (t *latticeServiceModelBuildTask) getRouteListeners(ctx context.Context) ([]routeListener, string, error) {
+	parents, err := GetRouteParents(ctx, t.client, t.route)
+	if err != nil {
+		return nil, "", err
 	}
 
+	var listeners []routeListener
 	var certARN = ""
-	// go through parent find out the matching section name
-	if parentRef.SectionName != nil {
-		found := false
-		for _, section := range gw.Spec.Listeners {
-			if section.Name == *parentRef.SectionName {
-				listenerPort = int(section.Port)
-				protocol = section.Protocol
-				found = true
-
-				if section.TLS != nil {
-					if section.TLS.Mode != nil && *section.TLS.Mode == gwv1beta1.TLSModeTerminate {
-						curCertARN, ok := section.TLS.Options[awsCustomCertARN]
-						if ok {
-							t.log.Debugf("Found certification %s under section %s", curCertARN, section.Name)
-							certARN = string(curCertARN)
-						}
-					}
-				}
-				break
-			}
+	for _, parent := range parents {
+		if !parent.Accepted() {
+			t.log.Infof("Ignore parentRef %s of route %s-%s, %s", parent.ParentRef.Name,
+				t.route.Name(), t.route.Namespace(), parent.Err)
+			continue
 		}
-		if !found {
-			return 0, "", "", fmt.Errorf("error building listener, no matching sectionName in parentRef for Name %s, Section %s", parentRef.Name, *parentRef.SectionName)
+
+		if parent.CertARN != "" {
+			t.log.Debugf("Found certification %s for parentRef %s", parent.CertARN, parent.ParentRef.Name)
+			certARN = parent.CertARN
 		}
-	} else {
-		// use 1st listener port
-		// TODO check no listener
-		if len(gw.Spec.Listeners) == 0 {
-			return 0, "", "", errors.New("error building listener, there is NO listeners on GW")
+
+		protocol := parent.Protocol
+		if protocol == string(gwv1beta1.TLSProtocolType) {
+			protocol = model.ListenerProtocolTLSPassthrough
 		}
-		listenerPort = int(gw.Spec.Listeners[0].Port)
-	}
 
-	return int64(listenerPort), string(protocol), certARN, nil
+		listener := routeListener{port: parent.Port, protocol: protocol}
+		if !slices.Contains(listeners, listener) {
+			listeners = append(listeners, listener)
+		}
+	}
 
+	if len(listeners) == 0 {
+		return nil, "", fmt.Errorf("failed to build listener, no VPC Lattice gateway accepts route %s-%s",
+			t.route.Name(), t.route.Namespace())
+	}
+	return listeners, certARN, nil
 }
 
 func (t *latticeServiceModelBuildTask) buildListeners(ctx context.Context) error {
-	for _, parentRef := range t.route.Spec().ParentRefs() {
-		if parentRef.Name != t.route.Spec().ParentRefs()[0].Name {
-			// when a service is associate to multiple service network(s), all listener config MUST be same
-			// so here we are only using the 1st gateway
-			t.log.Debugf("Ignore parentref of different gateway %s-%s", parentRef.Name, parentRef.Namespace)
-			continue
-		}
+	if len(t.route.Spec().Rules()) == 0 {
+		return fmt.Errorf("error building listener, there are no rules for route %s-%s",
+			t.route.Name(), t.route.Namespace())
+	}
 
-		port, protocol, certARN, err := t.extractListenerInfo(ctx, parentRef)
-		if err != nil {
-			return err
-		}
+	listeners, certARN, err := t.getRouteListeners(ctx)
+	if err != nil {
+		return err
+	}
 
-		if t.latticeService != nil {
-			t.latticeService.Spec.CustomerCertARN = certARN
-		}
+	if t.latticeService != nil {
+		t.latticeService.Spec.CustomerCertARN = certARN
+	}
 
-		t.log.Debugf("Building Listener: found matching listner Port %d", port)
+	action, err := t.buildListenerDefaultAction(ctx)
+	if err != nil {
+		return err
+	}
 
-		if len(t.route.Spec().Rules()) == 0 {
-			return fmt.Errorf("error building listener, there are no rules for route %s-%s",
+	if _, ok := t.route.(*core.TLSRoute); ok {
+		// TLS passthrough listeners have no rules and can only forward
+		if len(t.route.Spec().Rules()) > 1 {
+			return fmt.Errorf("error building listener, TLSRoute %s-%s has more than one rule",
 				t.route.Name(), t.route.Namespace())
 		}
-
-		rule := t.route.Spec().Rules()[0]
-
-		if len(rule.BackendRefs()) == 0 {
-			return fmt.Errorf("error building listener, there are no backend refs for route %s-%s",
+		if action.Forward == nil {
+			return fmt.Errorf("error building listener, TLSRoute %s-%s has no permitted backendRefs",
 				t.route.Name(), t.route.Namespace())
 		}
+	}
+
+	for _, listener := range listeners {
+		t.log.Debugf("Building Listener: found matching listner Port %d", listener.port)
+
+		listenerResourceName := fmt.Sprintf("%s-%s-%d-%s", t.route.Name(), t.route.Namespace(), listener.port, listener.protocol)
+		t.log.Infof("Creating new listener with name %s", listenerResourceName)
+		model.NewListener(t.stack, listenerResourceName, listener.port, listener.protocol, t.route.Name(), t.route.Namespace(), action)
+	}
 
-		backendRef := rule.BackendRefs()[0]
-		targetGroupName := string(backendRef.Name())
+	return nil
+}
 
-		var targetGroupNamespace = t.route.Namespace()
-		if backendRef.Namespace() != nil {
-			targetGroupNamespace = string(*backendRef.Namespace())
+// buildListenerDefaultAction forwards to the first rule without matches, which matches every request
+// and so takes precedence only when no other rule matches. Without such a rule requests get a fixed
+// response, 404 unless the route sets another status code by annotation.
+func (t *latticeServiceModelBuildTask) buildListenerDefaultAction(ctx context.Context) (model.DefaultAction, error) {
+	for _, rule := range t.route.Spec().Rules() {
+		if len(rule.Matches()) != 0 {
+			continue
 		}
 
-		action := model.DefaultAction{
-			BackendServiceName:      targetGroupName,
-			BackendServiceNamespace: targetGroupNamespace,
+		ruleAction, err := t.buildRuleAction(ctx, rule)
+		if err != nil {
+			return model.DefaultAction{}, err
+		}
+		if ruleAction.FixedResponseStatusCode != 0 {
+			return model.DefaultAction{FixedResponseStatusCode: ruleAction.FixedResponseStatusCode}, nil
+		}
+		if len(ruleAction.TargetGroups) == 0 {
+			t.log.Debugf("Catch-all rule of route %s-%s has no backend refs", t.route.Name(), t.route.Namespace())
+			return model.DefaultAction{FixedResponseStatusCode: noBackendStatusCode}, nil
 		}
 
-		listenerResourceName := fmt.Sprintf("%s-%s-%d-%s", t.route.Name(), t.route.Namespace(), port, protocol)
-		t.log.Infof("Creating new listener with name %s", listenerResourceName)
-		model.NewListener(t.stack, listenerResourceName, port, protocol, t.route.Name(), t.route.Namespace(), action)
+		return model.DefaultAction{Forward: &ruleAction}, nil
 	}
 
-	return nil
+	statusCode := int64(defaultActionStatusCode)
+	if value, ok := t.route.K8sObject().GetAnnotations()[LatticeDefaultActionStatusCodeAnnotation]; ok {
+		code, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || code < 100 || code > 599 {
+			return model.DefaultAction{}, fmt.Errorf("invalid %s annotation %s on route %s-%s, must be a status code from 100 to 599",
+				LatticeDefaultActionStatusCodeAnnotation, value, t.route.Name(), t.route.Namespace())
+		}
+		statusCode = code
+	}
+
+	return model.DefaultAction{FixedResponseStatusCode: statusCode}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
@@ -326,12 +327,13 @@ func Test_ListenerModelBuild(t *testing.T) {
 			ctx := context.TODO()
 
 			mockK8sClient := mock_client.NewMockClient(c)
+			expectLatticeGatewayClass(ctx, mockK8sClient)
 
 			if tt.k8sGetGatewayCall {
 
 				mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
 					func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
-
+						gw.Namespace = gwName.Namespace
 						if tt.k8sGatewayReturnOK {
 							listener := gwv1beta1.Listener{
 								Port:     tt.gwListenerPort,
@@ -416,12 +418,12 @@ func Test_ListenerModelBuild(t *testing.T) {
 			assert.Equal(t, resListener[0].Spec.Namespace, tt.route.Namespace())
 			assert.Equal(t, resListener[0].Spec.Protocol, "HTTP")
 
-			assert.Equal(t, resListener[0].Spec.DefaultAction.BackendServiceName,
-				string(tt.route.Spec().Rules()[0].BackendRefs()[0].Name()))
+			defaultTG := resListener[0].Spec.DefaultAction.Forward.TargetGroups[0]
+			assert.Equal(t, defaultTG.Name, string(tt.route.Spec().Rules()[0].BackendRefs()[0].Name()))
 			if ns := tt.route.Spec().Rules()[0].BackendRefs()[0].Namespace(); ns != nil {
-				assert.Equal(t, resListener[0].Spec.DefaultAction.BackendServiceNamespace, *ns)
+				assert.Equal(t, defaultTG.Namespace, string(*ns))
 			} else {
-				assert.Equal(t, resListener[0].Spec.DefaultAction.BackendServiceNamespace, tt.route.Namespace())
+				assert.Equal(t, defaultTG.Namespace, tt.route.Namespace())
 			}
 
 			if tt.tlsTerminate && !tt.noTLSOption && !tt.wrongTLSOption {
@@ -432,3 +434,99 @@ func Test_ListenerModelBuild(t *testing.T) {
 		})
 	}
 }
+
+func Test_buildListenerDefaultAction(t *testing.T) {
+	var serviceKind gwv1beta1.Kind = "Service"
+	var backendRef = gwv1beta1.HTTPBackendRef{
+		BackendRef: gwv1beta1.BackendRef{
+			BackendObjectReference: gwv1beta1.BackendObjectReference{
+				Name: "targetgroup1",
+				Kind: &serviceKind,
+			},
+		},
+	}
+	var pathMatch = gwv1beta1.HTTPRouteMatch{
+		Path: &gwv1beta1.HTTPPathMatch{
+			Value: aws.String("/api"),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		rules       []gwv1beta1.HTTPRouteRule
+		want        model.DefaultAction
+		wantErr     bool
+	}{
+		{
+			name: "catch-all rule forwards",
+			rules: []gwv1beta1.HTTPRouteRule{
+				{Matches: []gwv1beta1.HTTPRouteMatch{pathMatch}, BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef}},
+				{BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef}},
+			},
+			want: model.DefaultAction{
+				Forward: &model.RuleAction{
+					TargetGroups: []*model.RuleTargetGroup{
+						{Name: "targetgroup1", Namespace: "default", RouteName: "service1"},
+					},
+				},
+			},
+		},
+		{
+			name: "catch-all rule without backend",
+			rules: []gwv1beta1.HTTPRouteRule{
+				{},
+			},
+			want: model.DefaultAction{FixedResponseStatusCode: 500},
+		},
+		{
+			name: "no catch-all rule",
+			rules: []gwv1beta1.HTTPRouteRule{
+				{Matches: []gwv1beta1.HTTPRouteMatch{pathMatch}, BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef}},
+			},
+			want: model.DefaultAction{FixedResponseStatusCode: 404},
+		},
+		{
+			name:        "no catch-all rule, status code annotation",
+			annotations: map[string]string{LatticeDefaultActionStatusCodeAnnotation: "503"},
+			rules: []gwv1beta1.HTTPRouteRule{
+				{Matches: []gwv1beta1.HTTPRouteMatch{pathMatch}, BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef}},
+			},
+			want: model.DefaultAction{FixedResponseStatusCode: 503},
+		},
+		{
+			name:        "no catch-all rule, invalid status code annotation",
+			annotations: map[string]string{LatticeDefaultActionStatusCodeAnnotation: "600"},
+			rules: []gwv1beta1.HTTPRouteRule{
+				{Matches: []gwv1beta1.HTTPRouteMatch{pathMatch}, BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &latticeServiceModelBuildTask{
+				log: gwlog.FallbackLogger,
+				route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "service1",
+						Namespace:   "default",
+						Annotations: tt.annotations,
+					},
+					Spec: gwv1beta1.HTTPRouteSpec{
+						Rules: tt.rules,
+					},
+				}),
+			}
+
+			action, err := task.buildListenerDefaultAction(context.Background())
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, action)
+		})
+	}
+}
@@ -4,85 +4,106 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp/syntax"
+	"strings"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 
 	"github.com/aws/aws-sdk-go/aws"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 )
 
 const (
-	LATTICE_NO_SUPPORT_FOR_MULTIPLE_MATCHES = "LATTICE_NO_SUPPORT_FOR_MULTIPLE_MATCHES"
-	LATTICE_EXCEED_MAX_HEADER_MATCHES       = "LATTICE_EXCEED_MAX_HEADER_MATCHES"
-	LATTICE_UNSUPPORTED_MATCH_TYPE          = "LATTICE_UNSUPPORTED_MATCH_TYPE"
-	LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE   = "LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE"
-	LATTICE_UNSUPPORTED_PATH_MATCH_TYPE     = "LATTICE_UNSUPPORTED_PATH_MATCH_TYPE"
-	LATTICE_MAX_HEADER_MATCHES              = 5
+	LATTICE_EXCEED_MAX_RULES              = "LATTICE_EXCEED_MAX_RULES"
+	LATTICE_EXCEED_MAX_HEADER_MATCHES     = "LATTICE_EXCEED_MAX_HEADER_MATCHES"
+	LATTICE_UNSUPPORTED_MATCH_TYPE        = "LATTICE_UNSUPPORTED_MATCH_TYPE"
+	LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE = "LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE"
+	LATTICE_UNSUPPORTED_PATH_MATCH_TYPE   = "LATTICE_UNSUPPORTED_PATH_MATCH_TYPE"
+	LATTICE_MAX_HEADER_MATCHES            = 5
+
+	// Header values are matched case-insensitively when the route has this annotation set to "true",
+	// and case-sensitively when it has any other value
+	LatticeHeaderMatchCaseInsensitiveAnnotation = "application-networking.k8s.aws/header-match-case-insensitive"
+
+	// Status code of requests matching a rule whose LatticeFixedResponse does not exist
+	unresolvedExtensionRefStatusCode = 500
+	// Status code of requests matching a rule whose backendRefs are all refused for lack of a ReferenceGrant
+	unpermittedBackendRefStatusCode = 500
 )
 
 func (t *latticeServiceModelBuildTask) buildRules(ctx context.Context) error {
-	var ruleID = 1
-	for _, parentRef := range t.route.Spec().ParentRefs() {
-		if parentRef.Name != t.route.Spec().ParentRefs()[0].Name {
-			// when a service is associate to multiple service network(s), all listener config MUST be same
-			// so here we are only using the 1st gateway
-			t.log.Debugf("Ignore parentref of different gateway %s-%s", parentRef.Name, *parentRef.Namespace)
-			continue
-		}
+	listeners, _, err := t.getRouteListeners(ctx)
+	if err != nil {
+		return err
+	}
 
-		port, protocol, _, err := t.extractListenerInfo(ctx, parentRef)
-		if err != nil {
-			return err
-		}
+	for _, listener := range listeners {
+		port, protocol := listener.port, listener.protocol
+		var order = 1
+		ruleIDs := make(map[string]bool)
 
 		for _, rule := range t.route.Spec().Rules() {
-			var ruleSpec model.RuleSpec
-
-			if len(rule.Matches()) > 1 {
-				// only support 1 match today
-				return errors.New(LATTICE_NO_SUPPORT_FOR_MULTIPLE_MATCHES)
-			}
-
 			if len(rule.Matches()) == 0 {
+				// catch-all rules become the default action of the listener
 				t.log.Debugf("Continue next rule, no matches specified in current rule")
 				continue
 			}
 
-			// only support 1 match today
-			match := rule.Matches()[0]
+			ruleAction, err := t.buildRuleAction(ctx, rule)
+			if err != nil {
+				return err
+			}
 
-			switch m := match.(type) {
-			case *core.HTTPRouteMatch:
-				if err := t.updateRuleSpecForHttpRoute(m, &ruleSpec); err != nil {
-					return err
+			// matches within a rule are ORed, VPC Lattice only supports one match per rule,
+			// so each match becomes its own lattice rule with the same action, right after each other
+			for _, match := range rule.Matches() {
+				if order > model.MAX_RULE_PRIORITY {
+					return errors.New(LATTICE_EXCEED_MAX_RULES)
 				}
-			case *core.GRPCRouteMatch:
-				if err := t.updateRuleSpecForGrpcRoute(m, &ruleSpec); err != nil {
-					return err
+
+				var ruleSpec model.RuleSpec
+
+				switch m := match.(type) {
+				case *core.HTTPRouteMatch:
+					if err := t.updateRuleSpecForHttpRoute(m, &ruleSpec); err != nil {
+						return err
+					}
+				case *core.GRPCRouteMatch:
+					if err := t.updateRuleSpecForGrpcRoute(m, &ruleSpec); err != nil {
+						return err
+					}
+				default:
+					return fmt.Errorf("unsupported rule match: %T", m)
 				}
-			default:
-				return fmt.Errorf("unsupported rule match: %T", m)
-			}
 
-			if err := t.updateRuleSpecWithHeaderMatches(match, &ruleSpec); err != nil {
-				return err
-			}
+				if err := t.updateRuleSpecWithHeaderMatches(match, &ruleSpec); err != nil {
+					return err
+				}
 
-			tgList := t.getTargetGroupsForRuleAction(rule)
+				// rules are keyed by their match rather than their position, so that reordering
+				// route rules moves the existing lattice rules instead of rewriting all of them
+				ruleIDName := fmt.Sprintf("rule-%s", model.RuleMatchKey(port, protocol, &ruleSpec))
+				if ruleIDs[ruleIDName] {
+					// an identical match earlier in the route always takes precedence
+					t.log.Debugf("Ignore duplicate match of route %s-%s", t.route.Name(), t.route.Namespace())
+					continue
+				}
+				ruleIDs[ruleIDName] = true
 
-			ruleIDName := fmt.Sprintf("rule-%d", ruleID)
-			ruleAction := model.RuleAction{
-				TargetGroups: tgList,
+				ruleSpec.Order = order
+				model.NewRule(t.stack, ruleIDName, t.route.Name(), t.route.Namespace(), port,
+					protocol, ruleAction, ruleSpec)
+				order++
 			}
-			model.NewRule(t.stack, ruleIDName, t.route.Name(), t.route.Namespace(), port,
-				protocol, ruleAction, ruleSpec)
-			ruleID++
 		}
 	}
 
@@ -171,30 +192,133 @@ func (t *latticeServiceModelBuildTask) updateRuleSpecWithHeaderMatches(match cor
 
 	t.log.Debugf("Examining match headers for route %s-%s", t.route.Name(), t.route.Namespace())
 
+	// without the annotation, header matches leave case sensitivity to the VPC Lattice default
+	var caseSensitive *bool
+	if caseInsensitive, ok := t.route.K8sObject().GetAnnotations()[LatticeHeaderMatchCaseInsensitiveAnnotation]; ok {
+		caseSensitive = aws.Bool(caseInsensitive != "true")
+	}
+
 	for i, header := range match.Headers() {
-		t.log.Debugf("Examining match.Header: i = %d header.Type %s", i, *header.Type())
-		if header.Type() != nil && *header.Type() != gwv1beta1.HeaderMatchExact {
-			t.log.Debugf("Unsupported header matchtype %s for httproute %s-%s",
+		matchType := vpclattice.HeaderMatchType{}
+
+		if header.Type() == nil || *header.Type() == gwv1beta1.HeaderMatchExact {
+			matchType.Exact = aws.String(header.Value())
+		} else if *header.Type() == gwv1beta1.HeaderMatchRegularExpression {
+			t.log.Debugf("Examining match.Header: i = %d regular expression %s", i, header.Value())
+			if err := parseHeaderMatchRegularExpression(header.Value(), &matchType); err != nil {
+				t.log.Debugf("Unsupported header regular expression %s for route %s-%s, %s",
+					header.Value(), t.route.Name(), t.route.Namespace(), err)
+				return errors.New(LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE)
+			}
+		} else {
+			t.log.Debugf("Unsupported header matchtype %s for route %s-%s",
 				*header.Type(), t.route.Name(), t.route.Namespace())
 			return errors.New(LATTICE_UNSUPPORTED_HEADER_MATCH_TYPE)
 		}
 
-		matchType := vpclattice.HeaderMatchType{
-			Exact: aws.String(header.Value()),
-		}
 		ruleSpec.MatchedHeaders[i].Match = &matchType
 		headerName := header.Name()
 		ruleSpec.MatchedHeaders[i].Name = &headerName
+		ruleSpec.MatchedHeaders[i].CaseSensitive = caseSensitive
+	}
+	return nil
+}
+
+// parseHeaderMatchRegularExpression translates the subset of regular expressions VPC Lattice can express:
+//
+//	^foo.* or ^foo  -> prefix match on "foo"
+//	.*foo.*         -> contains match on "foo"
+//
+// "foo" must be a literal, regular expression metacharacters in it have to be escaped, e.g. ^v1\.2.*
+func parseHeaderMatchRegularExpression(expression string, matchType *vpclattice.HeaderMatchType) error {
+	var literal string
+	isPrefix := false
+
+	if strings.HasPrefix(expression, "^") {
+		isPrefix = true
+		literal = strings.TrimSuffix(strings.TrimPrefix(expression, "^"), ".*")
+	} else if strings.HasPrefix(expression, ".*") && strings.HasSuffix(expression, ".*") && len(expression) > 4 {
+		literal = expression[2 : len(expression)-2]
+	} else {
+		return fmt.Errorf("expression must be in the form of ^value.* or .*value.*")
+	}
+
+	re, err := syntax.Parse(literal, syntax.Perl)
+	if err != nil {
+		return err
+	}
+	if re.Op != syntax.OpLiteral || re.Flags&syntax.FoldCase != 0 {
+		return fmt.Errorf("%s is not a literal value", literal)
+	}
+
+	value := string(re.Rune)
+	if isPrefix {
+		matchType.Prefix = aws.String(value)
+	} else {
+		matchType.Contains = aws.String(value)
 	}
 	return nil
 }
 
+// buildRuleAction replies with the fixed response of the rule if it has one, otherwise it forwards to the backends of the rule.
+// It is shared by the rules of the route and the default action of its listeners, which forwards like the catch-all rule.
+func (t *latticeServiceModelBuildTask) buildRuleAction(ctx context.Context, rule core.RouteRule) (model.RuleAction, error) {
+	statusCode, err := t.getRuleFixedResponseStatusCode(ctx, rule)
+	if err != nil {
+		return model.RuleAction{}, err
+	}
+	if statusCode != 0 {
+		return model.RuleAction{FixedResponseStatusCode: statusCode}, nil
+	}
+
+	tgList := t.getTargetGroupsForRuleAction(rule)
+	if len(tgList) == 0 && len(rule.BackendRefs()) != 0 {
+		t.log.Infof("No ReferenceGrant permits the backend refs of a rule of route %s-%s",
+			t.route.Name(), t.route.Namespace())
+		return model.RuleAction{FixedResponseStatusCode: unpermittedBackendRefStatusCode}, nil
+	}
+	return model.RuleAction{TargetGroups: tgList}, nil
+}
+
+// getRuleFixedResponseStatusCode returns the status code of the LatticeFixedResponse referenced by an ExtensionRef
+// filter of the rule, or 0 if there is none. As required by the Gateway API, requests matching a rule whose
+// LatticeFixedResponse cannot be found get an error response rather than skipping the filter.
+func (t *latticeServiceModelBuildTask) getRuleFixedResponseStatusCode(ctx context.Context, rule core.RouteRule) (int64, error) {
+	for _, extensionRef := range rule.ExtensionRefs() {
+		if string(extensionRef.Group) != anv1alpha1.GroupName || string(extensionRef.Kind) != anv1alpha1.LatticeFixedResponseKind {
+			t.log.Debugf("Ignore unsupported extensionRef %s/%s of route %s-%s",
+				extensionRef.Group, extensionRef.Kind, t.route.Name(), t.route.Namespace())
+			continue
+		}
+
+		fixedResponse := &anv1alpha1.LatticeFixedResponse{}
+		key := types.NamespacedName{
+			Namespace: t.route.Namespace(),
+			Name:      string(extensionRef.Name),
+		}
+		if err := t.client.Get(ctx, key, fixedResponse); err != nil {
+			if apierrors.IsNotFound(err) {
+				t.log.Infof("LatticeFixedResponse %s referenced by route %s-%s not found",
+					key, t.route.Name(), t.route.Namespace())
+				return unresolvedExtensionRefStatusCode, nil
+			}
+			return 0, fmt.Errorf("failed to get LatticeFixedResponse %s, %w", key, err)
+		}
+		return fixedResponse.Spec.StatusCode, nil
+	}
+	return 0, nil
+}
+
 func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(rule core.RouteRule) []*model.RuleTargetGroup {
 	var tgList []*model.RuleTargetGroup
 
 	for _, backendRef := range rule.BackendRefs() {
+		if !t.isBackendRefPermitted(backendRef) {
+			continue
+		}
+
 		ruleTG := model.RuleTargetGroup{}
-		if string(*backendRef.Kind()) == "Service" {
+		if string(*backendRef.Kind()) == "Service" || IsLambdaFunctionBackendRef(backendRef) {
 			namespace := t.route.Namespace()
 			if backendRef.Namespace() != nil {
 				namespace = string(*backendRef.Namespace())
@@ -203,9 +327,15 @@ func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(rule core.Ro
 			ruleTG.Namespace = namespace
 			ruleTG.RouteName = t.route.Name()
 			ruleTG.IsServiceImport = false
-			if backendRef.Weight() != nil {
-				ruleTG.Weight = int64(*backendRef.Weight())
-			}
+			ruleTG.IsLambdaFunction = IsLambdaFunctionBackendRef(backendRef)
+		}
+
+		if IsLatticeTargetGroupBackendRef(backendRef) {
+			key := latticeTargetGroupKey(t.route, backendRef)
+			ruleTG.Name = key.Name
+			ruleTG.Namespace = key.Namespace
+			ruleTG.RouteName = t.route.Name()
+			ruleTG.LatticeTargetGroupID = t.latticeTargetGroupIDs[key]
 		}
 
 		if string(*backendRef.Kind()) == "ServiceImport" {
@@ -217,12 +347,11 @@ func (t *latticeServiceModelBuildTask) getTargetGroupsForRuleAction(rule core.Ro
 			// the routeName for serviceimport is always ""
 			ruleTG.RouteName = ""
 			ruleTG.IsServiceImport = true
-
-			if backendRef.Weight() != nil {
-				ruleTG.Weight = int64(*backendRef.Weight())
-			}
 		}
 
+		if backendRef.Weight() != nil {
+			ruleTG.Weight = int64(*backendRef.Weight())
+		}
 		tgList = append(tgList, &ruleTG)
 	}
 	return tgList
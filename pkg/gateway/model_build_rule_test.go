@@ -11,8 +11,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
@@ -20,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -473,12 +476,13 @@ func Test_RuleModelBuild(t *testing.T) {
 			ctx := context.TODO()
 
 			mockK8sClient := mock_client.NewMockClient(c)
+			expectLatticeGatewayClass(ctx, mockK8sClient)
 
 			if tt.k8sGetGatewayCall {
 
 				mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
 					func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
-
+						gw.Namespace = gwName.Namespace
 						if tt.k8sGatewayReturnOK {
 							gw.Spec.Listeners = append(gw.Spec.Listeners, gwv1beta1.Listener{
 								Port: tt.gwListenerPort,
@@ -520,7 +524,7 @@ func Test_RuleModelBuild(t *testing.T) {
 			var i = 1
 			for _, resRule := range resRules {
 
-				fmt.Sscanf(resRule.Spec.RuleID, "rule-%d", &i)
+				i = resRule.Spec.Order
 
 				assert.Equal(t, resRule.Spec.ListenerPort, int64(tt.gwListenerPort))
 				// Defer this to dedicate rule check			assert.Equal(t, resRule.Spec.PathMatchValue, tt.route.)
@@ -1118,9 +1122,9 @@ func Test_HeadersRuleBuild(t *testing.T) {
 			},
 		},
 		{
-			name:           "Negative, multiple methods",
+			name:           "multiple matches",
 			gwListenerPort: *PortNumberPtr(80),
-			wantErrIsNil:   true,
+			wantErrIsNil:   false,
 			samerule:       true,
 
 			route: core.NewHTTPRoute(gwv1beta1.HTTPRoute{
@@ -1424,10 +1428,11 @@ func Test_HeadersRuleBuild(t *testing.T) {
 			ctx := context.TODO()
 
 			mockK8sClient := mock_client.NewMockClient(c)
+			expectLatticeGatewayClass(ctx, mockK8sClient)
 
 			mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
 				func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
-
+					gw.Namespace = gwName.Namespace
 					gw.Spec.Listeners = append(gw.Spec.Listeners, gwv1beta1.Listener{
 						Port: tt.gwListenerPort,
 						Name: *tt.route.Spec().ParentRefs()[0].SectionName,
@@ -1543,6 +1548,11 @@ func isRuleSpecSame(rule1 *model.RuleSpec, rule2 *model.RuleSpec) bool {
 						found = true
 						break
 					}
+				} else if rule1Hdr.Match.Contains != nil && rule2Hdr.Match.Contains != nil {
+					if *rule1Hdr.Match.Contains == *rule2Hdr.Match.Contains {
+						found = true
+						break
+					}
 				}
 			}
 
@@ -1553,3 +1563,404 @@ func isRuleSpecSame(rule1 *model.RuleSpec, rule2 *model.RuleSpec) bool {
 	}
 	return true
 }
+
+func Test_MultipleMatchesRuleBuild(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	var httpSectionName gwv1beta1.SectionName = "http"
+	var serviceKind gwv1beta1.Kind = "Service"
+	var k8sPathMatchExactType = gwv1beta1.PathMatchExact
+	var k8sPathMatchPrefixType = gwv1beta1.PathMatchPathPrefix
+	var path1 = "/ver1"
+	var path2 = "/ver2"
+	var path3 = "/ver3"
+
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service1",
+			Namespace: "default",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{
+					{
+						Name:        "gw1",
+						SectionName: &httpSectionName,
+					},
+				},
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchExactType, Value: &path1}},
+						{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchPrefixType, Value: &path2}},
+					},
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "tg1", Kind: &serviceKind}}},
+					},
+				},
+				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchPrefixType, Value: &path3}},
+					},
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "tg2", Kind: &serviceKind}}},
+					},
+				},
+			},
+		},
+	})
+
+	mockK8sClient := mock_client.NewMockClient(c)
+	expectLatticeGatewayClass(ctx, mockK8sClient)
+	mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
+			gw.Namespace = gwName.Namespace
+			gw.Spec.Listeners = append(gw.Spec.Listeners, gwv1beta1.Listener{
+				Port: 80,
+				Name: httpSectionName,
+			})
+			return nil
+		},
+	)
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+	task := &latticeServiceModelBuildTask{
+		log:             gwlog.FallbackLogger,
+		route:           route,
+		stack:           stack,
+		client:          mockK8sClient,
+		listenerByResID: make(map[string]*model.Listener),
+		datastore:       latticestore.NewLatticeDataStore(),
+	}
+
+	err := task.buildRules(ctx)
+	assert.NoError(t, err)
+
+	var resRules []*model.Rule
+	stack.ListResources(&resRules)
+	assert.Equal(t, 3, len(resRules))
+
+	rulesByOrder := make(map[int]*model.Rule)
+	for _, resRule := range resRules {
+		rulesByOrder[resRule.Spec.Order] = resRule
+	}
+
+	assert.True(t, rulesByOrder[1].Spec.PathMatchExact)
+	assert.Equal(t, path1, rulesByOrder[1].Spec.PathMatchValue)
+	assert.True(t, rulesByOrder[2].Spec.PathMatchPrefix)
+	assert.Equal(t, path2, rulesByOrder[2].Spec.PathMatchValue)
+	assert.Equal(t, rulesByOrder[1].Spec.Action, rulesByOrder[2].Spec.Action)
+	assert.Equal(t, path3, rulesByOrder[3].Spec.PathMatchValue)
+	assert.Equal(t, "tg2", rulesByOrder[3].Spec.Action.TargetGroups[0].Name)
+}
+
+func Test_FixedResponseRuleBuild(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+	ctx := context.TODO()
+
+	var httpSectionName gwv1beta1.SectionName = "http"
+	var serviceKind gwv1beta1.Kind = "Service"
+	var k8sPathMatchPrefixType = gwv1beta1.PathMatchPathPrefix
+	var path1 = "/maintenance"
+	var path2 = "/deprecated"
+	var path3 = "/ver1"
+
+	fixedResponseFilter := func(name string) []gwv1beta1.HTTPRouteFilter {
+		return []gwv1beta1.HTTPRouteFilter{
+			{
+				Type: gwv1beta1.HTTPRouteFilterExtensionRef,
+				ExtensionRef: &gwv1beta1.LocalObjectReference{
+					Group: anv1alpha1.GroupName,
+					Kind:  anv1alpha1.LatticeFixedResponseKind,
+					Name:  gwv1beta1.ObjectName(name),
+				},
+			},
+		}
+	}
+
+	route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "service1",
+			Namespace: "default",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{
+					{
+						Name:        "gw1",
+						SectionName: &httpSectionName,
+					},
+				},
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchPrefixType, Value: &path1}},
+					},
+					Filters: fixedResponseFilter("maintenance"),
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "tg1", Kind: &serviceKind}}},
+					},
+				},
+				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchPrefixType, Value: &path2}},
+					},
+					Filters: fixedResponseFilter("missing"),
+				},
+				{
+					Matches: []gwv1beta1.HTTPRouteMatch{
+						{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchPrefixType, Value: &path3}},
+					},
+					BackendRefs: []gwv1beta1.HTTPBackendRef{
+						{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "tg1", Kind: &serviceKind}}},
+					},
+				},
+			},
+		},
+	})
+
+	mockK8sClient := mock_client.NewMockClient(c)
+	expectLatticeGatewayClass(ctx, mockK8sClient)
+	mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
+			gw.Namespace = gwName.Namespace
+			gw.Spec.Listeners = append(gw.Spec.Listeners, gwv1beta1.Listener{
+				Port: 80,
+				Name: httpSectionName,
+			})
+			return nil
+		},
+	)
+	mockK8sClient.EXPECT().Get(ctx, types.NamespacedName{Namespace: "default", Name: "maintenance"}, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, name types.NamespacedName, fixedResponse *anv1alpha1.LatticeFixedResponse, arg3 ...interface{}) error {
+			fixedResponse.Spec.StatusCode = 503
+			return nil
+		},
+	)
+	mockK8sClient.EXPECT().Get(ctx, types.NamespacedName{Namespace: "default", Name: "missing"}, gomock.Any(), gomock.Any()).Return(
+		apierrors.NewNotFound(anv1alpha1.Resource("latticefixedresponses"), "missing"))
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+	task := &latticeServiceModelBuildTask{
+		log:             gwlog.FallbackLogger,
+		route:           route,
+		stack:           stack,
+		client:          mockK8sClient,
+		listenerByResID: make(map[string]*model.Listener),
+		datastore:       latticestore.NewLatticeDataStore(),
+	}
+
+	err := task.buildRules(ctx)
+	assert.NoError(t, err)
+
+	var resRules []*model.Rule
+	stack.ListResources(&resRules)
+	assert.Equal(t, 3, len(resRules))
+
+	rulesByOrder := make(map[int]*model.Rule)
+	for _, resRule := range resRules {
+		rulesByOrder[resRule.Spec.Order] = resRule
+	}
+
+	assert.Equal(t, model.RuleAction{FixedResponseStatusCode: 503}, rulesByOrder[1].Spec.Action)
+	// requests of a rule with an unresolved LatticeFixedResponse get an error response
+	assert.Equal(t, model.RuleAction{FixedResponseStatusCode: 500}, rulesByOrder[2].Spec.Action)
+	assert.Equal(t, int64(0), rulesByOrder[3].Spec.Action.FixedResponseStatusCode)
+	assert.Equal(t, "tg1", rulesByOrder[3].Spec.Action.TargetGroups[0].Name)
+}
+
+func Test_RuleIDStableAcrossReorder(t *testing.T) {
+	var httpSectionName gwv1beta1.SectionName = "http"
+	var serviceKind gwv1beta1.Kind = "Service"
+	var k8sPathMatchPrefixType = gwv1beta1.PathMatchPathPrefix
+	var path1 = "/ver1"
+	var path2 = "/ver2"
+
+	routeRule := func(path *string, backend string) gwv1beta1.HTTPRouteRule {
+		return gwv1beta1.HTTPRouteRule{
+			Matches: []gwv1beta1.HTTPRouteMatch{
+				{Path: &gwv1beta1.HTTPPathMatch{Type: &k8sPathMatchPrefixType, Value: path}},
+			},
+			BackendRefs: []gwv1beta1.HTTPBackendRef{
+				{BackendRef: gwv1beta1.BackendRef{BackendObjectReference: gwv1beta1.BackendObjectReference{Name: gwv1beta1.ObjectName(backend), Kind: &serviceKind}}},
+			},
+		}
+	}
+
+	buildRules := func(rules ...gwv1beta1.HTTPRouteRule) map[string]*model.Rule {
+		c := gomock.NewController(t)
+		defer c.Finish()
+		ctx := context.TODO()
+
+		route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "service1",
+				Namespace: "default",
+			},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+					ParentRefs: []gwv1beta1.ParentReference{
+						{
+							Name:        "gw1",
+							SectionName: &httpSectionName,
+						},
+					},
+				},
+				Rules: rules,
+			},
+		})
+
+		mockK8sClient := mock_client.NewMockClient(c)
+		expectLatticeGatewayClass(ctx, mockK8sClient)
+		mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, gwName types.NamespacedName, gw *gwv1beta1.Gateway, arg3 ...interface{}) error {
+				gw.Namespace = gwName.Namespace
+				gw.Spec.Listeners = append(gw.Spec.Listeners, gwv1beta1.Listener{
+					Port: 80,
+					Name: httpSectionName,
+				})
+				return nil
+			},
+		)
+
+		stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+		task := &latticeServiceModelBuildTask{
+			log:             gwlog.FallbackLogger,
+			route:           route,
+			stack:           stack,
+			client:          mockK8sClient,
+			listenerByResID: make(map[string]*model.Listener),
+			datastore:       latticestore.NewLatticeDataStore(),
+		}
+
+		err := task.buildRules(ctx)
+		assert.NoError(t, err)
+
+		var resRules []*model.Rule
+		stack.ListResources(&resRules)
+
+		rulesByID := make(map[string]*model.Rule)
+		for _, resRule := range resRules {
+			rulesByID[resRule.Spec.RuleID] = resRule
+		}
+		return rulesByID
+	}
+
+	before := buildRules(routeRule(&path1, "tg1"), routeRule(&path2, "tg2"))
+	after := buildRules(routeRule(&path2, "tg3"), routeRule(&path1, "tg1"), routeRule(&path1, "tg4"))
+
+	// rules keep their id when reordered or forwarded elsewhere, a duplicate match is dropped
+	assert.Equal(t, 2, len(before))
+	assert.Equal(t, 2, len(after))
+	for ruleID, rule := range before {
+		assert.Contains(t, after, ruleID)
+		if rule.Spec.PathMatchValue == path1 {
+			assert.Equal(t, 1, rule.Spec.Order)
+			assert.Equal(t, 2, after[ruleID].Spec.Order)
+			assert.Equal(t, "tg1", after[ruleID].Spec.Action.TargetGroups[0].Name)
+		} else {
+			assert.Equal(t, 2, rule.Spec.Order)
+			assert.Equal(t, 1, after[ruleID].Spec.Order)
+			assert.Equal(t, "tg3", after[ruleID].Spec.Action.TargetGroups[0].Name)
+		}
+	}
+}
+
+func Test_parseHeaderMatchRegularExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		prefix     *string
+		contains   *string
+		wantErr    bool
+	}{
+		{expression: "^foo.*", prefix: aws.String("foo")},
+		{expression: "^foo", prefix: aws.String("foo")},
+		{expression: `^v1\.2.*`, prefix: aws.String("v1.2")},
+		{expression: ".*foo.*", contains: aws.String("foo")},
+		{expression: "foo", wantErr: true},
+		{expression: "^.*", wantErr: true},
+		{expression: ".*.*", wantErr: true},
+		{expression: "^fo+.*", wantErr: true},
+		{expression: "^foo$", wantErr: true},
+		{expression: ".*(a|b).*", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			matchType := vpclattice.HeaderMatchType{}
+			err := parseHeaderMatchRegularExpression(tt.expression, &matchType)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.prefix, matchType.Prefix)
+			assert.Equal(t, tt.contains, matchType.Contains)
+			assert.Nil(t, matchType.Exact)
+		})
+	}
+}
+
+func Test_HeaderMatchCaseSensitivity(t *testing.T) {
+	var httpSectionName gwv1beta1.SectionName = "http"
+	var k8sHeaderRegexType = gwv1beta1.HeaderMatchRegularExpression
+
+	for _, tt := range []struct {
+		annotations   map[string]string
+		caseSensitive *bool
+	}{
+		{annotations: nil, caseSensitive: nil},
+		{annotations: map[string]string{LatticeHeaderMatchCaseInsensitiveAnnotation: "true"}, caseSensitive: aws.Bool(false)},
+		{annotations: map[string]string{LatticeHeaderMatchCaseInsensitiveAnnotation: "false"}, caseSensitive: aws.Bool(true)},
+	} {
+		route := core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "service1",
+				Namespace:   "default",
+				Annotations: tt.annotations,
+			},
+			Spec: gwv1beta1.HTTPRouteSpec{
+				CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+					ParentRefs: []gwv1beta1.ParentReference{
+						{
+							Name:        "gw1",
+							SectionName: &httpSectionName,
+						},
+					},
+				},
+				Rules: []gwv1beta1.HTTPRouteRule{
+					{
+						Matches: []gwv1beta1.HTTPRouteMatch{
+							{
+								Headers: []gwv1beta1.HTTPHeaderMatch{
+									{
+										Type:  &k8sHeaderRegexType,
+										Name:  "env",
+										Value: ".*test.*",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+
+		task := &latticeServiceModelBuildTask{
+			log:   gwlog.FallbackLogger,
+			route: route,
+		}
+
+		var ruleSpec model.RuleSpec
+		err := task.updateRuleSpecWithHeaderMatches(route.Spec().Rules()[0].Matches()[0], &ruleSpec)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, ruleSpec.NumOfHeaderMatches)
+		assert.Equal(t, "test", *ruleSpec.MatchedHeaders[0].Match.Contains)
+		assert.Equal(t, tt.caseSensitive, ruleSpec.MatchedHeaders[0].CaseSensitive)
+	}
+}
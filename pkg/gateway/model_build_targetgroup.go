@@ -2,7 +2,6 @@ package gateway
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
@@ -24,6 +23,11 @@ import (
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 )
 
+const (
+	// LatticeIpAddressTypeAnnotation chooses the IP family, IPV4 or IPV6, of the target group of a dual-stack Service
+	LatticeIpAddressTypeAnnotation = "application-networking.k8s.aws/ip-address-type"
+)
+
 type SvcExportTargetGroupModelBuilder interface {
 	Build(ctx context.Context, srvExport *mcsv1alpha1.ServiceExport) (core.Stack, *model.TargetGroup, error)
 }
@@ -145,12 +149,12 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroupForServiceExportCre
 		return nil, fmt.Errorf("Failed to find corresponding k8sService %s, error :%w ", k8s.NamespacedName(t.serviceExport), err)
 	}
 
-	ipAddressType, err := buildTargetGroupIpAdressType(svc)
+	tgp, err := GetServiceExportTargetGroupPolicy(ctx, t.client, k8s.NamespacedName(t.serviceExport))
 	if err != nil {
 		return nil, err
 	}
 
-	tgp, err := GetAttachedPolicy(ctx, t.client, k8s.NamespacedName(t.serviceExport), &anv1alpha1.TargetGroupPolicy{})
+	ipAddressType, err := buildTargetGroupIpAdressType(svc, tgp)
 	if err != nil {
 		return nil, err
 	}
@@ -167,10 +171,18 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroupForServiceExportCre
 		}
 		healthCheckConfig = parseHealthCheckConfig(tgp)
 	}
+	if protocol == model.TargetGroupProtocolTCP {
+		protocolVersion = ""
+	}
+	tgType := buildTargetGroupType(tgp)
+	if tgType == model.TargetGroupTypeInstance {
+		// instance targets are registered by their ID, not by their IP
+		ipAddressType = ""
+	}
 
 	stackTG := model.NewTargetGroup(t.stack, targetGroupName, model.TargetGroupSpec{
 		Name: targetGroupName,
-		Type: model.TargetGroupTypeIP,
+		Type: tgType,
 		Config: model.TargetGroupConfig{
 			VpcID: config.VpcID,
 			// Fill in default HTTP port as we are using target port anyway.
@@ -183,6 +195,7 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroupForServiceExportCre
 			ProtocolVersion:     protocolVersion,
 			HealthCheckConfig:   healthCheckConfig,
 			IpAddressType:       ipAddressType,
+			SecondaryIpFamily:   isSecondaryIpFamily(svc, ipAddressType),
 		},
 	})
 
@@ -193,6 +206,7 @@ func (t *svcExportTargetGroupModelBuildTask) buildTargetGroupForServiceExportCre
 		"Protocol", stackTG.Spec.Config.Protocol,
 		"ProtocolVersion", stackTG.Spec.Config.ProtocolVersion,
 		"IpAddressType", stackTG.Spec.Config.IpAddressType,
+		"Type", stackTG.Spec.Type,
 		"HealthCheckConfig", stackTG.Spec.Config.HealthCheckConfig,
 	)
 
@@ -231,6 +245,20 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupsForRoute(
 ) error {
 	for _, rule := range t.route.Spec().Rules() {
 		for _, backendRef := range rule.BackendRefs() {
+			if !t.isBackendRefPermitted(backendRef) {
+				t.log.Infof("Skipping target group of backendRef %s of route %s-%s, no ReferenceGrant permits it",
+					backendRef.Name(), t.route.Name(), t.route.Namespace())
+				continue
+			}
+			if IsLatticeTargetGroupBackendRef(backendRef) {
+				// existing target groups are neither created nor deleted
+				if t.route.DeletionTimestamp().IsZero() {
+					if err := t.resolveLatticeTargetGroup(ctx, backendRef); err != nil {
+						return err
+					}
+				}
+				continue
+			}
 			tgName := t.buildTargetGroupName(ctx, backendRef)
 			tgSpec, err := t.buildTargetGroupSpec(ctx, client, backendRef)
 			if err != nil {
@@ -238,7 +266,7 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupsForRoute(
 			}
 
 			// add targetgroup to localcache for service reconcile to reference
-			if *backendRef.Kind() == "Service" {
+			if !tgSpec.Config.IsServiceImport {
 				t.datastore.AddTargetGroup(tgName, "", "", "", tgSpec.Config.IsServiceImport, t.route.Name())
 			} else {
 				// for serviceimport, the httproutename is ""
@@ -265,7 +293,8 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupsForRoute(
 func (t *latticeServiceModelBuildTask) buildTargetsForRoute(ctx context.Context) error {
 	for _, rule := range t.route.Spec().Rules() {
 		for _, backendRef := range rule.BackendRefs() {
-			if string(*backendRef.Kind()) == "ServiceImport" {
+			if string(*backendRef.Kind()) == "ServiceImport" || IsLatticeTargetGroupBackendRef(backendRef) ||
+				!t.isBackendRefPermitted(backendRef) {
 				continue
 			}
 
@@ -274,6 +303,13 @@ func (t *latticeServiceModelBuildTask) buildTargetsForRoute(ctx context.Context)
 				backendNamespace = string(*backendRef.Namespace())
 			}
 
+			if IsLambdaFunctionBackendRef(backendRef) {
+				if err := t.buildLambdaTargets(ctx, backendRef, backendNamespace); err != nil {
+					return err
+				}
+				continue
+			}
+
 			var port int32
 			if backendRef.Port() != nil {
 				port = int32(*backendRef.Port())
@@ -317,6 +353,10 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 	backendKind := string(*backendRef.Kind())
 	t.log.Debugf("buildTargetGroupSpec, kind %s", backendKind)
 
+	if IsLambdaFunctionBackendRef(backendRef) {
+		return t.buildLambdaTargetGroupSpec(ctx, backendRef, namespace)
+	}
+
 	var vpc = config.VpcID
 	var eksCluster = ""
 	var isServiceImport bool
@@ -329,6 +369,7 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 	}
 
 	ipAddressType := vpclattice.IpAddressTypeIpv4
+	var svc *corev1.Service
 
 	if backendKind == "ServiceImport" {
 		isServiceImport = true
@@ -338,7 +379,7 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 		}
 		serviceImport := &mcsv1alpha1.ServiceImport{}
 
-		if err := client.Get(context.TODO(), namespaceName, serviceImport); err == nil {
+		if err := client.Get(ctx, namespaceName, serviceImport); err == nil {
 			t.log.Debugf("Building target group spec using service import %s", namespaceName)
 			vpc = serviceImport.Annotations["multicluster.x-k8s.io/aws-vpc"]
 			eksCluster = serviceImport.Annotations["multicluster.x-k8s.io/aws-eks-cluster-name"]
@@ -364,7 +405,7 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 			Name:      string(backendRef.Name()),
 		}
 
-		svc := &corev1.Service{}
+		svc = &corev1.Service{}
 		if err := t.client.Get(ctx, serviceNamespaceName, svc); err != nil {
 			t.log.Infof("Error finding backend service %s due to %s", serviceNamespaceName, err)
 			if !isDeleted {
@@ -373,15 +414,6 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 				return model.TargetGroupSpec{}, err
 			}
 		}
-
-		var err error
-
-		ipAddressType, err = buildTargetGroupIpAdressType(svc)
-
-		// Ignore error for creation request
-		if !isDeleted && err != nil {
-			return model.TargetGroupSpec{}, err
-		}
 	}
 
 	tgName := latticestore.TargetGroupName(string(backendRef.Name()), namespace)
@@ -390,11 +422,21 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 		Namespace: namespace,
 		Name:      string(backendRef.Name()),
 	}
-	tgp, err := GetAttachedPolicy(ctx, t.client, refObjNamespacedName, &anv1alpha1.TargetGroupPolicy{})
+	tgp, err := GetRouteTargetGroupPolicy(ctx, t.client, t.route, refObjNamespacedName)
 
 	if err != nil {
 		return model.TargetGroupSpec{}, err
 	}
+
+	if svc != nil {
+		ipAddressType, err = buildTargetGroupIpAdressType(svc, tgp)
+
+		// Ignore error for deletion request
+		if !isDeleted && err != nil {
+			return model.TargetGroupSpec{}, err
+		}
+	}
+
 	protocol := "HTTP"
 	protocolVersion := vpclattice.TargetGroupProtocolVersionHttp1
 	var healthCheckConfig *vpclattice.HealthCheckConfig
@@ -414,9 +456,22 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 		protocolVersion = vpclattice.TargetGroupProtocolVersionGrpc
 	}
 
+	// TLS passthrough listeners only forward to TCP target groups.
+	if _, ok := t.route.(*core.TLSRoute); ok {
+		protocol = model.TargetGroupProtocolTCP
+	}
+	if protocol == model.TargetGroupProtocolTCP {
+		protocolVersion = ""
+	}
+	tgType := buildTargetGroupType(tgp)
+	if tgType == model.TargetGroupTypeInstance {
+		// instance targets are registered by their ID, not by their IP
+		ipAddressType = ""
+	}
+
 	return model.TargetGroupSpec{
 		Name: tgName,
-		Type: model.TargetGroupTypeIP,
+		Type: tgType,
 		Config: model.TargetGroupConfig{
 			VpcID:                 vpc,
 			EKSClusterName:        eksCluster,
@@ -430,19 +485,23 @@ func (t *latticeServiceModelBuildTask) buildTargetGroupSpec(
 			ProtocolVersion:       protocolVersion,
 			HealthCheckConfig:     healthCheckConfig,
 			// Fill in default HTTP port as we are using target port anyway.
-			Port:          80,
-			IpAddressType: ipAddressType,
+			Port:              80,
+			IpAddressType:     ipAddressType,
+			SecondaryIpFamily: isSecondaryIpFamily(svc, ipAddressType),
 		},
 		IsDeleted: isDeleted,
 	}, nil
 }
 
 func (t *latticeServiceModelBuildTask) buildTargetGroupName(_ context.Context, backendRef core.BackendRef) string {
+	namespace := t.route.Namespace()
 	if backendRef.Namespace() != nil {
-		return latticestore.TargetGroupName(string(backendRef.Name()), string(*backendRef.Namespace()))
-	} else {
-		return latticestore.TargetGroupName(string(backendRef.Name()), t.route.Namespace())
+		namespace = string(*backendRef.Namespace())
+	}
+	if IsLambdaFunctionBackendRef(backendRef) {
+		return latticestore.LambdaTargetGroupName(string(backendRef.Name()), namespace)
 	}
+	return latticestore.TargetGroupName(string(backendRef.Name()), namespace)
 }
 
 func parseHealthCheckConfig(tgp *anv1alpha1.TargetGroupPolicy) *vpclattice.HealthCheckConfig {
@@ -468,16 +527,54 @@ func parseHealthCheckConfig(tgp *anv1alpha1.TargetGroupPolicy) *vpclattice.Healt
 	}
 }
 
-func buildTargetGroupIpAdressType(svc *corev1.Service) (string, error) {
+// buildTargetGroupType returns the type of the target group of a Service, which registers the nodes
+// of the cluster as targets when the targetType of its TargetGroupPolicy is Instance
+func buildTargetGroupType(tgp *anv1alpha1.TargetGroupPolicy) model.TargetGroupType {
+	if tgp != nil && tgp.Spec.TargetType != nil && *tgp.Spec.TargetType == anv1alpha1.TargetTypeInstance {
+		return model.TargetGroupTypeInstance
+	}
+	return model.TargetGroupTypeIP
+}
+
+// buildTargetGroupIpAdressType returns the IP address type of the target group of svc. A dual-stack Service
+// uses the family chosen by its TargetGroupPolicy or its ip-address-type annotation, and its primary family otherwise
+func buildTargetGroupIpAdressType(svc *corev1.Service, tgp *anv1alpha1.TargetGroupPolicy) (string, error) {
 	ipFamilies := svc.Spec.IPFamilies
 
-	if len(ipFamilies) != 1 {
-		return "", errors.New("Lattice Target Group only supports single stack IP addresses")
+	if len(ipFamilies) == 0 {
+		return "", fmt.Errorf("service %s-%s has no ipFamilies", svc.Name, svc.Namespace)
+	}
+
+	var chosen string
+	if tgp != nil && tgp.Spec.IpAddressType != nil {
+		chosen = string(*tgp.Spec.IpAddressType)
+	} else if annotation, ok := svc.Annotations[LatticeIpAddressTypeAnnotation]; ok {
+		if annotation != vpclattice.IpAddressTypeIpv4 && annotation != vpclattice.IpAddressTypeIpv6 {
+			return "", fmt.Errorf("invalid value %s of annotation %s of service %s-%s, must be %s or %s", annotation,
+				LatticeIpAddressTypeAnnotation, svc.Name, svc.Namespace, vpclattice.IpAddressTypeIpv4, vpclattice.IpAddressTypeIpv6)
+		}
+		chosen = annotation
+	}
+
+	if chosen == "" {
+		// IpFamilies are ordered, the first one is the primary family
+		return ipAddressTypeOfFamily(ipFamilies[0])
 	}
 
-	// IpFamilies will always have at least 1 element
-	ipFamily := ipFamilies[0]
+	for _, ipFamily := range ipFamilies {
+		ipAddressType, err := ipAddressTypeOfFamily(ipFamily)
+		if err != nil {
+			return "", err
+		}
+		if ipAddressType == chosen {
+			return ipAddressType, nil
+		}
+	}
+	return "", fmt.Errorf("ip address type %s does not match the ipFamilies %v of service %s-%s",
+		chosen, ipFamilies, svc.Name, svc.Namespace)
+}
 
+func ipAddressTypeOfFamily(ipFamily corev1.IPFamily) (string, error) {
 	switch ipFamily {
 	case corev1.IPv4Protocol:
 		return vpclattice.IpAddressTypeIpv4, nil
@@ -488,6 +585,25 @@ func buildTargetGroupIpAdressType(svc *corev1.Service) (string, error) {
 	}
 }
 
+// isSecondaryIpFamily tells whether ipAddressType is the secondary IP family of a dual-stack Service
+func isSecondaryIpFamily(svc *corev1.Service, ipAddressType string) bool {
+	if svc == nil || ipAddressType == "" || len(svc.Spec.IPFamilies) < 2 {
+		return false
+	}
+	primary, err := ipAddressTypeOfFamily(svc.Spec.IPFamilies[0])
+	return err == nil && primary != ipAddressType
+}
+
+// GetRouteServiceIpAddressType returns the IP address type of the target group of svc created for route, taking
+// the TargetGroupPolicy in effect for it into account
+func GetRouteServiceIpAddressType(ctx context.Context, k8sClient client.Client, route core.Route, svc *corev1.Service) (string, error) {
+	tgp, err := GetRouteTargetGroupPolicy(ctx, k8sClient, route, k8s.NamespacedName(svc))
+	if err != nil {
+		return "", err
+	}
+	return buildTargetGroupIpAdressType(svc, tgp)
+}
+
 func GetServiceForBackendRef(ctx context.Context, client client.Client, route core.Route, backendRef core.BackendRef) (*corev1.Service, error) {
 	svc := &corev1.Service{}
 	key := types.NamespacedName{
@@ -17,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
@@ -171,7 +172,7 @@ func Test_TGModelByServicexportBuild(t *testing.T) {
 			wantIPv6TargetGroup: true,
 		},
 		{
-			name: "Failed to create IPv6 ServiceExport where service object with dual stack IpFamilies exists",
+			name: "Adding dual stack ServiceExport uses the primary IpFamily",
 			svcExport: &mcsv1alpha1.ServiceExport{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:       "export6",
@@ -191,11 +192,54 @@ func Test_TGModelByServicexportBuild(t *testing.T) {
 					},
 				},
 			},
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export6",
+			wantErrIsNil:  true,
+			wantIsDeleted: false,
+		},
+		{
+			name: "Adding dual stack ServiceExport with IPV6 annotation",
+			svcExport: &mcsv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "export7",
+					Namespace:  "ns1",
+					Finalizers: []string{"gateway.k8s.aws/resources"},
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "export7",
+					Namespace:   "ns1",
+					Annotations: map[string]string{LatticeIpAddressTypeAnnotation: "IPV6"},
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+					Ports: []corev1.ServicePort{
+						{},
+					},
+				},
+			},
+			wantErrIsNil:        true,
+			wantIsDeleted:       false,
+			wantIPv6TargetGroup: true,
+		},
+		{
+			name: "Failed to create ServiceExport with IPV6 annotation where service has IPv4 only",
+			svcExport: &mcsv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "export8",
+					Namespace:  "ns1",
+					Finalizers: []string{"gateway.k8s.aws/resources"},
+				},
+			},
+			svc: &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "export8",
+					Namespace:   "ns1",
+					Annotations: map[string]string{LatticeIpAddressTypeAnnotation: "IPV6"},
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+					Ports: []corev1.ServicePort{
+						{},
 					},
 				},
 			},
@@ -289,6 +333,7 @@ func Test_TGModelByHTTPRouteBuild(t *testing.T) {
 		wantName            string
 		wantIsDeleted       bool
 		wantIPv6TargetGroup bool
+		wantTCPTargetGroup  bool
 	}{
 		{
 			name: "Add LatticeService",
@@ -484,6 +529,42 @@ func Test_TGModelByHTTPRouteBuild(t *testing.T) {
 			wantErrIsNil:        true,
 			wantIPv6TargetGroup: true,
 		},
+		{
+			name: "TLSRoute uses TCP target group",
+			route: core.NewTLSRoute(gwv1alpha2.TLSRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "service6",
+					Namespace: "ns1",
+				},
+				Spec: gwv1alpha2.TLSRouteSpec{
+					CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+						ParentRefs: []gwv1beta1.ParentReference{
+							{
+								Name: "gateway1",
+							},
+						},
+					},
+					Rules: []gwv1alpha2.TLSRouteRule{
+						{
+							BackendRefs: []gwv1beta1.BackendRef{
+								{
+									BackendObjectReference: gwv1beta1.BackendObjectReference{
+										Name:      "service6-tg1",
+										Namespace: namespacePtr("ns1"),
+										Kind:      kindPtr("Service"),
+									},
+								},
+							},
+						},
+					},
+				},
+			}),
+			svcExist:           true,
+			wantError:          nil,
+			wantIsDeleted:      false,
+			wantErrIsNil:       true,
+			wantTCPTargetGroup: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -575,6 +656,13 @@ func Test_TGModelByHTTPRouteBuild(t *testing.T) {
 							} else {
 								assert.Equal(t, vpclattice.IpAddressTypeIpv4, ipAddressType)
 							}
+
+							if tt.wantTCPTargetGroup {
+								assert.Equal(t, model.TargetGroupProtocolTCP, tg.Spec.Config.Protocol)
+								assert.Equal(t, "", tg.Spec.Config.ProtocolVersion)
+							} else {
+								assert.Equal(t, vpclattice.TargetGroupProtocolHttp, tg.Spec.Config.Protocol)
+							}
 						} else {
 							// the routeName for serviceimport is ""
 							dsTG, err := ds.GetTargetGroup(tgName, "", true)
@@ -822,6 +910,23 @@ func Test_TGModelByHTTPRouteImportBuild(t *testing.T) {
 func Test_buildTargetGroupIpAdressType(t *testing.T) {
 	type args struct {
 		svc *corev1.Service
+		tgp *anv1alpha1.TargetGroupPolicy
+	}
+
+	dualStackSvc := func(annotations map[string]string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: annotations,
+			},
+			Spec: corev1.ServiceSpec{
+				IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+			},
+		}
+	}
+	ipAddressTypeTGP := func(ipAddressType anv1alpha1.IpAddressType) *anv1alpha1.TargetGroupPolicy {
+		return &anv1alpha1.TargetGroupPolicy{
+			Spec: anv1alpha1.TargetGroupPolicySpec{IpAddressType: &ipAddressType},
+		}
 	}
 
 	tests := []struct {
@@ -876,11 +981,49 @@ func Test_buildTargetGroupIpAdressType(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "dual stack IpFamilies get the primary family",
+			args:    args{svc: dualStackSvc(nil)},
+			want:    vpclattice.IpAddressTypeIpv6,
+			wantErr: false,
+		},
+		{
+			name:    "dual stack IpFamilies get the family of the annotation",
+			args:    args{svc: dualStackSvc(map[string]string{LatticeIpAddressTypeAnnotation: "IPV4"})},
+			want:    vpclattice.IpAddressTypeIpv4,
+			wantErr: false,
+		},
+		{
+			name: "TargetGroupPolicy takes precedence over the annotation",
+			args: args{
+				svc: dualStackSvc(map[string]string{LatticeIpAddressTypeAnnotation: "IPV6"}),
+				tgp: ipAddressTypeTGP(anv1alpha1.IpAddressTypeIPv4),
+			},
+			want:    vpclattice.IpAddressTypeIpv4,
+			wantErr: false,
+		},
+		{
+			name:    "invalid annotation get error",
+			args:    args{svc: dualStackSvc(map[string]string{LatticeIpAddressTypeAnnotation: "ipv6"})},
+			wantErr: true,
+		},
+		{
+			name: "family missing from IpFamilies get error",
+			args: args{
+				svc: &corev1.Service{
+					Spec: corev1.ServiceSpec{
+						IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol},
+					},
+				},
+				tgp: ipAddressTypeTGP(anv1alpha1.IpAddressTypeIPv6),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := buildTargetGroupIpAdressType(tt.args.svc)
+			got, err := buildTargetGroupIpAdressType(tt.args.svc, tt.args.tgp)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("buildTargetGroupIpAdressType() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -891,3 +1034,22 @@ func Test_buildTargetGroupIpAdressType(t *testing.T) {
 		})
 	}
 }
+
+func Test_isSecondaryIpFamily(t *testing.T) {
+	singleStackSvc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol},
+		},
+	}
+	dualStackSvc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			IPFamilies: []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol},
+		},
+	}
+	assert.False(t, isSecondaryIpFamily(singleStackSvc, vpclattice.IpAddressTypeIpv6),
+		"single-stack IPv6 Services keep the name of their target group")
+	assert.False(t, isSecondaryIpFamily(dualStackSvc, vpclattice.IpAddressTypeIpv6))
+	assert.True(t, isSecondaryIpFamily(dualStackSvc, vpclattice.IpAddressTypeIpv4))
+	assert.False(t, isSecondaryIpFamily(dualStackSvc, ""), "INSTANCE target groups have no IP address type")
+	assert.False(t, isSecondaryIpFamily(nil, vpclattice.IpAddressTypeIpv4))
+}
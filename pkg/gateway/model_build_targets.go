@@ -7,11 +7,15 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	pkg_aws "github.com/aws/aws-application-networking-k8s/pkg/aws"
 	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
 	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
@@ -150,26 +154,57 @@ func (t *latticeTargetsModelBuildTask) buildLatticeTargets(ctx context.Context)
 		skipMatch = true
 	}
 
+	var tgp *anv1alpha1.TargetGroupPolicy
+	if t.route != nil {
+		tgp, err = GetRouteTargetGroupPolicy(ctx, t.client, t.route, namespacedName)
+	} else if tg.ByServiceExport && t.routeName == "" {
+		tgp, err = GetServiceExportTargetGroupPolicy(ctx, t.client, namespacedName)
+	} else {
+		tgp, err = GetTargetGroupPolicy(ctx, t.client, namespacedName)
+	}
+	if err != nil {
+		return err
+	}
+
 	var targetList []model.Target
-	endpoints := &corev1.Endpoints{}
+	var endpoints []serviceEndpoint
 
 	if svc.DeletionTimestamp.IsZero() {
-		if err := t.client.Get(ctx, namespacedName, endpoints); err != nil {
-			return fmt.Errorf("build targets failed because K8S service %s does not exist", namespacedName)
-		}
-
-		for _, endPoint := range endpoints.Subsets {
-			for _, address := range endPoint.Addresses {
-				for _, port := range endPoint.Ports {
-					target := model.Target{
-						TargetIP: address.IP,
-						Port:     int64(port.Port),
-					}
-					// Note that the Endpoint's port name is from ServicePort, but the actual registered port
-					// is from Pods(targets).
-					if _, ok := servicePortNames[port.Name]; ok || skipMatch {
-						targetList = append(targetList, target)
-					}
+		addressType, err := endpointAddressType(svc, tgp)
+		if err != nil {
+			return err
+		}
+		endpoints, err = t.getServiceEndpoints(ctx, svc, addressType)
+		if err != nil {
+			return err
+		}
+	}
+
+	if svc.DeletionTimestamp.IsZero() && buildTargetGroupType(tgp) == model.TargetGroupTypeInstance {
+		targetList, err = t.buildInstanceTargets(ctx, svc, endpoints, definedPorts)
+		if err != nil {
+			return err
+		}
+	} else if svc.DeletionTimestamp.IsZero() {
+		// the same endpoint can briefly be in two slices while it moves between them
+		seen := make(map[model.Target]struct{})
+		for _, endpoint := range endpoints {
+			for _, port := range endpoint.ports {
+				if port.Port == nil {
+					continue
+				}
+				target := model.Target{
+					TargetIP: endpoint.Addresses[0],
+					Port:     int64(*port.Port),
+				}
+				// Note that the EndpointSlice's port name is from ServicePort, but the actual registered port
+				// is from Pods(targets).
+				if _, ok := servicePortNames[aws.StringValue(port.Name)]; !ok && !skipMatch {
+					continue
+				}
+				if _, ok := seen[target]; !ok {
+					seen[target] = struct{}{}
+					targetList = append(targetList, target)
 				}
 			}
 		}
@@ -187,6 +222,185 @@ func (t *latticeTargetsModelBuildTask) buildLatticeTargets(ctx context.Context)
 	return nil
 }
 
+// serviceEndpoint is an endpoint of an EndpointSlice with the ports of its slice
+type serviceEndpoint struct {
+	discoveryv1.Endpoint
+	ports []discoveryv1.EndpointPort
+}
+
+// endpointAddressType returns the address type of the EndpointSlices whose endpoints are registered as targets.
+// A dual-stack Service has slices of both families, only the family of its target group is registered.
+// Instance targets are registered by node, and a Service without ipFamilies has no known family,
+// their endpoints are not filtered.
+func endpointAddressType(svc *corev1.Service, tgp *anv1alpha1.TargetGroupPolicy) (discoveryv1.AddressType, error) {
+	if buildTargetGroupType(tgp) == model.TargetGroupTypeInstance || len(svc.Spec.IPFamilies) == 0 {
+		return "", nil
+	}
+	ipAddressType, err := buildTargetGroupIpAdressType(svc, tgp)
+	if err != nil {
+		return "", err
+	}
+	if ipAddressType == vpclattice.IpAddressTypeIpv6 {
+		return discoveryv1.AddressTypeIPv6, nil
+	}
+	return discoveryv1.AddressTypeIPv4, nil
+}
+
+// getServiceEndpoints returns the endpoints of the EndpointSlices of svc which should receive traffic.
+// Only slices of addressType are used, unless it is empty.
+// Terminating endpoints are left out so that they are deregistered, and drained while their pods shut down.
+// Pods waiting for their lattice-target-ready readiness gate are not ready yet, but their endpoints are returned.
+// When no endpoint is ready, e.g. while all the pods of the Service are replaced, the terminating endpoints
+// which are still serving are returned instead so that traffic is not dropped.
+func (t *latticeTargetsModelBuildTask) getServiceEndpoints(
+	ctx context.Context,
+	svc *corev1.Service,
+	addressType discoveryv1.AddressType,
+) ([]serviceEndpoint, error) {
+	sliceList := &discoveryv1.EndpointSliceList{}
+	if err := t.client.List(ctx, sliceList, client.InNamespace(svc.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: svc.Name}); err != nil {
+		return nil, fmt.Errorf("build targets failed because EndpointSlices of K8S service %s-%s cannot be listed, %w",
+			svc.Name, svc.Namespace, err)
+	}
+
+	var ready, servingTerminating []serviceEndpoint
+	for _, slice := range sliceList.Items {
+		if slice.AddressType == discoveryv1.AddressTypeFQDN {
+			continue
+		}
+		if addressType != "" && slice.AddressType != addressType {
+			continue
+		}
+		for _, endpoint := range slice.Endpoints {
+			if len(endpoint.Addresses) == 0 {
+				continue
+			}
+			conditions := endpoint.Conditions
+			// unknown conditions are interpreted as ready and serving
+			isTerminating := conditions.Terminating != nil && *conditions.Terminating
+			isReady := (conditions.Ready == nil || *conditions.Ready) && !isTerminating
+			isServing := conditions.Serving == nil || *conditions.Serving
+			if !isReady && !isTerminating && t.isPendingLatticeTargetReadiness(ctx, endpoint) {
+				// the pod waits for VPC Lattice to report its target healthy, which needs it to be registered
+				isReady = true
+			}
+			if isReady {
+				ready = append(ready, serviceEndpoint{Endpoint: endpoint, ports: slice.Ports})
+			} else if isTerminating && isServing {
+				servingTerminating = append(servingTerminating, serviceEndpoint{Endpoint: endpoint, ports: slice.Ports})
+			}
+		}
+	}
+
+	if len(ready) == 0 && len(servingTerminating) > 0 {
+		t.log.Debugf("Using %d terminating endpoints of service %s-%s which has no ready endpoint",
+			len(servingTerminating), svc.Name, svc.Namespace)
+		return servingTerminating, nil
+	}
+	return ready, nil
+}
+
+// isPendingLatticeTargetReadiness tells whether the pod of endpoint is only kept from being ready by
+// the lattice-target-ready readiness gate
+func (t *latticeTargetsModelBuildTask) isPendingLatticeTargetReadiness(ctx context.Context, endpoint discoveryv1.Endpoint) bool {
+	if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+		return false
+	}
+	pod := &corev1.Pod{}
+	key := types.NamespacedName{Namespace: endpoint.TargetRef.Namespace, Name: endpoint.TargetRef.Name}
+	if err := t.client.Get(ctx, key, pod); err != nil {
+		t.log.Debugf("Failed to get pod %s of endpoint, %s", key, err)
+		return false
+	}
+	return IsPendingLatticeTargetReadiness(pod)
+}
+
+// buildInstanceTargets builds a target for each NodePort of the Service on each ready node. With the Local
+// external traffic policy, nodes only forward traffic to pods on themselves, so only nodes with ready endpoints
+// are targets.
+func (t *latticeTargetsModelBuildTask) buildInstanceTargets(
+	ctx context.Context,
+	svc *corev1.Service,
+	endpoints []serviceEndpoint,
+	definedPorts map[int32]struct{},
+) ([]model.Target, error) {
+	var nodePorts []int32
+	for _, port := range svc.Spec.Ports {
+		if _, ok := definedPorts[port.Port]; !ok && len(definedPorts) > 0 {
+			continue
+		}
+		if port.NodePort == 0 {
+			return nil, fmt.Errorf("build targets failed because K8S service %s-%s has no NodePort for port %d, "+
+				"instance targets need a Service of type NodePort or LoadBalancer", svc.Name, svc.Namespace, port.Port)
+		}
+		nodePorts = append(nodePorts, port.NodePort)
+	}
+
+	var endpointNodes map[string]struct{}
+	if svc.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyTypeLocal {
+		endpointNodes = make(map[string]struct{})
+		for _, endpoint := range endpoints {
+			if endpoint.NodeName != nil {
+				endpointNodes[*endpoint.NodeName] = struct{}{}
+			}
+		}
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := t.client.List(ctx, nodes); err != nil {
+		return nil, fmt.Errorf("build targets failed because nodes cannot be listed, %w", err)
+	}
+
+	var targetList []model.Target
+	for _, node := range nodes.Items {
+		if !IsNodeReady(&node) {
+			continue
+		}
+		if _, ok := endpointNodes[node.Name]; !ok && endpointNodes != nil {
+			continue
+		}
+		instanceID, ok := getNodeInstanceID(&node)
+		if !ok {
+			t.log.Debugf("Skipping node %s without EC2 instance ID in its provider ID %s", node.Name, node.Spec.ProviderID)
+			continue
+		}
+		for _, nodePort := range nodePorts {
+			targetList = append(targetList, model.Target{
+				TargetIP: instanceID,
+				Port:     int64(nodePort),
+			})
+		}
+	}
+	return targetList, nil
+}
+
+// IsNodeReady tells whether node is ready and not being deleted, so that it can receive traffic
+func IsNodeReady(node *corev1.Node) bool {
+	if !node.DeletionTimestamp.IsZero() {
+		return false
+	}
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// getNodeInstanceID returns the EC2 instance ID from the provider ID of node, e.g. aws:///us-west-2a/i-0123456789abcdef0
+func getNodeInstanceID(node *corev1.Node) (string, bool) {
+	providerID := node.Spec.ProviderID
+	if !strings.HasPrefix(providerID, "aws://") {
+		return "", false
+	}
+	instanceID := providerID[strings.LastIndex(providerID, "/")+1:]
+	if !strings.HasPrefix(instanceID, "i-") {
+		return "", false
+	}
+	return instanceID, true
+}
+
 type latticeTargetsModelBuildTask struct {
 	log            gwlog.Logger
 	client         client.Client
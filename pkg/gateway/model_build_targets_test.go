@@ -11,13 +11,17 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
 	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
 
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
@@ -32,7 +36,7 @@ func Test_Targets(t *testing.T) {
 		srvExportName      string
 		srvExportNamespace string
 		port               int32
-		endPoints          []corev1.Endpoints
+		endpointSlices     []discoveryv1.EndpointSlice
 		svc                corev1.Service
 		serviceExport      mcsv1alpha1.ServiceExport
 		inDataStore        bool
@@ -41,25 +45,19 @@ func Test_Targets(t *testing.T) {
 		wantErrIsNil       bool
 		expectedTargetList []model.Target
 		route              core.Route
+		tgp                *anv1alpha1.TargetGroupPolicy
+		nodes              []corev1.Node
+		pods               []corev1.Pod
 	}{
 		{
 			name:               "Add all endpoints to build spec",
 			srvExportName:      "export1",
 			srvExportNamespace: "ns1",
 			port:               0,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export1",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Port: 8675}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export1",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -87,19 +85,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export1",
 			srvExportNamespace: "ns1",
 			port:               80,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export1",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 309}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export1",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(309)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -141,19 +130,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export1",
 			srvExportNamespace: "ns1",
 			port:               3090,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export1",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 3090}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export1",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(3090)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -203,19 +183,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export1",
 			srvExportNamespace: "ns1",
 			port:               0,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export1",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 309}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export1",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(309)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -236,7 +207,7 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export1",
 			srvExportNamespace: "ns1",
 			port:               0,
-			endPoints:          []corev1.Endpoints{},
+			endpointSlices:     []discoveryv1.EndpointSlice{},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: "ns1",
@@ -256,19 +227,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export2",
 			srvExportNamespace: "ns1",
 			port:               0,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export1",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 309}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export1",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(309)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -286,19 +248,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export3",
 			srvExportNamespace: "ns1",
 			port:               0,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export1",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 309}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export1",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(309)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -333,19 +286,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export5",
 			srvExportNamespace: "ns1",
 			port:               0,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export5",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 309}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export5",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(309)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -381,19 +325,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export6",
 			srvExportNamespace: "ns1",
 			port:               8675,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export6",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export6",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -421,19 +356,10 @@ func Test_Targets(t *testing.T) {
 			srvExportName:      "export7",
 			srvExportNamespace: "ns1",
 			port:               8750,
-			endPoints: []corev1.Endpoints{
-				{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: "ns1",
-						Name:      "export7",
-					},
-					Subsets: []corev1.EndpointSubset{
-						{
-							Addresses: []corev1.EndpointAddress{{IP: "10.10.1.1"}, {IP: "10.10.2.2"}},
-							Ports:     []corev1.EndpointPort{{Name: "a", Port: 8675}, {Name: "b", Port: 309}},
-						},
-					},
-				},
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export7",
+					[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}, {Name: pointer.String("b"), Port: pointer.Int32(309)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
 			},
 			svc: corev1.Service{
 				ObjectMeta: metav1.ObjectMeta{
@@ -447,6 +373,253 @@ func Test_Targets(t *testing.T) {
 			refByServiceExport: true,
 			wantErrIsNil:       false,
 		},
+		{
+			name:               "Add ready nodes with NodePort to build spec for instance target type",
+			srvExportName:      "export8",
+			srvExportNamespace: "ns1",
+			port:               80,
+			endpointSlices:     []discoveryv1.EndpointSlice{instanceTargetsEndpointSlice("export8", "node-1")},
+			svc:                instanceTargetsService("export8", corev1.ServiceExternalTrafficPolicyTypeCluster, 30080),
+			inDataStore:        true,
+			refByService:       true,
+			wantErrIsNil:       true,
+			tgp:                instanceTargetGroupPolicy("export8"),
+			nodes:              instanceTargetsNodes(),
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "i-00000000000000001",
+					Port:     30080,
+				},
+				{
+					TargetIP: "i-00000000000000002",
+					Port:     30080,
+				},
+			},
+		},
+		{
+			name:               "Only add nodes with endpoints for instance target type and Local traffic policy",
+			srvExportName:      "export9",
+			srvExportNamespace: "ns1",
+			port:               80,
+			endpointSlices:     []discoveryv1.EndpointSlice{instanceTargetsEndpointSlice("export9", "node-2")},
+			svc:                instanceTargetsService("export9", corev1.ServiceExternalTrafficPolicyTypeLocal, 30080),
+			inDataStore:        true,
+			refByService:       true,
+			wantErrIsNil:       true,
+			tgp:                instanceTargetGroupPolicy("export9"),
+			nodes:              instanceTargetsNodes(),
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "i-00000000000000002",
+					Port:     30080,
+				},
+			},
+		},
+		{
+			name:               "Service without NodePort for instance target type",
+			srvExportName:      "export10",
+			srvExportNamespace: "ns1",
+			port:               80,
+			endpointSlices:     []discoveryv1.EndpointSlice{instanceTargetsEndpointSlice("export10", "node-1")},
+			svc:                instanceTargetsService("export10", corev1.ServiceExternalTrafficPolicyTypeCluster, 0),
+			inDataStore:        true,
+			refByService:       true,
+			wantErrIsNil:       false,
+			tgp:                instanceTargetGroupPolicy("export10"),
+			nodes:              instanceTargetsNodes(),
+		},
+		{
+			name:               "Add endpoints of all EndpointSlices once",
+			srvExportName:      "export11",
+			srvExportNamespace: "ns1",
+			port:               0,
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export11",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}, {Addresses: []string{"10.10.2.2"}}}),
+				newEndpointSlice("export11",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.2.2"}}, {Addresses: []string{"10.10.3.3"}}}),
+				newEndpointSlice("other",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{{Addresses: []string{"10.10.4.4"}}}),
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export11",
+				},
+			},
+			inDataStore:  true,
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+				},
+				{
+					TargetIP: "10.10.2.2",
+					Port:     8675,
+				},
+				{
+					TargetIP: "10.10.3.3",
+					Port:     8675,
+				},
+			},
+		},
+		{
+			name:               "Only add ready endpoints while some are terminating",
+			srvExportName:      "export12",
+			srvExportNamespace: "ns1",
+			port:               0,
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export12",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{
+						{Addresses: []string{"10.10.1.1"}, Conditions: endpointConditions(true, true, false)},
+						{Addresses: []string{"10.10.2.2"}, Conditions: endpointConditions(false, true, true)},
+						{Addresses: []string{"10.10.3.3"}, Conditions: endpointConditions(false, false, false)},
+					}),
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export12",
+				},
+			},
+			inDataStore:  true,
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+				},
+			},
+		},
+		{
+			name:               "Add serving terminating endpoints when no endpoint is ready",
+			srvExportName:      "export13",
+			srvExportNamespace: "ns1",
+			port:               0,
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export13",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{
+						{Addresses: []string{"10.10.1.1"}, Conditions: endpointConditions(false, true, true)},
+						{Addresses: []string{"10.10.2.2"}, Conditions: endpointConditions(false, false, true)},
+						{Addresses: []string{"10.10.3.3"}, Conditions: endpointConditions(false, false, false)},
+					}),
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export13",
+				},
+			},
+			inDataStore:  true,
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+				},
+			},
+		},
+		{
+			name:               "Only add endpoints of the family of the annotation of a dual stack service",
+			srvExportName:      "export14",
+			srvExportNamespace: "ns1",
+			port:               0,
+			endpointSlices:     dualStackEndpointSlices("export14"),
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "ns1",
+					Name:        "export14",
+					Annotations: map[string]string{LatticeIpAddressTypeAnnotation: "IPV6"},
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+				},
+			},
+			inDataStore:  true,
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "2001:db8::1",
+					Port:     8675,
+				},
+			},
+		},
+		{
+			name:               "Only add endpoints of the primary family of a dual stack service",
+			srvExportName:      "export15",
+			srvExportNamespace: "ns1",
+			port:               0,
+			endpointSlices:     dualStackEndpointSlices("export15"),
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export15",
+				},
+				Spec: corev1.ServiceSpec{
+					IPFamilies: []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol},
+				},
+			},
+			inDataStore:  true,
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+				},
+			},
+		},
+		{
+			name:               "Add not ready endpoints of pods waiting for the lattice readiness gate",
+			srvExportName:      "export16",
+			srvExportNamespace: "ns1",
+			port:               0,
+			endpointSlices: []discoveryv1.EndpointSlice{
+				newEndpointSlice("export16",
+					[]discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}},
+					[]discoveryv1.Endpoint{
+						{
+							Addresses:  []string{"10.10.1.1"},
+							Conditions: endpointConditions(false, false, false),
+							TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "ns1", Name: "gated"},
+						},
+						{
+							Addresses:  []string{"10.10.2.2"},
+							Conditions: endpointConditions(false, false, false),
+							TargetRef:  &corev1.ObjectReference{Kind: "Pod", Namespace: "ns1", Name: "not-ready"},
+						},
+					}),
+			},
+			svc: corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "ns1",
+					Name:      "export16",
+				},
+			},
+			pods: []corev1.Pod{
+				*newReadinessGatePod("gated", true),
+				*newReadinessGatePod("not-ready", false),
+			},
+			inDataStore:  true,
+			refByService: true,
+			wantErrIsNil: true,
+			expectedTargetList: []model.Target{
+				{
+					TargetIP: "10.10.1.1",
+					Port:     8675,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -458,14 +631,25 @@ func Test_Targets(t *testing.T) {
 			k8sSchema := runtime.NewScheme()
 			k8sSchema.AddKnownTypes(mcsv1alpha1.SchemeGroupVersion, &mcsv1alpha1.ServiceExport{})
 			clientgoscheme.AddToScheme(k8sSchema)
+			anv1alpha1.AddToScheme(k8sSchema)
 			k8sClient := testclient.NewFakeClientWithScheme(k8sSchema)
 
+			if tt.tgp != nil {
+				assert.NoError(t, k8sClient.Create(ctx, tt.tgp.DeepCopy()))
+			}
+			for _, node := range tt.nodes {
+				assert.NoError(t, k8sClient.Create(ctx, node.DeepCopy()))
+			}
+			for _, pod := range tt.pods {
+				assert.NoError(t, k8sClient.Create(ctx, pod.DeepCopy()))
+			}
+
 			if !reflect.DeepEqual(tt.serviceExport, mcsv1alpha1.ServiceExport{}) {
 				assert.NoError(t, k8sClient.Create(ctx, tt.serviceExport.DeepCopy()))
 			}
 
-			if len(tt.endPoints) > 0 {
-				assert.NoError(t, k8sClient.Create(ctx, tt.endPoints[0].DeepCopy()))
+			for _, slice := range tt.endpointSlices {
+				assert.NoError(t, k8sClient.Create(ctx, slice.DeepCopy()))
 			}
 
 			assert.NoError(t, k8sClient.Create(ctx, tt.svc.DeepCopy()))
@@ -507,8 +691,8 @@ func Test_Targets(t *testing.T) {
 				assert.Equal(t, tt.srvExportName, targetTask.latticeTargets.Spec.Name)
 				assert.Equal(t, tt.srvExportNamespace, targetTask.latticeTargets.Spec.Namespace)
 
-				// verify targets, ports are built correctly
-				assert.Equal(t, tt.expectedTargetList, targetTask.latticeTargets.Spec.TargetIPList)
+				// verify targets, ports are built correctly, in any order since EndpointSlices have generated names
+				assert.ElementsMatch(t, tt.expectedTargetList, targetTask.latticeTargets.Spec.TargetIPList)
 
 			} else {
 				assert.NotNil(t, err)
@@ -516,3 +700,96 @@ func Test_Targets(t *testing.T) {
 		})
 	}
 }
+
+func instanceTargetGroupPolicy(svcName string) *anv1alpha1.TargetGroupPolicy {
+	targetType := anv1alpha1.TargetTypeInstance
+	return &anv1alpha1.TargetGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      svcName,
+		},
+		Spec: anv1alpha1.TargetGroupPolicySpec{
+			TargetType: &targetType,
+			TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+				PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+					Kind: "Service",
+					Name: gwv1alpha2.ObjectName(svcName),
+				},
+			},
+		},
+	}
+}
+
+func instanceTargetsService(name string, trafficPolicy corev1.ServiceExternalTrafficPolicyType, nodePort int32) corev1.Service {
+	return corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      name,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:                  corev1.ServiceTypeNodePort,
+			ExternalTrafficPolicy: trafficPolicy,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "a",
+					Port:       80,
+					TargetPort: intstr.FromInt(8675),
+					NodePort:   nodePort,
+				},
+			},
+		},
+	}
+}
+
+func instanceTargetsEndpointSlice(svcName string, nodeName string) discoveryv1.EndpointSlice {
+	return newEndpointSlice(svcName,
+		[]discoveryv1.EndpointPort{{Name: pointer.String("a"), Port: pointer.Int32(8675)}},
+		[]discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}, NodeName: &nodeName}})
+}
+
+func instanceTargetsNodes() []corev1.Node {
+	node := func(name, providerID string, ready corev1.ConditionStatus) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       corev1.NodeSpec{ProviderID: providerID},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+			},
+		}
+	}
+	return []corev1.Node{
+		node("node-1", "aws:///us-west-2a/i-00000000000000001", corev1.ConditionTrue),
+		node("node-2", "aws:///us-west-2b/i-00000000000000002", corev1.ConditionTrue),
+		node("node-3", "aws:///us-west-2c/i-00000000000000003", corev1.ConditionFalse),
+		node("node-4", "kind://docker/kind/node-4", corev1.ConditionTrue),
+	}
+}
+
+func newEndpointSlice(svcName string, ports []discoveryv1.EndpointPort, endpoints []discoveryv1.Endpoint) discoveryv1.EndpointSlice {
+	return discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    "ns1",
+			GenerateName: svcName + "-",
+			Labels:       map[string]string{discoveryv1.LabelServiceName: svcName},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints:   endpoints,
+		Ports:       ports,
+	}
+}
+
+func dualStackEndpointSlices(svcName string) []discoveryv1.EndpointSlice {
+	ports := []discoveryv1.EndpointPort{{Port: pointer.Int32(8675)}}
+	ipv4Slice := newEndpointSlice(svcName, ports, []discoveryv1.Endpoint{{Addresses: []string{"10.10.1.1"}}})
+	ipv6Slice := newEndpointSlice(svcName, ports, []discoveryv1.Endpoint{{Addresses: []string{"2001:db8::1"}}})
+	ipv6Slice.AddressType = discoveryv1.AddressTypeIPv6
+	return []discoveryv1.EndpointSlice{ipv4Slice, ipv6Slice}
+}
+
+func endpointConditions(ready, serving, terminating bool) discoveryv1.EndpointConditions {
+	return discoveryv1.EndpointConditions{
+		Ready:       &ready,
+		Serving:     &serving,
+		Terminating: &terminating,
+	}
+}
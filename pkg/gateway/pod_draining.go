@@ -0,0 +1,59 @@
+package gateway
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+const (
+	// LatticeTargetDrainingFinalizer keeps terminating pods until VPC Lattice drained their targets
+	LatticeTargetDrainingFinalizer = "application-networking.k8s.aws/lattice-target-draining"
+)
+
+// GetDrainingTimeout returns the longest draining timeout of the TargetGroupPolicies in effect for the IP target
+// groups of services, zero when none of them sets one. Instance target groups register nodes, not pods.
+func GetDrainingTimeout(ctx context.Context, k8sClient client.Client, services []*corev1.Service) (time.Duration, error) {
+	var timeout time.Duration
+	for _, svc := range services {
+		policies, err := getServiceTargetGroupPolicies(ctx, k8sClient, k8s.NamespacedName(svc))
+		if err != nil {
+			return 0, err
+		}
+		for _, policy := range policies {
+			tgp := policy.tgp
+			if tgp == nil || tgp.Spec.DrainingTimeoutSeconds == nil || buildTargetGroupType(tgp) != model.TargetGroupTypeIP {
+				continue
+			}
+			if tgTimeout := time.Duration(*tgp.Spec.DrainingTimeoutSeconds) * time.Second; tgTimeout > timeout {
+				timeout = tgTimeout
+			}
+		}
+	}
+	return timeout, nil
+}
+
+// IsPodTargetRegistered tells whether a target of a pod with podIPs is still listed in the target groups of
+// targetsByTargetGroup, by target group ID. Deregistered targets are listed as DRAINING until VPC Lattice
+// completes their in-flight requests.
+func IsPodTargetRegistered(podIPs []string, targetsByTargetGroup map[string][]*vpclattice.TargetSummary) bool {
+	isPodIP := make(map[string]struct{})
+	for _, ip := range podIPs {
+		isPodIP[ip] = struct{}{}
+	}
+	for _, targets := range targetsByTargetGroup {
+		for _, target := range targets {
+			if _, ok := isPodIP[aws.StringValue(target.Id)]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
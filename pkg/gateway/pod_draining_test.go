@@ -0,0 +1,87 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+)
+
+func Test_GetDrainingTimeout(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := newTargetGroupPolicyTestClient()
+
+	services := make(map[string]*corev1.Service)
+	for _, name := range []string{"service", "backend", "instance-backend", "exported", "no-tgp"} {
+		services[name] = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"}}
+		if name == "exported" {
+			assert.NoError(t, k8sClient.Create(ctx, &mcsv1alpha1.ServiceExport{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			}))
+			continue
+		}
+		route := newTargetGroupPolicyTestRoute(name)
+		route.Name = name + "-route"
+		assert.NoError(t, k8sClient.Create(ctx, route))
+	}
+
+	instance := anv1alpha1.TargetTypeInstance
+	for _, tgp := range []*anv1alpha1.TargetGroupPolicy{
+		newTargetGroupPolicy("service", time.Hour, "", "Service", "service",
+			anv1alpha1.TargetGroupPolicySpec{DrainingTimeoutSeconds: aws.Int64(30)}),
+		withSectionName(newTargetGroupPolicy("backend", time.Hour, gwv1beta1.GroupName, "HTTPRoute", "backend-route",
+			anv1alpha1.TargetGroupPolicySpec{DrainingTimeoutSeconds: aws.Int64(120)}), "backend"),
+		// instance target groups register nodes, their timeout does not apply to pods
+		withSectionName(newTargetGroupPolicy("instance-backend", time.Hour, gwv1beta1.GroupName, "HTTPRoute", "instance-backend-route",
+			anv1alpha1.TargetGroupPolicySpec{DrainingTimeoutSeconds: aws.Int64(600), TargetType: &instance}), "instance-backend"),
+		newTargetGroupPolicy("exported", time.Hour, mcsv1alpha1.GroupName, "ServiceExport", "exported",
+			anv1alpha1.TargetGroupPolicySpec{DrainingTimeoutSeconds: aws.Int64(90)}),
+	} {
+		assert.NoError(t, k8sClient.Create(ctx, tgp))
+	}
+
+	tests := []struct {
+		services []string
+		want     time.Duration
+	}{
+		{services: []string{"service", "backend", "instance-backend", "exported", "no-tgp"}, want: 120 * time.Second},
+		{services: []string{"service", "exported"}, want: 90 * time.Second},
+		{services: []string{"service", "no-tgp"}, want: 30 * time.Second},
+		{services: []string{"instance-backend", "no-tgp"}, want: 0},
+		{services: nil, want: 0},
+	}
+	for _, tt := range tests {
+		var svcs []*corev1.Service
+		for _, name := range tt.services {
+			svcs = append(svcs, services[name])
+		}
+		timeout, err := GetDrainingTimeout(ctx, k8sClient, svcs)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, timeout, "services %v", tt.services)
+	}
+}
+
+func Test_IsPodTargetRegistered(t *testing.T) {
+	target := func(ip string, status string) *vpclattice.TargetSummary {
+		return &vpclattice.TargetSummary{Id: aws.String(ip), Port: aws.Int64(8080), Status: aws.String(status)}
+	}
+	podIPs := []string{"10.0.0.1", "2001:db8::1"}
+
+	assert.False(t, IsPodTargetRegistered(podIPs, nil))
+	assert.False(t, IsPodTargetRegistered(podIPs, map[string][]*vpclattice.TargetSummary{
+		"tg-1": {target("10.0.0.2", vpclattice.TargetStatusHealthy)},
+	}))
+	assert.True(t, IsPodTargetRegistered(podIPs, map[string][]*vpclattice.TargetSummary{
+		"tg-1": {target("10.0.0.2", vpclattice.TargetStatusHealthy)},
+		"tg-2": {target("2001:db8::1", vpclattice.TargetStatusDraining)},
+	}))
+}
@@ -0,0 +1,225 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+const (
+	// LatticeTargetReadyConditionType is the readiness gate of pods which become ready only once VPC Lattice
+	// reports them healthy in the target groups of their Services
+	LatticeTargetReadyConditionType corev1.PodConditionType = "application-networking.k8s.aws/lattice-target-ready"
+
+	LatticeTargetReadyReasonHealthy    = "LatticeTargetHealthy"
+	LatticeTargetReadyReasonNotHealthy = "LatticeTargetNotHealthy"
+)
+
+// HasLatticeTargetReadinessGate tells whether pod has the lattice-target-ready readiness gate
+func HasLatticeTargetReadinessGate(pod *corev1.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == LatticeTargetReadyConditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetPodCondition returns the condition of pod with conditionType, nil if pod does not have it
+func GetPodCondition(pod *corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// IsPodContainersReady tells whether all containers of pod are ready, pods with readiness gates
+// are not ready before their gates are
+func IsPodContainersReady(pod *corev1.Pod) bool {
+	condition := GetPodCondition(pod, corev1.ContainersReady)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// IsPendingLatticeTargetReadiness tells whether pod is only kept from being ready by the lattice-target-ready
+// readiness gate. Its endpoints are registered as targets nevertheless, so that VPC Lattice can health check them.
+func IsPendingLatticeTargetReadiness(pod *corev1.Pod) bool {
+	if !HasLatticeTargetReadinessGate(pod) || !pod.DeletionTimestamp.IsZero() || !IsPodContainersReady(pod) {
+		return false
+	}
+	condition := GetPodCondition(pod, LatticeTargetReadyConditionType)
+	return condition == nil || condition.Status != corev1.ConditionTrue
+}
+
+// GetPodTargetServices returns the Services selecting pod with a target group which registers the IPs of their
+// endpoints, target groups with instance targets register nodes instead
+func GetPodTargetServices(ctx context.Context, k8sClient client.Client, pod *corev1.Pod) ([]*corev1.Service, error) {
+	svcList := &corev1.ServiceList{}
+	if err := k8sClient.List(ctx, svcList, client.InNamespace(pod.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list services of pod %s-%s, %w", pod.Name, pod.Namespace, err)
+	}
+
+	var services []*corev1.Service
+	for i := range svcList.Items {
+		svc := &svcList.Items[i]
+		if len(svc.Spec.Selector) == 0 || !labels.SelectorFromSet(svc.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		tgTypes, err := GetServiceTargetGroupTypes(ctx, k8sClient, k8s.NamespacedName(svc))
+		if err != nil {
+			return nil, err
+		}
+		for _, tgType := range tgTypes {
+			if tgType == model.TargetGroupTypeIP {
+				services = append(services, svc)
+				break
+			}
+		}
+	}
+	return services, nil
+}
+
+// GetServiceTargetGroupTypes returns the types of the target groups of the Service svcName, which each take the
+// TargetGroupPolicy in effect for them: the ones of its routes by route name, like the datastore records them, and
+// the one of its ServiceExport by the empty name. Target groups of routes with the same name share their record,
+// they are IP target groups when one of them is.
+func GetServiceTargetGroupTypes(ctx context.Context, k8sClient client.Client, svcName types.NamespacedName) (map[string]model.TargetGroupType, error) {
+	policies, err := getServiceTargetGroupPolicies(ctx, k8sClient, svcName)
+	if err != nil {
+		return nil, err
+	}
+	tgTypes := make(map[string]model.TargetGroupType)
+	for _, policy := range policies {
+		if tgTypes[policy.routeName] != model.TargetGroupTypeIP {
+			tgTypes[policy.routeName] = buildTargetGroupType(policy.tgp)
+		}
+	}
+	return tgTypes, nil
+}
+
+// serviceTargetGroupPolicy is the TargetGroupPolicy in effect for a target group of a Service, nil if there is none
+type serviceTargetGroupPolicy struct {
+	// routeName is the name of the route the target group is created for, empty for the one of the ServiceExport
+	routeName string
+	tgp       *anv1alpha1.TargetGroupPolicy
+}
+
+// getServiceTargetGroupPolicies returns the TargetGroupPolicies in effect for the target groups of the routes and of
+// the ServiceExport of the Service svcName
+func getServiceTargetGroupPolicies(ctx context.Context, k8sClient client.Client, svcName types.NamespacedName) ([]serviceTargetGroupPolicy, error) {
+	routes, err := core.ListAllRoutes(ctx, k8sClient, client.MatchingFields{RouteServiceBackendIndex: svcName.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list routes of service %s, %w", svcName, err)
+	}
+
+	var policies []serviceTargetGroupPolicy
+	for _, route := range routes {
+		tgp, err := GetRouteTargetGroupPolicy(ctx, k8sClient, route, svcName)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, serviceTargetGroupPolicy{routeName: route.Name(), tgp: tgp})
+	}
+
+	if err := k8sClient.Get(ctx, svcName, &mcsv1alpha1.ServiceExport{}); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return policies, nil
+		}
+		return nil, fmt.Errorf("failed to get service export %s, %w", svcName, err)
+	}
+	tgp, err := GetServiceExportTargetGroupPolicy(ctx, k8sClient, svcName)
+	if err != nil {
+		return nil, err
+	}
+	return append(policies, serviceTargetGroupPolicy{tgp: tgp}), nil
+}
+
+// GetLatticeTargetReadiness tells whether the targets of a pod with podIPs are registered and healthy in all the
+// target groups of targetsByTargetGroup, by target group ID, with a message explaining why the pod is not ready.
+// Targets of target groups with health checks disabled are unavailable, and considered healthy.
+func GetLatticeTargetReadiness(podIPs []string, targetsByTargetGroup map[string][]*vpclattice.TargetSummary) (bool, string) {
+	if len(targetsByTargetGroup) == 0 {
+		return false, "pod is not a target of any VPC Lattice target group yet"
+	}
+
+	isPodIP := make(map[string]struct{})
+	for _, ip := range podIPs {
+		isPodIP[ip] = struct{}{}
+	}
+
+	var tgIDs []string
+	for tgID := range targetsByTargetGroup {
+		tgIDs = append(tgIDs, tgID)
+	}
+	sort.Strings(tgIDs)
+
+	for _, tgID := range tgIDs {
+		registered := false
+		for _, target := range targetsByTargetGroup[tgID] {
+			if _, ok := isPodIP[aws.StringValue(target.Id)]; !ok {
+				continue
+			}
+			registered = true
+			status := aws.StringValue(target.Status)
+			if status != vpclattice.TargetStatusHealthy && status != vpclattice.TargetStatusUnavailable {
+				return false, fmt.Sprintf("target %s:%d is %s in target group %s",
+					aws.StringValue(target.Id), aws.Int64Value(target.Port), status, tgID)
+			}
+		}
+		if !registered {
+			return false, fmt.Sprintf("pod is not registered to target group %s yet", tgID)
+		}
+	}
+	return true, fmt.Sprintf("pod is healthy in target groups %v", tgIDs)
+}
+
+// SetLatticeTargetReadyCondition sets the lattice-target-ready condition of pod, and tells whether it changed
+func SetLatticeTargetReadyCondition(pod *corev1.Pod, ready bool, message string, now metav1.Time) bool {
+	status := corev1.ConditionFalse
+	reason := LatticeTargetReadyReasonNotHealthy
+	if ready {
+		status = corev1.ConditionTrue
+		reason = LatticeTargetReadyReasonHealthy
+	}
+
+	condition := GetPodCondition(pod, LatticeTargetReadyConditionType)
+	if condition == nil {
+		pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+			Type:               LatticeTargetReadyConditionType,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastProbeTime:      now,
+			LastTransitionTime: now,
+		})
+		return true
+	}
+	if condition.Status == status && condition.Message == message {
+		return false
+	}
+	if condition.Status != status {
+		condition.LastTransitionTime = now
+	}
+	condition.Status = status
+	condition.Reason = reason
+	condition.Message = message
+	condition.LastProbeTime = now
+	return true
+}
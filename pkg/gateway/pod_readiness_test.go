@@ -0,0 +1,229 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/vpclattice"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+)
+
+func newReadinessGatePod(name string, containersReady bool, gateStatus ...corev1.ConditionStatus) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns1",
+			Labels:    map[string]string{"app": "inventory"},
+		},
+		Spec: corev1.PodSpec{
+			ReadinessGates: []corev1.PodReadinessGate{{ConditionType: LatticeTargetReadyConditionType}},
+		},
+	}
+	containersReadyStatus := corev1.ConditionFalse
+	if containersReady {
+		containersReadyStatus = corev1.ConditionTrue
+	}
+	pod.Status.Conditions = append(pod.Status.Conditions,
+		corev1.PodCondition{Type: corev1.ContainersReady, Status: containersReadyStatus})
+	for _, status := range gateStatus {
+		pod.Status.Conditions = append(pod.Status.Conditions,
+			corev1.PodCondition{Type: LatticeTargetReadyConditionType, Status: status})
+	}
+	return pod
+}
+
+func Test_IsPendingLatticeTargetReadiness(t *testing.T) {
+	withoutGate := newReadinessGatePod("pod", true)
+	withoutGate.Spec.ReadinessGates = nil
+
+	deleted := newReadinessGatePod("pod", true)
+	now := metav1.Now()
+	deleted.DeletionTimestamp = &now
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+		want bool
+	}{
+		{name: "containers ready, gate not set yet", pod: newReadinessGatePod("pod", true), want: true},
+		{name: "containers ready, gate not ready", pod: newReadinessGatePod("pod", true, corev1.ConditionFalse), want: true},
+		{name: "gate ready", pod: newReadinessGatePod("pod", true, corev1.ConditionTrue), want: false},
+		{name: "containers not ready", pod: newReadinessGatePod("pod", false), want: false},
+		{name: "no readiness gate", pod: withoutGate, want: false},
+		{name: "pod deleted", pod: deleted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPendingLatticeTargetReadiness(tt.pod))
+		})
+	}
+}
+
+func Test_GetLatticeTargetReadiness(t *testing.T) {
+	target := func(ip string, status string) *vpclattice.TargetSummary {
+		return &vpclattice.TargetSummary{Id: aws.String(ip), Port: aws.Int64(8080), Status: aws.String(status)}
+	}
+	podIPs := []string{"10.0.0.1"}
+
+	tests := []struct {
+		name    string
+		targets map[string][]*vpclattice.TargetSummary
+		want    bool
+	}{
+		{
+			name: "no target group",
+			want: false,
+		},
+		{
+			name: "healthy in all target groups",
+			targets: map[string][]*vpclattice.TargetSummary{
+				"tg-1": {target("10.0.0.1", vpclattice.TargetStatusHealthy)},
+				"tg-2": {target("10.0.0.1", vpclattice.TargetStatusUnavailable)},
+			},
+			want: true,
+		},
+		{
+			name: "not registered to a target group yet",
+			targets: map[string][]*vpclattice.TargetSummary{
+				"tg-1": {target("10.0.0.1", vpclattice.TargetStatusHealthy)},
+				"tg-2": {target("10.0.0.2", vpclattice.TargetStatusHealthy)},
+			},
+			want: false,
+		},
+		{
+			name: "still health checked",
+			targets: map[string][]*vpclattice.TargetSummary{
+				"tg-1": {target("10.0.0.1", vpclattice.TargetStatusInitial)},
+			},
+			want: false,
+		},
+		{
+			name: "unhealthy",
+			targets: map[string][]*vpclattice.TargetSummary{
+				"tg-1": {target("10.0.0.1", vpclattice.TargetStatusUnhealthy)},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, message := GetLatticeTargetReadiness(podIPs, tt.targets)
+			assert.Equal(t, tt.want, ready)
+			assert.NotEmpty(t, message)
+		})
+	}
+}
+
+func Test_SetLatticeTargetReadyCondition(t *testing.T) {
+	pod := newReadinessGatePod("pod", true)
+	then := metav1.Unix(1000, 0)
+	now := metav1.Unix(2000, 0)
+
+	assert.True(t, SetLatticeTargetReadyCondition(pod, false, "pod is not registered", then))
+	condition := GetPodCondition(pod, LatticeTargetReadyConditionType)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, LatticeTargetReadyReasonNotHealthy, condition.Reason)
+
+	assert.False(t, SetLatticeTargetReadyCondition(pod, false, "pod is not registered", now))
+
+	assert.True(t, SetLatticeTargetReadyCondition(pod, true, "pod is healthy", now))
+	condition = GetPodCondition(pod, LatticeTargetReadyConditionType)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, LatticeTargetReadyReasonHealthy, condition.Reason)
+	assert.Equal(t, now, condition.LastTransitionTime)
+	assert.Len(t, pod.Status.Conditions, 2)
+}
+
+func Test_GetPodTargetServices(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := newTargetGroupPolicyTestClient()
+
+	for name, selector := range map[string]map[string]string{
+		"selecting":        {"app": "inventory"},
+		"other":            {"app": "other"},
+		"no-selector":      nil,
+		"no-target-group":  {"app": "inventory"},
+		"instance-svc":     {"app": "inventory"},
+		"instance-backend": {"app": "inventory"},
+		"ip-backend":       {"app": "inventory"},
+		"exported":         {"app": "inventory"},
+		"instance-export":  {"app": "inventory"},
+	} {
+		assert.NoError(t, k8sClient.Create(ctx, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec:       corev1.ServiceSpec{Selector: selector},
+		}))
+	}
+	for _, svcName := range []string{"selecting", "other", "no-selector", "instance-svc", "instance-backend", "ip-backend"} {
+		route := newTargetGroupPolicyTestRoute(svcName)
+		route.Name = svcName + "-route"
+		assert.NoError(t, k8sClient.Create(ctx, route))
+	}
+	for _, svcName := range []string{"exported", "instance-export"} {
+		assert.NoError(t, k8sClient.Create(ctx, &mcsv1alpha1.ServiceExport{
+			ObjectMeta: metav1.ObjectMeta{Name: svcName, Namespace: "ns1"},
+		}))
+	}
+
+	instance := anv1alpha1.TargetTypeInstance
+	ip := anv1alpha1.TargetTypeIP
+	for _, tgp := range []*anv1alpha1.TargetGroupPolicy{
+		instanceTargetGroupPolicy("instance-svc"),
+		instanceTargetGroupPolicy("ip-backend"),
+		withSectionName(newTargetGroupPolicy("instance-backend", time.Hour, gwv1beta1.GroupName, "HTTPRoute",
+			"instance-backend-route", anv1alpha1.TargetGroupPolicySpec{TargetType: &instance}), "instance-backend"),
+		withSectionName(newTargetGroupPolicy("ip-backend-route", time.Hour, gwv1beta1.GroupName, "HTTPRoute",
+			"ip-backend-route", anv1alpha1.TargetGroupPolicySpec{TargetType: &ip}), "ip-backend"),
+		newTargetGroupPolicy("instance-export", time.Hour, mcsv1alpha1.GroupName, "ServiceExport",
+			"instance-export", anv1alpha1.TargetGroupPolicySpec{TargetType: &instance}),
+	} {
+		assert.NoError(t, k8sClient.Create(ctx, tgp))
+	}
+
+	services, err := GetPodTargetServices(ctx, k8sClient, newReadinessGatePod("pod", true))
+	assert.NoError(t, err)
+	var names []string
+	for _, svc := range services {
+		names = append(names, svc.Name)
+	}
+	assert.ElementsMatch(t, []string{"selecting", "ip-backend", "exported"}, names)
+}
+
+func Test_GetServiceTargetGroupTypes(t *testing.T) {
+	ctx := context.Background()
+	svcName := types.NamespacedName{Namespace: "ns1", Name: "svc"}
+
+	instance := anv1alpha1.TargetTypeInstance
+	ipRoute := newTargetGroupPolicyTestRoute("svc")
+	ipRoute.Name = "ip-route"
+	instanceRoute := newTargetGroupPolicyTestRoute("svc")
+	instanceRoute.Name = "instance-route"
+	k8sClient := newTargetGroupPolicyTestClient(
+		ipRoute,
+		instanceRoute,
+		newTargetGroupPolicyTestRoute("other-svc"),
+		&mcsv1alpha1.ServiceExport{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns1"}},
+		withSectionName(newTargetGroupPolicy("instance-backend", time.Hour, gwv1beta1.GroupName, "HTTPRoute",
+			"instance-route", anv1alpha1.TargetGroupPolicySpec{TargetType: &instance}), "svc"),
+		newTargetGroupPolicy("instance-export", time.Hour, mcsv1alpha1.GroupName, "ServiceExport",
+			"svc", anv1alpha1.TargetGroupPolicySpec{TargetType: &instance}),
+	)
+
+	tgTypes, err := GetServiceTargetGroupTypes(ctx, k8sClient, svcName)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]model.TargetGroupType{
+		"ip-route":       model.TargetGroupTypeIP,
+		"instance-route": model.TargetGroupTypeInstance,
+		"":               model.TargetGroupTypeInstance,
+	}, tgTypes)
+}
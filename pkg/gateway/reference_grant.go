@@ -0,0 +1,93 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+// GetUnpermittedBackendRefs returns the backendRefs of the route which refer to another namespace
+// without a ReferenceGrant in that namespace permitting it. Without the ReferenceGrant CRD installed
+// no cross-namespace backendRef is permitted.
+func GetUnpermittedBackendRefs(ctx context.Context, k8sClient client.Client, route core.Route) ([]core.BackendRef, error) {
+	var unpermitted []core.BackendRef
+	grantsByNamespace := make(map[string][]gwv1beta1.ReferenceGrant)
+
+	for _, rule := range route.Spec().Rules() {
+		for _, backendRef := range rule.BackendRefs() {
+			if backendRef.Namespace() == nil || string(*backendRef.Namespace()) == route.Namespace() {
+				continue
+			}
+			namespace := string(*backendRef.Namespace())
+
+			grants, ok := grantsByNamespace[namespace]
+			if !ok {
+				grantList := &gwv1beta1.ReferenceGrantList{}
+				err := k8sClient.List(ctx, grantList, client.InNamespace(namespace))
+				if err != nil && !meta.IsNoMatchError(err) {
+					return nil, fmt.Errorf("failed to list reference grants in namespace %s, %w", namespace, err)
+				}
+				grants = grantList.Items
+				grantsByNamespace[namespace] = grants
+			}
+
+			if !isBackendRefPermitted(route, backendRef, grants) {
+				unpermitted = append(unpermitted, backendRef)
+			}
+		}
+	}
+	return unpermitted, nil
+}
+
+// isBackendRefPermitted checks whether any of grants, which are in the namespace of backendRef,
+// permits routes of this kind in the route namespace to refer to it
+func isBackendRefPermitted(route core.Route, backendRef core.BackendRef, grants []gwv1beta1.ReferenceGrant) bool {
+	group := gwv1beta1.Group(corev1.GroupName)
+	if backendRef.Group() != nil {
+		group = *backendRef.Group()
+	}
+	kind := gwv1beta1.Kind("Service")
+	if backendRef.Kind() != nil {
+		kind = *backendRef.Kind()
+	}
+
+	for _, grant := range grants {
+		fromRoute := false
+		for _, from := range grant.Spec.From {
+			if from.Group == gwv1beta1.GroupName && from.Kind == core.RouteKind(route) &&
+				string(from.Namespace) == route.Namespace() {
+				fromRoute = true
+				break
+			}
+		}
+		if !fromRoute {
+			continue
+		}
+
+		for _, to := range grant.Spec.To {
+			if to.Group == group && to.Kind == kind && (to.Name == nil || *to.Name == backendRef.Name()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FormatBackendRefs lists backendRefs as namespace/name for status messages
+func FormatBackendRefs(route core.Route, backendRefs []core.BackendRef) string {
+	var refs []string
+	for _, backendRef := range backendRefs {
+		namespace := route.Namespace()
+		if backendRef.Namespace() != nil {
+			namespace = string(*backendRef.Namespace())
+		}
+		refs = append(refs, fmt.Sprintf("%s/%s", namespace, backendRef.Name()))
+	}
+	return fmt.Sprintf("%v", refs)
+}
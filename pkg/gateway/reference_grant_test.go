@@ -0,0 +1,208 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+func newCrossNamespaceRoute(backendRefs ...gwv1beta1.BackendObjectReference) core.Route {
+	var httpBackendRefs []gwv1beta1.HTTPBackendRef
+	for _, backendRef := range backendRefs {
+		httpBackendRefs = append(httpBackendRefs, gwv1beta1.HTTPBackendRef{
+			BackendRef: gwv1beta1.BackendRef{BackendObjectReference: backendRef},
+		})
+	}
+
+	return core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route",
+			Namespace: "team-a",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{{BackendRefs: httpBackendRefs}},
+		},
+	})
+}
+
+func backendObjectRef(kind string, name string, namespace string) gwv1beta1.BackendObjectReference {
+	ref := gwv1beta1.BackendObjectReference{
+		Kind: (*gwv1beta1.Kind)(pointer.String(kind)),
+		Name: gwv1beta1.ObjectName(name),
+	}
+	if kind == "ServiceImport" {
+		ref.Group = (*gwv1beta1.Group)(pointer.String("multicluster.x-k8s.io"))
+	}
+	if namespace != "" {
+		ref.Namespace = (*gwv1beta1.Namespace)(pointer.String(namespace))
+	}
+	return ref
+}
+
+func Test_GetUnpermittedBackendRefs(t *testing.T) {
+	fromTeamA := []gwv1beta1.ReferenceGrantFrom{
+		{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "team-a"},
+	}
+
+	tests := []struct {
+		name            string
+		grants          []gwv1beta1.ReferenceGrant
+		backendRefs     []gwv1beta1.BackendObjectReference
+		wantUnpermitted []string
+	}{
+		{
+			name: "backendRefs in the route namespace need no grant",
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", ""),
+				backendObjectRef("Service", "svc", "team-a"),
+			},
+		},
+		{
+			name: "cross-namespace backendRef without grant",
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", ""),
+				backendObjectRef("Service", "svc", "team-b"),
+			},
+			wantUnpermitted: []string{"svc"},
+		},
+		{
+			name: "grant to every service of the namespace",
+			grants: []gwv1beta1.ReferenceGrant{{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "team-b"},
+				Spec: gwv1beta1.ReferenceGrantSpec{
+					From: fromTeamA,
+					To:   []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+				},
+			}},
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", "team-b"),
+				backendObjectRef("Service", "other-svc", "team-b"),
+			},
+		},
+		{
+			name: "grant to a named service",
+			grants: []gwv1beta1.ReferenceGrant{{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "team-b"},
+				Spec: gwv1beta1.ReferenceGrantSpec{
+					From: fromTeamA,
+					To: []gwv1beta1.ReferenceGrantTo{
+						{Group: "", Kind: "Service", Name: (*gwv1beta1.ObjectName)(pointer.String("svc"))},
+					},
+				},
+			}},
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", "team-b"),
+				backendObjectRef("Service", "other-svc", "team-b"),
+			},
+			wantUnpermitted: []string{"other-svc"},
+		},
+		{
+			name: "grant in another namespace",
+			grants: []gwv1beta1.ReferenceGrant{{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "team-c"},
+				Spec: gwv1beta1.ReferenceGrantSpec{
+					From: fromTeamA,
+					To:   []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+				},
+			}},
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", "team-b"),
+			},
+			wantUnpermitted: []string{"svc"},
+		},
+		{
+			name: "grant from another route kind or namespace",
+			grants: []gwv1beta1.ReferenceGrant{{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "team-b"},
+				Spec: gwv1beta1.ReferenceGrantSpec{
+					From: []gwv1beta1.ReferenceGrantFrom{
+						{Group: gwv1beta1.GroupName, Kind: "GRPCRoute", Namespace: "team-a"},
+						{Group: gwv1beta1.GroupName, Kind: "HTTPRoute", Namespace: "team-c"},
+					},
+					To: []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+				},
+			}},
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", "team-b"),
+			},
+			wantUnpermitted: []string{"svc"},
+		},
+		{
+			name: "grant to services does not permit service imports",
+			grants: []gwv1beta1.ReferenceGrant{{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "team-b"},
+				Spec: gwv1beta1.ReferenceGrantSpec{
+					From: fromTeamA,
+					To:   []gwv1beta1.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+				},
+			}},
+			backendRefs: []gwv1beta1.BackendObjectReference{
+				backendObjectRef("Service", "svc", "team-b"),
+				backendObjectRef("ServiceImport", "import", "team-b"),
+			},
+			wantUnpermitted: []string{"import"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k8sSchema := runtime.NewScheme()
+			clientgoscheme.AddToScheme(k8sSchema)
+			gwv1beta1.AddToScheme(k8sSchema)
+			k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+			for _, grant := range tt.grants {
+				assert.NoError(t, k8sClient.Create(context.Background(), grant.DeepCopy()))
+			}
+
+			unpermitted, err := GetUnpermittedBackendRefs(context.Background(), k8sClient, newCrossNamespaceRoute(tt.backendRefs...))
+			assert.NoError(t, err)
+
+			var names []string
+			for _, backendRef := range unpermitted {
+				names = append(names, string(backendRef.Name()))
+			}
+			assert.Equal(t, tt.wantUnpermitted, names)
+		})
+	}
+}
+
+func Test_BuildRuleActionForUnpermittedBackendRefs(t *testing.T) {
+	route := newCrossNamespaceRoute(
+		backendObjectRef("Service", "svc", "team-a"),
+		backendObjectRef("Service", "svc", "team-b"),
+	)
+	rule := route.Spec().Rules()[0]
+
+	task := &latticeServiceModelBuildTask{
+		log:   gwlog.FallbackLogger,
+		route: route,
+	}
+
+	action, err := task.buildRuleAction(context.Background(), rule)
+	assert.NoError(t, err)
+	assert.Len(t, action.TargetGroups, 2)
+
+	// only the permitted backendRef gets traffic
+	task.unpermittedBackendRefs = rule.BackendRefs()[1:]
+	action, err = task.buildRuleAction(context.Background(), rule)
+	assert.NoError(t, err)
+	assert.Equal(t, []*model.RuleTargetGroup{{Name: "svc", Namespace: "team-a", RouteName: "route"}}, action.TargetGroups)
+
+	// requests get an error response when no backendRef of the rule is permitted
+	task.unpermittedBackendRefs = rule.BackendRefs()
+	action, err = task.buildRuleAction(context.Background(), rule)
+	assert.NoError(t, err)
+	assert.Equal(t, model.RuleAction{FixedResponseStatusCode: unpermittedBackendRefStatusCode}, action)
+}
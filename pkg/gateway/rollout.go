@@ -0,0 +1,226 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+const (
+	defaultRolloutStepInterval = 5 * time.Minute
+	// how often the health of the canary targets is checked during a step
+	rolloutHealthRetryInterval = 30 * time.Second
+)
+
+// RolloutHealth counts the targets of the canary target group of a rollout
+type RolloutHealth struct {
+	Total     int
+	Unhealthy int
+	// targets whose health is not checked yet
+	Pending int
+}
+
+// GetRolloutRule returns the rule of route the rollout shifts traffic of, which has to have backendRefs
+// to both the stable and the canary Service of the rollout
+func GetRolloutRule(route *gwv1beta1.HTTPRoute, rollout *anv1alpha1.LatticeRollout) (*gwv1beta1.HTTPRouteRule, error) {
+	ruleIndex := int(rollout.Spec.RouteRef.RuleIndex)
+	if ruleIndex >= len(route.Spec.Rules) {
+		return nil, fmt.Errorf("HTTPRoute %s has no rule %d", route.Name, ruleIndex)
+	}
+	rule := &route.Spec.Rules[ruleIndex]
+
+	for _, service := range []gwv1beta1.ObjectName{rollout.Spec.StableService, rollout.Spec.CanaryService} {
+		found := false
+		for _, backendRef := range rule.BackendRefs {
+			if isRolloutBackendRef(route, backendRef.BackendObjectReference, service) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("rule %d of HTTPRoute %s has no backendRef to Service %s", ruleIndex, route.Name, service)
+		}
+	}
+	return rule, nil
+}
+
+func isRolloutBackendRef(route *gwv1beta1.HTTPRoute, backendRef gwv1beta1.BackendObjectReference, service gwv1beta1.ObjectName) bool {
+	if backendRef.Kind != nil && *backendRef.Kind != "Service" {
+		return false
+	}
+	if backendRef.Namespace != nil && string(*backendRef.Namespace) != route.Namespace {
+		return false
+	}
+	return backendRef.Name == service
+}
+
+// applyRolloutWeights returns a copy of route with the weights of the backendRefs set by the LatticeRollouts
+// in progress, or route itself if there are none. The route in the cluster keeps the weights it was written with.
+func applyRolloutWeights(ctx context.Context, k8sClient client.Client, route core.Route) (core.Route, error) {
+	httpRoute, ok := route.(*core.HTTPRoute)
+	if !ok || !route.DeletionTimestamp().IsZero() {
+		return route, nil
+	}
+
+	rollouts := &anv1alpha1.LatticeRolloutList{}
+	if err := k8sClient.List(ctx, rollouts, client.InNamespace(route.Namespace())); err != nil {
+		if meta.IsNoMatchError(err) {
+			return route, nil
+		}
+		return nil, fmt.Errorf("failed to list lattice rollouts, %w", err)
+	}
+
+	var weighted *core.HTTPRoute
+	for i := range rollouts.Items {
+		rollout := &rollouts.Items[i]
+		if string(rollout.Spec.RouteRef.Name) != route.Name() ||
+			rollout.Status.Phase == "" || rollout.Status.Phase == anv1alpha1.LatticeRolloutPhaseInvalid {
+			continue
+		}
+
+		if weighted == nil {
+			weighted = httpRoute.DeepCopy().(*core.HTTPRoute)
+		}
+		rule, err := GetRolloutRule(weighted.Inner(), rollout)
+		if err != nil {
+			continue
+		}
+		for j := range rule.BackendRefs {
+			backendRef := &rule.BackendRefs[j]
+			if isRolloutBackendRef(weighted.Inner(), backendRef.BackendObjectReference, rollout.Spec.CanaryService) {
+				backendRef.Weight = rolloutWeight(rollout.Status.CanaryWeight)
+			} else if isRolloutBackendRef(weighted.Inner(), backendRef.BackendObjectReference, rollout.Spec.StableService) {
+				backendRef.Weight = rolloutWeight(100 - rollout.Status.CanaryWeight)
+			}
+		}
+	}
+
+	if weighted == nil {
+		return route, nil
+	}
+	return weighted, nil
+}
+
+func rolloutWeight(weight int32) *int32 {
+	return &weight
+}
+
+func rolloutStepInterval(rollout *anv1alpha1.LatticeRollout) time.Duration {
+	if rollout.Spec.StepInterval == nil || rollout.Spec.StepInterval.Duration <= 0 {
+		return defaultRolloutStepInterval
+	}
+	return rollout.Spec.StepInterval.Duration
+}
+
+// AdvanceRollout moves the status of rollout on to where it should be at now. A rollout starts at its first step
+// whenever its spec changes, and applies the next step once the step interval passed and health tells the canary
+// targets are healthy enough. The canary health is checked on every call, and the rollout rolls back as soon as
+// too many canary targets are unhealthy, or when the canary still has no targets once the step interval passed.
+// It returns how long to wait before advancing the rollout again, 0 once the rollout is done.
+func AdvanceRollout(
+	rollout *anv1alpha1.LatticeRollout,
+	health func() (RolloutHealth, error),
+	now time.Time,
+) (time.Duration, error) {
+	status := &rollout.Status
+	interval := rolloutStepInterval(rollout)
+
+	if status.ObservedGeneration != rollout.Generation || status.Phase == "" ||
+		status.Phase == anv1alpha1.LatticeRolloutPhaseInvalid {
+		status.ObservedGeneration = rollout.Generation
+		setRolloutStep(rollout, 0, rollout.Spec.Steps[0].Weight, anv1alpha1.LatticeRolloutPhaseProgressing,
+			"Rollout started", now)
+		return interval, nil
+	}
+
+	if status.Phase != anv1alpha1.LatticeRolloutPhaseProgressing {
+		return 0, nil
+	}
+
+	canaryHealth, err := health()
+	if err != nil {
+		return 0, err
+	}
+
+	if canaryHealth.Total > 0 {
+		unhealthyPercent := int32(canaryHealth.Unhealthy * 100 / canaryHealth.Total)
+		if unhealthyPercent > rollout.Spec.MaxUnhealthyTargetsPercent {
+			setRolloutStep(rollout, status.CurrentStep, 0, anv1alpha1.LatticeRolloutPhaseRolledBack,
+				fmt.Sprintf("Rolled back, %d of %d targets of canary Service %s are unhealthy, more than %d%%",
+					canaryHealth.Unhealthy, canaryHealth.Total, rollout.Spec.CanaryService, rollout.Spec.MaxUnhealthyTargetsPercent),
+				now)
+			return 0, nil
+		}
+	}
+
+	var elapsed time.Duration
+	if status.LastStepTime != nil {
+		elapsed = now.Sub(status.LastStepTime.Time)
+	}
+	if elapsed < interval {
+		// the canary health is checked again before the step interval passes
+		if interval-elapsed < rolloutHealthRetryInterval {
+			return interval - elapsed, nil
+		}
+		return rolloutHealthRetryInterval, nil
+	}
+
+	if canaryHealth.Total == 0 {
+		// canary pods which never become ready leave the canary without targets
+		setRolloutStep(rollout, status.CurrentStep, 0, anv1alpha1.LatticeRolloutPhaseRolledBack,
+			fmt.Sprintf("Rolled back, canary Service %s has no targets after the step interval", rollout.Spec.CanaryService),
+			now)
+		return 0, nil
+	}
+	if canaryHealth.Pending > 0 {
+		status.Message = fmt.Sprintf("Waiting for the health of the targets of canary Service %s", rollout.Spec.CanaryService)
+		return rolloutHealthRetryInterval, nil
+	}
+
+	next := status.CurrentStep + 1
+	if int(next) >= len(rollout.Spec.Steps) {
+		setRolloutStep(rollout, status.CurrentStep, status.CanaryWeight, anv1alpha1.LatticeRolloutPhaseSucceeded,
+			"Rollout completed", now)
+		return 0, nil
+	}
+
+	setRolloutStep(rollout, next, rollout.Spec.Steps[next].Weight, anv1alpha1.LatticeRolloutPhaseProgressing,
+		fmt.Sprintf("Applied step %d, %d of %d canary targets are unhealthy", next, canaryHealth.Unhealthy, canaryHealth.Total),
+		now)
+	return interval, nil
+}
+
+func setRolloutStep(
+	rollout *anv1alpha1.LatticeRollout,
+	step int32,
+	canaryWeight int32,
+	phase anv1alpha1.LatticeRolloutPhase,
+	message string,
+	now time.Time,
+) {
+	status := &rollout.Status
+	status.CurrentStep = step
+	status.CanaryWeight = canaryWeight
+	status.Phase = phase
+	status.Message = message
+	status.LastStepTime = &metav1.Time{Time: now}
+
+	status.History = append(status.History, anv1alpha1.LatticeRolloutHistoryEntry{
+		Time:         metav1.Time{Time: now},
+		Step:         step,
+		CanaryWeight: canaryWeight,
+		Phase:        phase,
+		Message:      message,
+	})
+	if len(status.History) > anv1alpha1.MaxLatticeRolloutHistory {
+		status.History = status.History[len(status.History)-anv1alpha1.MaxLatticeRolloutHistory:]
+	}
+}
@@ -0,0 +1,370 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+func newRolloutRoute() *gwv1beta1.HTTPRoute {
+	backendRef := func(name string, weight int32) gwv1beta1.HTTPBackendRef {
+		return gwv1beta1.HTTPBackendRef{
+			BackendRef: gwv1beta1.BackendRef{
+				BackendObjectReference: gwv1beta1.BackendObjectReference{
+					Name: gwv1beta1.ObjectName(name),
+				},
+				Weight: pointer.Int32(weight),
+			},
+		}
+	}
+
+	return &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route",
+			Namespace: "default",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			Rules: []gwv1beta1.HTTPRouteRule{
+				{BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef("other", 100)}},
+				{BackendRefs: []gwv1beta1.HTTPBackendRef{backendRef("stable", 100), backendRef("canary", 0)}},
+			},
+		},
+	}
+}
+
+func newRollout() *anv1alpha1.LatticeRollout {
+	return &anv1alpha1.LatticeRollout{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "rollout",
+			Namespace:  "default",
+			Generation: 1,
+		},
+		Spec: anv1alpha1.LatticeRolloutSpec{
+			RouteRef:      anv1alpha1.LatticeRolloutRouteRef{Name: "route", RuleIndex: 1},
+			StableService: "stable",
+			CanaryService: "canary",
+			Steps: []anv1alpha1.LatticeRolloutStep{
+				{Weight: 10}, {Weight: 50}, {Weight: 100},
+			},
+			StepInterval:               &metav1.Duration{Duration: time.Minute},
+			MaxUnhealthyTargetsPercent: 20,
+		},
+	}
+}
+
+func Test_GetRolloutRule(t *testing.T) {
+	route := newRolloutRoute()
+
+	rollout := newRollout()
+	rule, err := GetRolloutRule(route, rollout)
+	assert.NoError(t, err)
+	assert.Equal(t, &route.Spec.Rules[1], rule)
+
+	rollout.Spec.RouteRef.RuleIndex = 2
+	_, err = GetRolloutRule(route, rollout)
+	assert.Error(t, err)
+
+	rollout.Spec.RouteRef.RuleIndex = 0
+	_, err = GetRolloutRule(route, rollout)
+	assert.Error(t, err)
+
+	rollout.Spec.RouteRef.RuleIndex = 1
+	route.Spec.Rules[1].BackendRefs[1].Namespace = (*gwv1beta1.Namespace)(pointer.String("other-namespace"))
+	_, err = GetRolloutRule(route, rollout)
+	assert.Error(t, err)
+}
+
+func Test_AdvanceRollout(t *testing.T) {
+	now := time.Now()
+	healthy := func() (RolloutHealth, error) {
+		return RolloutHealth{Total: 5, Unhealthy: 1}, nil
+	}
+	unhealthy := func() (RolloutHealth, error) {
+		return RolloutHealth{Total: 5, Unhealthy: 2}, nil
+	}
+	pending := func() (RolloutHealth, error) {
+		return RolloutHealth{Total: 5, Pending: 1}, nil
+	}
+	noTargets := func() (RolloutHealth, error) {
+		return RolloutHealth{}, nil
+	}
+	noHealthCheck := func() (RolloutHealth, error) {
+		return RolloutHealth{}, errors.New("health should not be checked")
+	}
+
+	tests := []struct {
+		name             string
+		status           anv1alpha1.LatticeRolloutStatus
+		health           func() (RolloutHealth, error)
+		wantRequeueAfter time.Duration
+		wantErr          bool
+		wantPhase        anv1alpha1.LatticeRolloutPhase
+		wantStep         int32
+		wantCanaryWeight int32
+		wantHistory      int
+	}{
+		{
+			name:             "new rollout starts at the first step",
+			health:           noHealthCheck,
+			wantRequeueAfter: time.Minute,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+			wantHistory:      1,
+		},
+		{
+			name: "changed rollout starts over",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 0,
+				Phase:              anv1alpha1.LatticeRolloutPhaseSucceeded,
+				CurrentStep:        2,
+				CanaryWeight:       100,
+			},
+			health:           noHealthCheck,
+			wantRequeueAfter: time.Minute,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+			wantHistory:      1,
+		},
+		{
+			name: "step interval has not passed",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-20 * time.Second)},
+			},
+			health:           healthy,
+			wantRequeueAfter: rolloutHealthRetryInterval,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+		},
+		{
+			name: "end of the step interval is waited for",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-50 * time.Second)},
+			},
+			health:           healthy,
+			wantRequeueAfter: 10 * time.Second,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+		},
+		{
+			name: "unhealthy canary rolls back before the step interval passed",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-20 * time.Second)},
+			},
+			health:           unhealthy,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseRolledBack,
+			wantCanaryWeight: 0,
+			wantHistory:      1,
+		},
+		{
+			name: "canary without targets is waited for during the step interval",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-20 * time.Second)},
+			},
+			health:           noTargets,
+			wantRequeueAfter: rolloutHealthRetryInterval,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+		},
+		{
+			name: "canary without targets after the step interval rolls back",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			health:           noTargets,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseRolledBack,
+			wantCanaryWeight: 0,
+			wantHistory:      1,
+		},
+		{
+			name: "healthy canary advances to the next step",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			health:           healthy,
+			wantRequeueAfter: time.Minute,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantStep:         1,
+			wantCanaryWeight: 50,
+			wantHistory:      1,
+		},
+		{
+			name: "pending canary health is waited for",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			health:           pending,
+			wantRequeueAfter: rolloutHealthRetryInterval,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+		},
+		{
+			name: "unhealthy canary rolls back",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CurrentStep:        1,
+				CanaryWeight:       50,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			health:           unhealthy,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseRolledBack,
+			wantStep:         1,
+			wantCanaryWeight: 0,
+			wantHistory:      1,
+		},
+		{
+			name: "last step completes the rollout",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CurrentStep:        2,
+				CanaryWeight:       100,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			health:           healthy,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseSucceeded,
+			wantStep:         2,
+			wantCanaryWeight: 100,
+			wantHistory:      1,
+		},
+		{
+			name: "rolled back rollout stays rolled back",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseRolledBack,
+				CurrentStep:        1,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+			health:    noHealthCheck,
+			wantPhase: anv1alpha1.LatticeRolloutPhaseRolledBack,
+			wantStep:  1,
+		},
+		{
+			name: "failing health check",
+			status: anv1alpha1.LatticeRolloutStatus{
+				ObservedGeneration: 1,
+				Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+				CanaryWeight:       10,
+				LastStepTime:       &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			health:           noHealthCheck,
+			wantErr:          true,
+			wantPhase:        anv1alpha1.LatticeRolloutPhaseProgressing,
+			wantCanaryWeight: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := newRollout()
+			rollout.Status = tt.status
+
+			requeueAfter, err := AdvanceRollout(rollout, tt.health, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRequeueAfter, requeueAfter)
+			}
+			assert.Equal(t, tt.wantPhase, rollout.Status.Phase)
+			assert.Equal(t, tt.wantStep, rollout.Status.CurrentStep)
+			assert.Equal(t, tt.wantCanaryWeight, rollout.Status.CanaryWeight)
+			assert.Equal(t, int64(1), rollout.Status.ObservedGeneration)
+			assert.Len(t, rollout.Status.History, tt.wantHistory)
+		})
+	}
+}
+
+func Test_AdvanceRolloutHistoryIsCapped(t *testing.T) {
+	now := time.Now()
+	rollout := newRollout()
+	for i := 0; i < anv1alpha1.MaxLatticeRolloutHistory+5; i++ {
+		setRolloutStep(rollout, 0, 10, anv1alpha1.LatticeRolloutPhaseProgressing, "step", now.Add(time.Duration(i)*time.Second))
+	}
+	assert.Len(t, rollout.Status.History, anv1alpha1.MaxLatticeRolloutHistory)
+	assert.Equal(t, now.Add(time.Duration(anv1alpha1.MaxLatticeRolloutHistory+4)*time.Second), rollout.Status.History[anv1alpha1.MaxLatticeRolloutHistory-1].Time.Time)
+}
+
+func Test_applyRolloutWeights(t *testing.T) {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	k8sClient := testclient.NewClientBuilder().WithScheme(k8sSchema).Build()
+	ctx := context.Background()
+
+	route := core.NewHTTPRoute(*newRolloutRoute())
+
+	// no rollouts, route is used as is
+	weighted, err := applyRolloutWeights(ctx, k8sClient, route)
+	assert.NoError(t, err)
+	assert.Same(t, route, weighted)
+
+	rollout := newRollout()
+	assert.NoError(t, k8sClient.Create(ctx, rollout))
+
+	// rollout without status has not started yet
+	weighted, err = applyRolloutWeights(ctx, k8sClient, route)
+	assert.NoError(t, err)
+	assert.Same(t, route, weighted)
+
+	rollout.Status = anv1alpha1.LatticeRolloutStatus{
+		ObservedGeneration: 1,
+		Phase:              anv1alpha1.LatticeRolloutPhaseProgressing,
+		CurrentStep:        1,
+		CanaryWeight:       30,
+	}
+	assert.NoError(t, k8sClient.Update(ctx, rollout))
+
+	weighted, err = applyRolloutWeights(ctx, k8sClient, route)
+	assert.NoError(t, err)
+	rules := weighted.(*core.HTTPRoute).Inner().Spec.Rules
+	assert.Equal(t, int32(100), *rules[0].BackendRefs[0].Weight)
+	assert.Equal(t, int32(70), *rules[1].BackendRefs[0].Weight)
+	assert.Equal(t, int32(30), *rules[1].BackendRefs[1].Weight)
+
+	// the original route keeps its weights
+	rules = route.Inner().Spec.Rules
+	assert.Equal(t, int32(100), *rules[1].BackendRefs[0].Weight)
+	assert.Equal(t, int32(0), *rules[1].BackendRefs[1].Weight)
+
+	rollout.Status.Phase = anv1alpha1.LatticeRolloutPhaseInvalid
+	assert.NoError(t, k8sClient.Update(ctx, rollout))
+
+	weighted, err = applyRolloutWeights(ctx, k8sClient, route)
+	assert.NoError(t, err)
+	assert.Same(t, route, weighted)
+}
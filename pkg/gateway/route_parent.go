@@ -0,0 +1,366 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+// RouteParent is a parentRef of a route which refers to a Gateway of the VPC Lattice GatewayClass
+type RouteParent struct {
+	ParentRef gwv1beta1.ParentReference
+	Gateway   *gwv1beta1.Gateway
+
+	// listener of the gateway the route attaches to, nil if there is no such listener
+	Listener *gwv1beta1.Listener
+	Port     int64
+	Protocol string
+	CertARN  string
+
+	// why the route is not accepted by the parent, Err is nil when it is
+	Reason gwv1beta1.RouteConditionReason
+	Err    error
+}
+
+func (p *RouteParent) Accepted() bool {
+	return p.Err == nil
+}
+
+// GetRouteParents resolves the parentRefs of the route which refer to a Gateway of the VPC Lattice GatewayClass,
+// parentRefs to gateways which do not exist or belong to another GatewayClass are left out.
+//
+// All parents share the same lattice service, which can only have one listener per port and one certificate.
+// Parents are resolved in order and a parent whose listener conflicts with the listener of a parent
+// accepted before is not accepted.
+func GetRouteParents(ctx context.Context, k8sClient client.Client, route core.Route) ([]*RouteParent, error) {
+	var parents []*RouteParent
+
+	for _, parentRef := range route.Spec().ParentRefs() {
+		gw, err := getLatticeGateway(ctx, k8sClient, route, parentRef)
+		if err != nil {
+			return nil, err
+		}
+		if gw == nil {
+			continue
+		}
+
+		parent := &RouteParent{
+			ParentRef: parentRef,
+			Gateway:   gw,
+		}
+		if err := resolveParentAcceptance(ctx, k8sClient, route, parent, parents); err != nil {
+			return nil, err
+		}
+		parents = append(parents, parent)
+	}
+
+	return parents, nil
+}
+
+// resolveParentAcceptance sets the listener of parent and the reason it does not accept the route, if it does not.
+// Errors are only returned when the acceptance cannot be determined.
+func resolveParentAcceptance(
+	ctx context.Context,
+	k8sClient client.Client,
+	route core.Route,
+	parent *RouteParent,
+	parents []*RouteParent,
+) error {
+	var err error
+	parent.Port, parent.Protocol, parent.CertARN, err = extractListenerInfo(parent.Gateway, parent.ParentRef)
+	if err != nil {
+		parent.Reason = gwv1beta1.RouteReasonNoMatchingParent
+		parent.Err = err
+		return nil
+	}
+
+	if err := validateParentRouteKind(route, parent); err != nil {
+		parent.Reason = gwv1beta1.RouteReasonNotAllowedByListeners
+		parent.Err = err
+		return nil
+	}
+
+	listener := getParentListener(parent.Gateway, parent.ParentRef)
+	parent.Listener = listener
+	allowed, err := isRouteNamespaceAllowed(ctx, k8sClient, route, parent.Gateway, listener)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		parent.Reason = gwv1beta1.RouteReasonNotAllowedByListeners
+		parent.Err = fmt.Errorf("listener %s of gateway %s does not allow routes from namespace %s",
+			listener.Name, parent.Gateway.Name, route.Namespace())
+		return nil
+	}
+	if !isRouteKindAllowed(route, listener) {
+		parent.Reason = gwv1beta1.RouteReasonNotAllowedByListeners
+		parent.Err = fmt.Errorf("listener %s of gateway %s does not allow %s routes",
+			listener.Name, parent.Gateway.Name, core.RouteKind(route))
+		return nil
+	}
+
+	if !isRouteHostnameAllowed(route, listener) {
+		parent.Reason = gwv1beta1.RouteReasonNoMatchingListenerHostname
+		parent.Err = fmt.Errorf("no hostname of the route matches hostname %s of listener %s of gateway %s",
+			*listener.Hostname, listener.Name, parent.Gateway.Name)
+		return nil
+	}
+
+	if err := validateParentListener(parent, parents); err != nil {
+		parent.Reason = gwv1beta1.RouteReasonUnsupportedValue
+		parent.Err = err
+	}
+	return nil
+}
+
+// ParentRefGatewayName returns the name of the Gateway parentRef of route refers to, false if it refers to another kind
+func ParentRefGatewayName(route core.Route, parentRef gwv1beta1.ParentReference) (types.NamespacedName, bool) {
+	if (parentRef.Group != nil && *parentRef.Group != gwv1beta1.GroupName) ||
+		(parentRef.Kind != nil && *parentRef.Kind != "Gateway") {
+		return types.NamespacedName{}, false
+	}
+	gwName := types.NamespacedName{Namespace: route.Namespace(), Name: string(parentRef.Name)}
+	if parentRef.Namespace != nil {
+		gwName.Namespace = string(*parentRef.Namespace)
+	}
+	return gwName, true
+}
+
+// IsRouteAcceptedByGateway tells whether the status of route says the Gateway gwName accepted it, which the route
+// controller records once the lattice service of the route is deployed
+func IsRouteAcceptedByGateway(route core.Route, gwName types.NamespacedName) bool {
+	for _, parentStatus := range route.Status().Parents() {
+		if parentStatus.ControllerName != config.LatticeGatewayControllerName {
+			continue
+		}
+		if name, ok := ParentRefGatewayName(route, parentStatus.ParentRef); !ok || name != gwName {
+			continue
+		}
+		if meta.IsStatusConditionTrue(parentStatus.Conditions, string(gwv1beta1.RouteConditionAccepted)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getLatticeGateway returns the gateway parentRef refers to, or nil if it does not exist or is not a VPC Lattice gateway
+func getLatticeGateway(
+	ctx context.Context,
+	k8sClient client.Client,
+	route core.Route,
+	parentRef gwv1beta1.ParentReference,
+) (*gwv1beta1.Gateway, error) {
+	gwName, ok := ParentRefGatewayName(route, parentRef)
+	if !ok {
+		return nil, nil
+	}
+
+	gw := &gwv1beta1.Gateway{}
+	if err := k8sClient.Get(ctx, gwName, gw); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get gateway %s, %w", gwName, err)
+	}
+
+	gwClass := &gwv1beta1.GatewayClass{}
+	gwClassName := types.NamespacedName{
+		Name: string(gw.Spec.GatewayClassName),
+	}
+	if err := k8sClient.Get(ctx, gwClassName, gwClass); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get gateway class %s, %w", gwClassName.Name, err)
+	}
+
+	if gwClass.Spec.ControllerName != config.LatticeGatewayControllerName {
+		return nil, nil
+	}
+	return gw, nil
+}
+
+func extractListenerInfo(gw *gwv1beta1.Gateway, parentRef gwv1beta1.ParentReference) (int64, string, string, error) {
+	if parentRef.SectionName == nil {
+		// use 1st listener port
+		if len(gw.Spec.Listeners) == 0 {
+			return 0, "", "", errors.New("error building listener, there is NO listeners on GW")
+		}
+		if gw.Spec.Listeners[0].Protocol == gwv1beta1.TLSProtocolType {
+			return sectionListenerInfo(gw.Spec.Listeners[0])
+		}
+		return int64(gw.Spec.Listeners[0].Port), string(gwv1beta1.HTTPProtocolType), "", nil
+	}
+
+	// go through parent find out the matching section name
+	for _, section := range gw.Spec.Listeners {
+		if section.Name == *parentRef.SectionName {
+			return sectionListenerInfo(section)
+		}
+	}
+
+	return 0, "", "", fmt.Errorf("error building listener, no matching sectionName in parentRef for Name %s, Section %s",
+		parentRef.Name, *parentRef.SectionName)
+}
+
+// getParentListener returns the gateway listener parentRef refers to, which extractListenerInfo found
+func getParentListener(gw *gwv1beta1.Gateway, parentRef gwv1beta1.ParentReference) *gwv1beta1.Listener {
+	if parentRef.SectionName == nil {
+		return &gw.Spec.Listeners[0]
+	}
+	for i := range gw.Spec.Listeners {
+		if gw.Spec.Listeners[i].Name == *parentRef.SectionName {
+			return &gw.Spec.Listeners[i]
+		}
+	}
+	return nil
+}
+
+func sectionListenerInfo(section gwv1beta1.Listener) (int64, string, string, error) {
+	if section.Protocol == gwv1beta1.TLSProtocolType {
+		// VPC Lattice cannot terminate TLS without HTTP, TLS listeners have to pass it through to the targets
+		if section.TLS == nil || section.TLS.Mode == nil || *section.TLS.Mode != gwv1beta1.TLSModePassthrough {
+			return 0, "", "", fmt.Errorf("error building listener, TLS listener %s has to use Passthrough mode", section.Name)
+		}
+		return int64(section.Port), string(section.Protocol), "", nil
+	}
+
+	var certARN = ""
+	if section.TLS != nil && section.TLS.Mode != nil && *section.TLS.Mode == gwv1beta1.TLSModeTerminate {
+		if curCertARN, ok := section.TLS.Options[awsCustomCertARN]; ok {
+			certARN = string(curCertARN)
+		}
+	}
+	return int64(section.Port), string(section.Protocol), certARN, nil
+}
+
+// validateParentRouteKind makes sure TLSRoutes, and only TLSRoutes, attach to TLS listeners
+func validateParentRouteKind(route core.Route, parent *RouteParent) error {
+	_, isTLSRoute := route.(*core.TLSRoute)
+	isTLSListener := parent.Protocol == string(gwv1beta1.TLSProtocolType)
+
+	if isTLSRoute && !isTLSListener {
+		return fmt.Errorf("TLSRoute can only attach to a TLS listener, listener of gateway %s uses %s",
+			parent.Gateway.Name, parent.Protocol)
+	}
+	if !isTLSRoute && isTLSListener {
+		return fmt.Errorf("only TLSRoute can attach to the TLS listener of gateway %s", parent.Gateway.Name)
+	}
+	return nil
+}
+
+func validateParentListener(parent *RouteParent, parents []*RouteParent) error {
+	for _, other := range parents {
+		if !other.Accepted() {
+			continue
+		}
+
+		if other.Port == parent.Port && other.Protocol != parent.Protocol {
+			return fmt.Errorf("%s listener on port %d conflicts with %s listener of gateway %s on the same port",
+				parent.Protocol, parent.Port, other.Protocol, other.Gateway.Name)
+		}
+
+		if parent.CertARN != "" && other.CertARN != "" && parent.CertARN != other.CertARN {
+			return fmt.Errorf("certificate %s conflicts with certificate %s of gateway %s, a route can only use one certificate",
+				parent.CertARN, other.CertARN, other.Gateway.Name)
+		}
+	}
+	return nil
+}
+
+// isRouteNamespaceAllowed checks the allowedRoutes namespaces of the listener, which allow routes
+// from the namespace of the gateway only unless they say otherwise
+func isRouteNamespaceAllowed(
+	ctx context.Context,
+	k8sClient client.Client,
+	route core.Route,
+	gw *gwv1beta1.Gateway,
+	listener *gwv1beta1.Listener,
+) (bool, error) {
+	from := gwv1beta1.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if listener.AllowedRoutes != nil && listener.AllowedRoutes.Namespaces != nil {
+		if listener.AllowedRoutes.Namespaces.From != nil {
+			from = *listener.AllowedRoutes.Namespaces.From
+		}
+		selector = listener.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case gwv1beta1.NamespacesFromAll:
+		return true, nil
+	case gwv1beta1.NamespacesFromSelector:
+		if selector == nil {
+			return false, nil
+		}
+		namespaceSelector, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			// an invalid selector selects nothing
+			return false, nil
+		}
+		namespace := &corev1.Namespace{}
+		if err := k8sClient.Get(ctx, types.NamespacedName{Name: route.Namespace()}, namespace); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get namespace %s, %w", route.Namespace(), err)
+		}
+		return namespaceSelector.Matches(labels.Set(namespace.Labels)), nil
+	default:
+		return route.Namespace() == gw.Namespace, nil
+	}
+}
+
+// isRouteKindAllowed checks the allowedRoutes kinds of the listener, if it restricts them
+func isRouteKindAllowed(route core.Route, listener *gwv1beta1.Listener) bool {
+	if listener.AllowedRoutes == nil || len(listener.AllowedRoutes.Kinds) == 0 {
+		return true
+	}
+	for _, kind := range listener.AllowedRoutes.Kinds {
+		if (kind.Group == nil || *kind.Group == gwv1beta1.GroupName) && kind.Kind == core.RouteKind(route) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRouteHostnameAllowed checks whether any hostname of the route intersects with the hostname of the listener.
+// Listeners without hostname accept any route, and routes without hostnames match any listener.
+func isRouteHostnameAllowed(route core.Route, listener *gwv1beta1.Listener) bool {
+	if listener.Hostname == nil || *listener.Hostname == "" || len(route.Spec().Hostnames()) == 0 {
+		return true
+	}
+	for _, hostname := range route.Spec().Hostnames() {
+		if hostnamesIntersect(string(*listener.Hostname), string(hostname)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostnamesIntersect tells whether two hostnames, either of which may have a wildcard label, match a common hostname
+func hostnamesIntersect(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
+		return true
+	}
+	if strings.HasPrefix(b, "*.") && strings.HasSuffix(a, b[1:]) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,515 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
+	"github.com/aws/aws-application-networking-k8s/pkg/config"
+	"github.com/aws/aws-application-networking-k8s/pkg/k8s"
+	"github.com/aws/aws-application-networking-k8s/pkg/latticestore"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
+	"github.com/aws/aws-application-networking-k8s/pkg/utils/gwlog"
+)
+
+// expectLatticeGatewayClass makes every GatewayClass a VPC Lattice GatewayClass
+func expectLatticeGatewayClass(ctx context.Context, mockK8sClient *mock_client.MockClient) {
+	mockK8sClient.EXPECT().Get(ctx, gomock.Any(), gomock.AssignableToTypeOf(&gwv1beta1.GatewayClass{}), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, name types.NamespacedName, gwClass *gwv1beta1.GatewayClass, arg3 ...interface{}) error {
+			gwClass.Name = name.Name
+			gwClass.Spec.ControllerName = config.LatticeGatewayControllerName
+			return nil
+		},
+	).AnyTimes()
+}
+
+func newRouteParentsTestClient() client.Client {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+
+	mode := gwv1beta1.TLSModeTerminate
+	passthrough := gwv1beta1.TLSModePassthrough
+	tlsListener := func(name gwv1beta1.SectionName, port gwv1beta1.PortNumber, certARN string) gwv1beta1.Listener {
+		return gwv1beta1.Listener{
+			Name:     name,
+			Port:     port,
+			Protocol: gwv1beta1.HTTPSProtocolType,
+			TLS: &gwv1beta1.GatewayTLSConfig{
+				Mode: &mode,
+				Options: map[gwv1beta1.AnnotationKey]gwv1beta1.AnnotationValue{
+					awsCustomCertARN: gwv1beta1.AnnotationValue(certARN),
+				},
+			},
+		}
+	}
+
+	fromAll := gwv1beta1.NamespacesFromAll
+	fromSelector := gwv1beta1.NamespacesFromSelector
+	allowAll := func(listener gwv1beta1.Listener) gwv1beta1.Listener {
+		listener.AllowedRoutes = &gwv1beta1.AllowedRoutes{
+			Namespaces: &gwv1beta1.RouteNamespaces{From: &fromAll},
+		}
+		return listener
+	}
+	allowSelected := func(listener gwv1beta1.Listener, team string) gwv1beta1.Listener {
+		listener.AllowedRoutes = &gwv1beta1.AllowedRoutes{
+			Namespaces: &gwv1beta1.RouteNamespaces{
+				From:     &fromSelector,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": team}},
+			},
+		}
+		return listener
+	}
+	hostname := gwv1beta1.Hostname("*.example.com")
+
+	return testclient.NewFakeClientWithScheme(k8sSchema,
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Labels: map[string]string{"team": "payments"}},
+		},
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "amazon-vpc-lattice"},
+			Spec:       gwv1beta1.GatewayClassSpec{ControllerName: config.LatticeGatewayControllerName},
+		},
+		&gwv1beta1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "other"},
+			Spec:       gwv1beta1.GatewayClassSpec{ControllerName: "example.com/other"},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw1", Namespace: "default"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{
+					{Name: "http", Port: 80, Protocol: gwv1beta1.HTTPProtocolType},
+					tlsListener("https", 443, "cert-1"),
+				},
+			},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "gw2", Namespace: "other-ns"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{
+					allowAll(gwv1beta1.Listener{Name: "http", Port: 8080, Protocol: gwv1beta1.HTTPProtocolType}),
+					allowAll(gwv1beta1.Listener{Name: "https-80", Port: 80, Protocol: gwv1beta1.HTTPSProtocolType}),
+					allowAll(tlsListener("https", 443, "cert-2")),
+				},
+			},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "fenced", Namespace: "platform"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{
+					{Name: "same", Port: 80, Protocol: gwv1beta1.HTTPProtocolType},
+					allowSelected(gwv1beta1.Listener{Name: "payments", Port: 81, Protocol: gwv1beta1.HTTPProtocolType}, "payments"),
+					allowSelected(gwv1beta1.Listener{Name: "orders", Port: 82, Protocol: gwv1beta1.HTTPProtocolType}, "orders"),
+					{
+						Name:     "grpc-only",
+						Port:     83,
+						Protocol: gwv1beta1.HTTPProtocolType,
+						AllowedRoutes: &gwv1beta1.AllowedRoutes{
+							Namespaces: &gwv1beta1.RouteNamespaces{From: &fromAll},
+							Kinds:      []gwv1beta1.RouteGroupKind{{Kind: "GRPCRoute"}},
+						},
+					},
+					allowAll(gwv1beta1.Listener{Name: "hostname", Port: 84, Protocol: gwv1beta1.HTTPProtocolType, Hostname: &hostname}),
+				},
+			},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "tls-gw", Namespace: "default"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "amazon-vpc-lattice",
+				Listeners: []gwv1beta1.Listener{
+					{
+						Name:     "tls",
+						Port:     443,
+						Protocol: gwv1beta1.TLSProtocolType,
+						TLS:      &gwv1beta1.GatewayTLSConfig{Mode: &passthrough},
+					},
+					{
+						Name:     "tls-terminate",
+						Port:     8443,
+						Protocol: gwv1beta1.TLSProtocolType,
+						TLS:      &gwv1beta1.GatewayTLSConfig{Mode: &mode},
+					},
+				},
+			},
+		},
+		&gwv1beta1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-lattice", Namespace: "default"},
+			Spec: gwv1beta1.GatewaySpec{
+				GatewayClassName: "other",
+				Listeners: []gwv1beta1.Listener{
+					{Name: "http", Port: 80, Protocol: gwv1beta1.HTTPProtocolType},
+				},
+			},
+		},
+	)
+}
+
+func newRouteWithParents(parentRefs ...gwv1beta1.ParentReference) core.Route {
+	var serviceKind gwv1beta1.Kind = "Service"
+	var pathPrefix = gwv1beta1.PathMatchPathPrefix
+
+	return core.NewHTTPRoute(gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "route",
+			Namespace: "default",
+		},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{{
+				Matches: []gwv1beta1.HTTPRouteMatch{{
+					Path: &gwv1beta1.HTTPPathMatch{
+						Type:  &pathPrefix,
+						Value: pointer.String("/api"),
+					},
+				}},
+				BackendRefs: []gwv1beta1.HTTPBackendRef{{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{
+							Name: "service1",
+							Kind: &serviceKind,
+						},
+					},
+				}},
+			}},
+		},
+	})
+}
+
+func parentRef(name string, namespace string, sectionName string) gwv1beta1.ParentReference {
+	ref := gwv1beta1.ParentReference{Name: gwv1beta1.ObjectName(name)}
+	if namespace != "" {
+		ns := gwv1beta1.Namespace(namespace)
+		ref.Namespace = &ns
+	}
+	if sectionName != "" {
+		section := gwv1beta1.SectionName(sectionName)
+		ref.SectionName = &section
+	}
+	return ref
+}
+
+func Test_GetRouteParents(t *testing.T) {
+	type wantParent struct {
+		name     string
+		port     int64
+		protocol string
+		certARN  string
+		reason   gwv1beta1.RouteConditionReason
+	}
+
+	tests := []struct {
+		name       string
+		parentRefs []gwv1beta1.ParentReference
+		hostnames  []gwv1beta1.Hostname
+		want       []wantParent
+	}{
+		{
+			name:       "no section name uses the first listener",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("gw1", "", "")},
+			want:       []wantParent{{name: "gw1", port: 80, protocol: "HTTP"}},
+		},
+		{
+			name: "parents on different gateways",
+			parentRefs: []gwv1beta1.ParentReference{
+				parentRef("gw1", "", "http"),
+				parentRef("gw2", "other-ns", "http"),
+			},
+			want: []wantParent{
+				{name: "gw1", port: 80, protocol: "HTTP"},
+				{name: "gw2", port: 8080, protocol: "HTTP"},
+			},
+		},
+		{
+			name: "gateways which do not exist or are not VPC Lattice gateways are left out",
+			parentRefs: []gwv1beta1.ParentReference{
+				parentRef("missing", "", ""),
+				parentRef("not-lattice", "", ""),
+				parentRef("gw1", "", "https"),
+			},
+			want: []wantParent{{name: "gw1", port: 443, protocol: "HTTPS", certARN: "cert-1"}},
+		},
+		{
+			name:       "missing section",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("gw1", "", "grpc")},
+			want:       []wantParent{{name: "gw1", reason: gwv1beta1.RouteReasonNoMatchingParent}},
+		},
+		{
+			name: "conflicting protocol on the same port",
+			parentRefs: []gwv1beta1.ParentReference{
+				parentRef("gw1", "", "http"),
+				parentRef("gw2", "other-ns", "https-80"),
+			},
+			want: []wantParent{
+				{name: "gw1", port: 80, protocol: "HTTP"},
+				{name: "gw2", port: 80, protocol: "HTTPS", reason: gwv1beta1.RouteReasonUnsupportedValue},
+			},
+		},
+		{
+			name: "conflicting certificates",
+			parentRefs: []gwv1beta1.ParentReference{
+				parentRef("gw1", "", "https"),
+				parentRef("gw2", "other-ns", "https"),
+			},
+			want: []wantParent{
+				{name: "gw1", port: 443, protocol: "HTTPS", certARN: "cert-1"},
+				{name: "gw2", port: 443, protocol: "HTTPS", certARN: "cert-2", reason: gwv1beta1.RouteReasonUnsupportedValue},
+			},
+		},
+		{
+			name:       "listeners allow routes from the gateway namespace by default",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("fenced", "platform", "same")},
+			want:       []wantParent{{name: "fenced", port: 80, protocol: "HTTP", reason: gwv1beta1.RouteReasonNotAllowedByListeners}},
+		},
+		{
+			name: "listeners allow routes from namespaces matching their selector",
+			parentRefs: []gwv1beta1.ParentReference{
+				parentRef("fenced", "platform", "payments"),
+				parentRef("fenced", "platform", "orders"),
+			},
+			want: []wantParent{
+				{name: "fenced", port: 81, protocol: "HTTP"},
+				{name: "fenced", port: 82, protocol: "HTTP", reason: gwv1beta1.RouteReasonNotAllowedByListeners},
+			},
+		},
+		{
+			name:       "listeners allow routes of their allowed kinds",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("fenced", "platform", "grpc-only")},
+			want:       []wantParent{{name: "fenced", port: 83, protocol: "HTTP", reason: gwv1beta1.RouteReasonNotAllowedByListeners}},
+		},
+		{
+			name:       "route hostname matching the listener hostname",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("fenced", "platform", "hostname")},
+			hostnames:  []gwv1beta1.Hostname{"other.org", "api.example.com"},
+			want:       []wantParent{{name: "fenced", port: 84, protocol: "HTTP"}},
+		},
+		{
+			name:       "route without hostnames matches the listener hostname",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("fenced", "platform", "hostname")},
+			want:       []wantParent{{name: "fenced", port: 84, protocol: "HTTP"}},
+		},
+		{
+			name:       "no route hostname matches the listener hostname",
+			parentRefs: []gwv1beta1.ParentReference{parentRef("fenced", "platform", "hostname")},
+			hostnames:  []gwv1beta1.Hostname{"example.com", "api.example.org"},
+			want:       []wantParent{{name: "fenced", port: 84, protocol: "HTTP", reason: gwv1beta1.RouteReasonNoMatchingListenerHostname}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			route := newRouteWithParents(tt.parentRefs...)
+			route.(*core.HTTPRoute).Inner().Spec.Hostnames = tt.hostnames
+
+			parents, err := GetRouteParents(context.TODO(), newRouteParentsTestClient(), route)
+			assert.NoError(t, err)
+
+			var got []wantParent
+			for _, parent := range parents {
+				assert.Equal(t, parent.Reason == "", parent.Accepted())
+				got = append(got, wantParent{
+					name:     parent.Gateway.Name,
+					port:     parent.Port,
+					protocol: parent.Protocol,
+					certARN:  parent.CertARN,
+					reason:   parent.Reason,
+				})
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_BuildForMultipleParents(t *testing.T) {
+	ctx := context.TODO()
+	route := newRouteWithParents(
+		parentRef("gw1", "", "http"),
+		parentRef("gw1", "", "https"),
+		parentRef("gw2", "other-ns", "http"),
+		parentRef("gw2", "other-ns", "https-80"),
+	)
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+	task := &latticeServiceModelBuildTask{
+		log:             gwlog.FallbackLogger,
+		route:           route,
+		stack:           stack,
+		client:          newRouteParentsTestClient(),
+		listenerByResID: make(map[string]*model.Listener),
+		datastore:       latticestore.NewLatticeDataStore(),
+		latticeService:  &model.Service{},
+	}
+
+	assert.NoError(t, task.buildListeners(ctx))
+	assert.NoError(t, task.buildRules(ctx))
+
+	var resListener []*model.Listener
+	stack.ListResources(&resListener)
+	var listeners []string
+	for _, listener := range resListener {
+		listeners = append(listeners, fmt.Sprintf("%s/%d", listener.Spec.Protocol, listener.Spec.Port))
+	}
+	assert.ElementsMatch(t, []string{"HTTP/80", "HTTPS/443", "HTTP/8080"}, listeners)
+	assert.Equal(t, "cert-1", task.latticeService.Spec.CustomerCertARN)
+
+	var resRule []*model.Rule
+	stack.ListResources(&resRule)
+	var rules []string
+	for _, rule := range resRule {
+		rules = append(rules, fmt.Sprintf("%s/%d", rule.Spec.ListenerProtocol, rule.Spec.ListenerPort))
+	}
+	assert.ElementsMatch(t, []string{"HTTP/80", "HTTPS/443", "HTTP/8080"}, rules)
+}
+
+func newTLSRouteWithParents(parentRefs ...gwv1beta1.ParentReference) core.Route {
+	var serviceKind gwv1beta1.Kind = "Service"
+
+	return core.NewTLSRoute(gwv1alpha2.TLSRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tls-route",
+			Namespace: "default",
+		},
+		Spec: gwv1alpha2.TLSRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: parentRefs,
+			},
+			Hostnames: []gwv1beta1.Hostname{"tls.example.com"},
+			Rules: []gwv1alpha2.TLSRouteRule{{
+				BackendRefs: []gwv1beta1.BackendRef{{
+					BackendObjectReference: gwv1beta1.BackendObjectReference{
+						Name: "service1",
+						Kind: &serviceKind,
+					},
+				}},
+			}},
+		},
+	})
+}
+
+func Test_GetRouteParents_TLS(t *testing.T) {
+	tests := []struct {
+		name       string
+		route      core.Route
+		wantReason gwv1beta1.RouteConditionReason
+	}{
+		{
+			name:  "TLSRoute on TLS passthrough listener",
+			route: newTLSRouteWithParents(parentRef("tls-gw", "", "tls")),
+		},
+		{
+			name:  "TLSRoute on TLS passthrough listener without section name",
+			route: newTLSRouteWithParents(parentRef("tls-gw", "", "")),
+		},
+		{
+			name:       "TLSRoute on TLS terminate listener",
+			route:      newTLSRouteWithParents(parentRef("tls-gw", "", "tls-terminate")),
+			wantReason: gwv1beta1.RouteReasonNoMatchingParent,
+		},
+		{
+			name:       "TLSRoute on HTTP listener",
+			route:      newTLSRouteWithParents(parentRef("gw1", "", "http")),
+			wantReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+		{
+			name:       "HTTPRoute on TLS listener",
+			route:      newRouteWithParents(parentRef("tls-gw", "", "tls")),
+			wantReason: gwv1beta1.RouteReasonNotAllowedByListeners,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parents, err := GetRouteParents(context.TODO(), newRouteParentsTestClient(), tt.route)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(parents))
+			assert.Equal(t, tt.wantReason, parents[0].Reason)
+			if tt.wantReason == "" {
+				assert.Equal(t, int64(443), parents[0].Port)
+				assert.Equal(t, "TLS", parents[0].Protocol)
+			}
+		})
+	}
+}
+
+func Test_BuildForTLSRoute(t *testing.T) {
+	ctx := context.TODO()
+	route := newTLSRouteWithParents(parentRef("tls-gw", "", "tls"))
+
+	stack := core.NewDefaultStack(core.StackID(k8s.NamespacedName(route.K8sObject())))
+	task := &latticeServiceModelBuildTask{
+		log:             gwlog.FallbackLogger,
+		route:           route,
+		stack:           stack,
+		client:          newRouteParentsTestClient(),
+		listenerByResID: make(map[string]*model.Listener),
+		datastore:       latticestore.NewLatticeDataStore(),
+	}
+
+	assert.NoError(t, task.buildLatticeService(ctx))
+	assert.Equal(t, core.TlsRouteType, task.latticeService.Spec.RouteType)
+	assert.Equal(t, "tls.example.com", task.latticeService.Spec.CustomerDomainName)
+
+	assert.NoError(t, task.buildListeners(ctx))
+	assert.NoError(t, task.buildRules(ctx))
+
+	var resListener []*model.Listener
+	stack.ListResources(&resListener)
+	assert.Equal(t, 1, len(resListener))
+	assert.Equal(t, model.ListenerProtocolTLSPassthrough, resListener[0].Spec.Protocol)
+	assert.Equal(t, int64(443), resListener[0].Spec.Port)
+	assert.NotNil(t, resListener[0].Spec.DefaultAction.Forward)
+
+	// TLS passthrough listeners have no rules
+	var resRule []*model.Rule
+	stack.ListResources(&resRule)
+	assert.Empty(t, resRule)
+
+	// a TLSRoute without hostname cannot be routed by SNI
+	route.(*core.TLSRoute).Inner().Spec.Hostnames = nil
+	assert.Error(t, task.buildLatticeService(ctx))
+}
+
+func Test_hostnamesIntersect(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want bool
+	}{
+		{"example.com", "example.com", true},
+		{"example.com", "api.example.com", false},
+		{"*.example.com", "api.example.com", true},
+		{"*.example.com", "v1.api.example.com", true},
+		{"*.example.com", "example.com", false},
+		{"*.example.com", "api.example.org", false},
+		{"*.example.com", "*.api.example.com", true},
+		{"*.example.com", "*.example.com", true},
+		{"*.api.example.com", "*.web.example.com", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, hostnamesIntersect(tt.a, tt.b), "%s and %s", tt.a, tt.b)
+		assert.Equal(t, tt.want, hostnamesIntersect(tt.b, tt.a), "%s and %s", tt.b, tt.a)
+	}
+}
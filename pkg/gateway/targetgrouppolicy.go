@@ -0,0 +1,355 @@
+package gateway
+
+import (
+	"context"
+
+	"golang.org/x/exp/slices"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+const (
+	targetRefKindService       gwv1beta1.Kind = "Service"
+	targetRefKindNamespace     gwv1beta1.Kind = "Namespace"
+	targetRefKindGateway       gwv1beta1.Kind = "Gateway"
+	targetRefKindServiceExport gwv1beta1.Kind = "ServiceExport"
+	targetRefKindHTTPRoute     gwv1beta1.Kind = "HTTPRoute"
+	targetRefKindGRPCRoute     gwv1beta1.Kind = "GRPCRoute"
+)
+
+// GetTargetGroupPolicy returns the TargetGroupPolicy in effect for the Service svcName, nil if there is none.
+//
+// Following Gateway API policy attachment, a TargetGroupPolicy attached to a Namespace sets defaults for the
+// Services of the namespace, one attached to a Gateway sets defaults for the Services behind its routes, which take
+// precedence over the ones of the Namespace, and one attached to the Service overrides both, field by field.
+// When several policies are attached to the same level, the oldest one wins.
+//
+// Policies attached to a route backend or to a ServiceExport only apply to the target group of the route or of
+// the export, see GetRouteTargetGroupPolicy and GetServiceExportTargetGroupPolicy.
+func GetTargetGroupPolicy(ctx context.Context, k8sClient client.Client, svcName types.NamespacedName) (*anv1alpha1.TargetGroupPolicy, error) {
+	return getTargetGroupPolicy(ctx, k8sClient, svcName, nil, false)
+}
+
+// GetRouteTargetGroupPolicy returns the TargetGroupPolicy in effect for the target group of the Service svcName
+// created for route, nil if there is none. A policy attached to the backend of the route overrides the ones of
+// GetTargetGroupPolicy, field by field, and only the policies of the parent Gateways of route apply.
+func GetRouteTargetGroupPolicy(ctx context.Context, k8sClient client.Client, route core.Route, svcName types.NamespacedName) (*anv1alpha1.TargetGroupPolicy, error) {
+	return getTargetGroupPolicy(ctx, k8sClient, svcName, route, false)
+}
+
+// GetServiceExportTargetGroupPolicy returns the TargetGroupPolicy in effect for the target group of the exported
+// Service svcName, nil if there is none. A policy attached to the ServiceExport overrides the ones attached to the
+// Service and its Namespace, field by field. Exported Services are not behind a Gateway.
+func GetServiceExportTargetGroupPolicy(ctx context.Context, k8sClient client.Client, svcName types.NamespacedName) (*anv1alpha1.TargetGroupPolicy, error) {
+	return getTargetGroupPolicy(ctx, k8sClient, svcName, nil, true)
+}
+
+func getTargetGroupPolicy(
+	ctx context.Context,
+	k8sClient client.Client,
+	svcName types.NamespacedName,
+	route core.Route,
+	export bool,
+) (*anv1alpha1.TargetGroupPolicy, error) {
+	tgpList := &anv1alpha1.TargetGroupPolicyList{}
+	if err := k8sClient.List(ctx, tgpList); err != nil {
+		if meta.IsNoMatchError(err) {
+			// CRD does not exist
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var namespacePolicy, gatewayPolicy, servicePolicy, targetGroupPolicy *anv1alpha1.TargetGroupPolicy
+	var gatewayPolicies []*anv1alpha1.TargetGroupPolicy
+	for i := range tgpList.Items {
+		tgp := &tgpList.Items[i]
+		switch {
+		case IsPolicyAttachedTo(tgp, corev1.GroupName, targetRefKindService, svcName):
+			servicePolicy = olderTargetGroupPolicy(servicePolicy, tgp)
+		case IsPolicyAttachedTo(tgp, corev1.GroupName, targetRefKindNamespace, namespaceNamespacedName(svcName.Namespace)):
+			namespacePolicy = olderTargetGroupPolicy(namespacePolicy, tgp)
+		case export && IsPolicyAttachedTo(tgp, mcsv1alpha1.GroupName, targetRefKindServiceExport, svcName):
+			targetGroupPolicy = olderTargetGroupPolicy(targetGroupPolicy, tgp)
+		case route != nil && isTargetGroupPolicyAttachedToRouteBackend(tgp, route, svcName):
+			targetGroupPolicy = olderTargetGroupPolicy(targetGroupPolicy, tgp)
+		case !export && tgp.Spec.TargetRef != nil && tgp.Spec.TargetRef.Group == gwv1beta1.GroupName && tgp.Spec.TargetRef.Kind == targetRefKindGateway:
+			gatewayPolicies = append(gatewayPolicies, tgp)
+		}
+	}
+
+	if len(gatewayPolicies) > 0 {
+		var gateways []types.NamespacedName
+		if route != nil {
+			gateways = routeParentGateways(route)
+		} else {
+			var err error
+			if gateways, err = getServiceGateways(ctx, k8sClient, svcName); err != nil {
+				return nil, err
+			}
+		}
+		for _, tgp := range gatewayPolicies {
+			for _, gw := range gateways {
+				if IsPolicyAttachedTo(tgp, gwv1beta1.GroupName, targetRefKindGateway, gw) {
+					gatewayPolicy = olderTargetGroupPolicy(gatewayPolicy, tgp)
+				}
+			}
+		}
+	}
+
+	var effective *anv1alpha1.TargetGroupPolicy
+	for _, tgp := range []*anv1alpha1.TargetGroupPolicy{namespacePolicy, gatewayPolicy, servicePolicy, targetGroupPolicy} {
+		if tgp == nil {
+			continue
+		}
+		if effective == nil {
+			effective = tgp.DeepCopy()
+			continue
+		}
+		spec := tgp.Spec.DeepCopy()
+		mergeTargetGroupPolicySpec(spec, &effective.Spec)
+		effective.ObjectMeta = *tgp.ObjectMeta.DeepCopy()
+		effective.Spec = *spec
+	}
+	return effective, nil
+}
+
+// IsTargetGroupPolicyTargetKind tells whether a TargetGroupPolicy can be attached to objects of group and kind
+func IsTargetGroupPolicyTargetKind(group gwv1beta1.Group, kind gwv1beta1.Kind) bool {
+	switch kind {
+	case targetRefKindService, targetRefKindNamespace:
+		return group == corev1.GroupName
+	case targetRefKindGateway, targetRefKindHTTPRoute, targetRefKindGRPCRoute:
+		return group == gwv1beta1.GroupName
+	case targetRefKindServiceExport:
+		return group == mcsv1alpha1.GroupName
+	}
+	return false
+}
+
+// GetTargetGroupPolicyRouteBackend returns the name of the Service backend of route which tgp is attached to, as
+// the sectionName of its targetRef, false when tgp is not attached to a backend of route. The sectionName only names
+// the Service, so it refers to a backend in the namespace of the route, like a backendRef without a namespace.
+func GetTargetGroupPolicyRouteBackend(tgp *anv1alpha1.TargetGroupPolicy, route core.Route) (types.NamespacedName, bool) {
+	targetRef := tgp.Spec.TargetRef
+	routeName := types.NamespacedName{Namespace: route.Namespace(), Name: route.Name()}
+	routeKind := core.RouteKind(route)
+	if targetRef == nil || targetRef.SectionName == nil || !IsTargetGroupPolicyTargetKind(gwv1beta1.GroupName, routeKind) ||
+		!IsPolicyAttachedTo(tgp, gwv1beta1.GroupName, routeKind, routeName) {
+		return types.NamespacedName{}, false
+	}
+	sectionBackend := types.NamespacedName{Namespace: route.Namespace(), Name: string(*targetRef.SectionName)}
+	if slices.Contains(routeServiceBackends(route), sectionBackend) {
+		return sectionBackend, true
+	}
+	return types.NamespacedName{}, false
+}
+
+func isTargetGroupPolicyAttachedToRouteBackend(tgp *anv1alpha1.TargetGroupPolicy, route core.Route, svcName types.NamespacedName) bool {
+	backend, ok := GetTargetGroupPolicyRouteBackend(tgp, route)
+	return ok && backend == svcName
+}
+
+// mergeTargetGroupPolicySpec sets the fields of spec which are not set to the ones of defaults
+func mergeTargetGroupPolicySpec(spec *anv1alpha1.TargetGroupPolicySpec, defaults *anv1alpha1.TargetGroupPolicySpec) {
+	if spec.Protocol == nil {
+		spec.Protocol = defaults.Protocol
+	}
+	if spec.ProtocolVersion == nil {
+		spec.ProtocolVersion = defaults.ProtocolVersion
+	}
+	if spec.TargetType == nil {
+		spec.TargetType = defaults.TargetType
+	}
+	if spec.IpAddressType == nil {
+		spec.IpAddressType = defaults.IpAddressType
+	}
+	if spec.DrainingTimeoutSeconds == nil {
+		spec.DrainingTimeoutSeconds = defaults.DrainingTimeoutSeconds
+	}
+	if defaults.HealthCheck == nil {
+		return
+	}
+	if spec.HealthCheck == nil {
+		spec.HealthCheck = defaults.HealthCheck
+		return
+	}
+	hc, hcDefaults := spec.HealthCheck, defaults.HealthCheck
+	if hc.Enabled == nil {
+		hc.Enabled = hcDefaults.Enabled
+	}
+	if hc.IntervalSeconds == nil {
+		hc.IntervalSeconds = hcDefaults.IntervalSeconds
+	}
+	if hc.TimeoutSeconds == nil {
+		hc.TimeoutSeconds = hcDefaults.TimeoutSeconds
+	}
+	if hc.HealthyThresholdCount == nil {
+		hc.HealthyThresholdCount = hcDefaults.HealthyThresholdCount
+	}
+	if hc.UnhealthyThresholdCount == nil {
+		hc.UnhealthyThresholdCount = hcDefaults.UnhealthyThresholdCount
+	}
+	if hc.StatusMatch == nil {
+		hc.StatusMatch = hcDefaults.StatusMatch
+	}
+	if hc.Path == nil {
+		hc.Path = hcDefaults.Path
+	}
+	if hc.Port == nil {
+		hc.Port = hcDefaults.Port
+	}
+	if hc.Protocol == nil {
+		hc.Protocol = hcDefaults.Protocol
+	}
+	if hc.ProtocolVersion == nil {
+		hc.ProtocolVersion = hcDefaults.ProtocolVersion
+	}
+}
+
+func olderTargetGroupPolicy(a, b *anv1alpha1.TargetGroupPolicy) *anv1alpha1.TargetGroupPolicy {
+	if a == nil || IsOlderPolicy(b, a) {
+		return b
+	}
+	return a
+}
+
+// namespaceNamespacedName is the name of a Namespace as targetRef, policies attached to a Namespace are in it
+func namespaceNamespacedName(namespace string) types.NamespacedName {
+	return types.NamespacedName{Namespace: namespace, Name: namespace}
+}
+
+// IsTargetGroupPolicyInUse tells whether tgp applies to a Service which is a backend of a route or is exported,
+// i.e. whether the policy configures target groups
+func IsTargetGroupPolicyInUse(ctx context.Context, k8sClient client.Client, tgp *anv1alpha1.TargetGroupPolicy) (bool, error) {
+	targetRef := tgp.Spec.TargetRef
+	if targetRef == nil {
+		return false, nil
+	}
+	target := types.NamespacedName{Namespace: tgp.Namespace, Name: string(targetRef.Name)}
+
+	routes, err := core.ListAllRoutes(ctx, k8sClient)
+	if err != nil {
+		return false, err
+	}
+	for _, route := range routes {
+		if !route.DeletionTimestamp().IsZero() {
+			continue
+		}
+		backends := routeServiceBackends(route)
+		switch targetRef.Kind {
+		case targetRefKindService:
+			if slices.Contains(backends, target) {
+				return true, nil
+			}
+		case targetRefKindNamespace:
+			for _, backend := range backends {
+				if backend.Namespace == target.Namespace {
+					return true, nil
+				}
+			}
+		case targetRefKindGateway:
+			if len(backends) > 0 && slices.Contains(routeParentGateways(route), target) {
+				return true, nil
+			}
+		case targetRefKindHTTPRoute, targetRefKindGRPCRoute:
+			if _, ok := GetTargetGroupPolicyRouteBackend(tgp, route); ok {
+				return true, nil
+			}
+		}
+	}
+
+	if targetRef.Kind != targetRefKindService && targetRef.Kind != targetRefKindNamespace &&
+		targetRef.Kind != targetRefKindServiceExport {
+		// exported Services are neither behind a Gateway nor backends of routes
+		return false, nil
+	}
+	exports := &mcsv1alpha1.ServiceExportList{}
+	if err := k8sClient.List(ctx, exports, client.InNamespace(tgp.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			// CRD does not exist
+			return false, nil
+		}
+		return false, err
+	}
+	for _, export := range exports.Items {
+		if targetRef.Kind == targetRefKindNamespace || export.Name == target.Name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RouteServiceBackendIndex is the field index of routes by the Services in their backendRefs, as namespace/name
+const RouteServiceBackendIndex = "routeServiceBackends"
+
+// IndexRouteServiceBackends is the IndexerFunc of RouteServiceBackendIndex for HTTPRoutes, GRPCRoutes and TLSRoutes
+func IndexRouteServiceBackends(obj client.Object) []string {
+	route, err := core.NewRoute(obj)
+	if err != nil {
+		return nil
+	}
+	var services []string
+	for _, svc := range routeServiceBackends(route) {
+		services = append(services, svc.String())
+	}
+	return services
+}
+
+// getServiceGateways returns the Gateways which are parents of the routes with a backendRef to the Service svcName,
+// looking the routes up with RouteServiceBackendIndex
+func getServiceGateways(ctx context.Context, k8sClient client.Client, svcName types.NamespacedName) ([]types.NamespacedName, error) {
+	routes, err := core.ListAllRoutes(ctx, k8sClient, client.MatchingFields{RouteServiceBackendIndex: svcName.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var gateways []types.NamespacedName
+	for _, route := range routes {
+		gateways = append(gateways, routeParentGateways(route)...)
+	}
+	return gateways, nil
+}
+
+// routeParentGateways returns the names of the Gateways in the parentRefs of route
+func routeParentGateways(route core.Route) []types.NamespacedName {
+	var gateways []types.NamespacedName
+	for _, parentRef := range route.Spec().ParentRefs() {
+		if parentRef.Group != nil && *parentRef.Group != gwv1beta1.GroupName ||
+			parentRef.Kind != nil && *parentRef.Kind != targetRefKindGateway {
+			continue
+		}
+		gw := types.NamespacedName{Namespace: route.Namespace(), Name: string(parentRef.Name)}
+		if parentRef.Namespace != nil {
+			gw.Namespace = string(*parentRef.Namespace)
+		}
+		gateways = append(gateways, gw)
+	}
+	return gateways
+}
+
+// routeServiceBackends returns the names of the Services in the backendRefs of route
+func routeServiceBackends(route core.Route) []types.NamespacedName {
+	var services []types.NamespacedName
+	for _, rule := range route.Spec().Rules() {
+		for _, backendRef := range rule.BackendRefs() {
+			if backendRef.Group() != nil && *backendRef.Group() != corev1.GroupName ||
+				backendRef.Kind() != nil && *backendRef.Kind() != targetRefKindService {
+				continue
+			}
+			svc := types.NamespacedName{Namespace: route.Namespace(), Name: string(backendRef.Name())}
+			if backendRef.Namespace() != nil {
+				svc.Namespace = string(*backendRef.Namespace())
+			}
+			services = append(services, svc)
+		}
+	}
+	return services
+}
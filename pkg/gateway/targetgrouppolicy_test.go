@@ -0,0 +1,328 @@
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	testclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+	mcsv1alpha1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
+
+	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
+	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+)
+
+func newTargetGroupPolicy(name string, age time.Duration, group gwv1beta1.Group, kind gwv1beta1.Kind, target string,
+	spec anv1alpha1.TargetGroupPolicySpec) *anv1alpha1.TargetGroupPolicy {
+	spec.TargetRef = &anv1alpha1.PolicyTargetReferenceWithSectionName{
+		PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+			Group: group,
+			Kind:  kind,
+			Name:  gwv1alpha2.ObjectName(target),
+		},
+	}
+	return &anv1alpha1.TargetGroupPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "ns1",
+			CreationTimestamp: metav1.NewTime(time.Unix(10000, 0).Add(-age)),
+		},
+		Spec: spec,
+	}
+}
+
+// withSectionName sets the sectionName of the targetRef of tgp
+func withSectionName(tgp *anv1alpha1.TargetGroupPolicy, sectionName string) *anv1alpha1.TargetGroupPolicy {
+	tgp.Spec.TargetRef.SectionName = (*gwv1beta1.SectionName)(&sectionName)
+	return tgp
+}
+
+func newTargetGroupPolicyTestClient(objs ...client.Object) client.Client {
+	k8sSchema := runtime.NewScheme()
+	clientgoscheme.AddToScheme(k8sSchema)
+	anv1alpha1.AddToScheme(k8sSchema)
+	gwv1beta1.AddToScheme(k8sSchema)
+	gwv1alpha2.AddToScheme(k8sSchema)
+	mcsv1alpha1.AddToScheme(k8sSchema)
+	return testclient.NewClientBuilder().WithScheme(k8sSchema).WithObjects(objs...).
+		WithIndex(&gwv1beta1.HTTPRoute{}, RouteServiceBackendIndex, IndexRouteServiceBackends).
+		WithIndex(&gwv1alpha2.GRPCRoute{}, RouteServiceBackendIndex, IndexRouteServiceBackends).
+		WithIndex(&gwv1alpha2.TLSRoute{}, RouteServiceBackendIndex, IndexRouteServiceBackends).
+		Build()
+}
+
+// newTargetGroupPolicyTestRoute returns a route of Gateway gw in ns1 to the Service svcName
+func newTargetGroupPolicyTestRoute(svcName string) *gwv1beta1.HTTPRoute {
+	return &gwv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "route", Namespace: "ns1"},
+		Spec: gwv1beta1.HTTPRouteSpec{
+			CommonRouteSpec: gwv1beta1.CommonRouteSpec{
+				ParentRefs: []gwv1beta1.ParentReference{{Name: "gw"}},
+			},
+			Rules: []gwv1beta1.HTTPRouteRule{{
+				BackendRefs: []gwv1beta1.HTTPBackendRef{{
+					BackendRef: gwv1beta1.BackendRef{
+						BackendObjectReference: gwv1beta1.BackendObjectReference{Name: gwv1beta1.ObjectName(svcName)},
+					},
+				}},
+			}},
+		},
+	}
+}
+
+func Test_GetTargetGroupPolicy(t *testing.T) {
+	ctx := context.Background()
+	svcName := types.NamespacedName{Namespace: "ns1", Name: "svc"}
+
+	route := newTargetGroupPolicyTestRoute("svc")
+	instance := anv1alpha1.TargetTypeInstance
+	namespacePolicy := newTargetGroupPolicy("namespace", time.Hour, "", "Namespace", "ns1",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol:               aws.String("HTTPS"),
+			TargetType:             &instance,
+			DrainingTimeoutSeconds: aws.Int64(30),
+			HealthCheck: &anv1alpha1.HealthCheckConfig{
+				Path:            aws.String("/health"),
+				IntervalSeconds: aws.Int64(10),
+			},
+		})
+	gatewayPolicy := newTargetGroupPolicy("gateway", time.Hour, gwv1beta1.GroupName, "Gateway", "gw",
+		anv1alpha1.TargetGroupPolicySpec{
+			ProtocolVersion: aws.String("HTTP2"),
+			HealthCheck: &anv1alpha1.HealthCheckConfig{
+				IntervalSeconds: aws.Int64(20),
+			},
+		})
+	servicePolicy := newTargetGroupPolicy("service", time.Hour, "", "Service", "svc",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("HTTP"),
+			HealthCheck: &anv1alpha1.HealthCheckConfig{
+				Path: aws.String("/ready"),
+			},
+		})
+	newerServicePolicy := newTargetGroupPolicy("newer-service", time.Minute, "", "Service", "svc",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("GRPC"),
+		})
+	otherServicePolicy := newTargetGroupPolicy("other-service", time.Hour, "", "Service", "other-svc",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("GRPC"),
+		})
+
+	t.Run("no policy", func(t *testing.T) {
+		tgp, err := GetTargetGroupPolicy(ctx, newTargetGroupPolicyTestClient(otherServicePolicy), svcName)
+		assert.NoError(t, err)
+		assert.Nil(t, tgp)
+	})
+
+	t.Run("namespace defaults", func(t *testing.T) {
+		tgp, err := GetTargetGroupPolicy(ctx, newTargetGroupPolicyTestClient(namespacePolicy), svcName)
+		assert.NoError(t, err)
+		assert.Equal(t, "namespace", tgp.Name)
+		assert.Equal(t, namespacePolicy.Spec, tgp.Spec)
+	})
+
+	t.Run("gateway policy without route to the service", func(t *testing.T) {
+		k8sClient := newTargetGroupPolicyTestClient(newTargetGroupPolicyTestRoute("other-svc"), gatewayPolicy)
+		tgp, err := GetTargetGroupPolicy(ctx, k8sClient, svcName)
+		assert.NoError(t, err)
+		assert.Nil(t, tgp)
+	})
+
+	t.Run("service overrides gateway and namespace defaults", func(t *testing.T) {
+		k8sClient := newTargetGroupPolicyTestClient(route, namespacePolicy, gatewayPolicy, servicePolicy, newerServicePolicy)
+		tgp, err := GetTargetGroupPolicy(ctx, k8sClient, svcName)
+		assert.NoError(t, err)
+		assert.Equal(t, "service", tgp.Name)
+		assert.Equal(t, "Service", string(tgp.Spec.TargetRef.Kind))
+		assert.Equal(t, "HTTP", *tgp.Spec.Protocol)
+		assert.Equal(t, "HTTP2", *tgp.Spec.ProtocolVersion)
+		assert.Equal(t, anv1alpha1.TargetTypeInstance, *tgp.Spec.TargetType)
+		assert.Equal(t, int64(30), *tgp.Spec.DrainingTimeoutSeconds)
+		assert.Equal(t, "/ready", *tgp.Spec.HealthCheck.Path)
+		assert.Equal(t, int64(20), *tgp.Spec.HealthCheck.IntervalSeconds)
+
+		// defaults are not modified
+		assert.Nil(t, gatewayPolicy.Spec.HealthCheck.Path)
+	})
+}
+
+func Test_GetRouteAndServiceExportTargetGroupPolicy(t *testing.T) {
+	ctx := context.Background()
+	svcName := types.NamespacedName{Namespace: "ns1", Name: "svc"}
+
+	route := newTargetGroupPolicyTestRoute("svc")
+	gatewayPolicy := newTargetGroupPolicy("gateway", time.Hour, gwv1beta1.GroupName, "Gateway", "gw",
+		anv1alpha1.TargetGroupPolicySpec{
+			ProtocolVersion: aws.String("HTTP2"),
+		})
+	servicePolicy := newTargetGroupPolicy("service", time.Hour, "", "Service", "svc",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("HTTP"),
+			HealthCheck: &anv1alpha1.HealthCheckConfig{
+				Path: aws.String("/ready"),
+			},
+		})
+	routePolicy := withSectionName(newTargetGroupPolicy("route", time.Hour, gwv1beta1.GroupName, "HTTPRoute", "route",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("HTTPS"),
+			HealthCheck: &anv1alpha1.HealthCheckConfig{
+				IntervalSeconds: aws.Int64(5),
+			},
+		}), "svc")
+	otherBackendPolicy := withSectionName(newTargetGroupPolicy("other-backend", 2*time.Hour, gwv1beta1.GroupName, "HTTPRoute", "route",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("GRPC"),
+		}), "other-svc")
+	exportPolicy := newTargetGroupPolicy("export", time.Hour, mcsv1alpha1.GroupName, "ServiceExport", "svc",
+		anv1alpha1.TargetGroupPolicySpec{
+			Protocol: aws.String("TCP"),
+		})
+	k8sClient := newTargetGroupPolicyTestClient(route, gatewayPolicy, servicePolicy, routePolicy, otherBackendPolicy, exportPolicy)
+
+	t.Run("route backend overrides service and gateway", func(t *testing.T) {
+		tgp, err := GetRouteTargetGroupPolicy(ctx, k8sClient, core.NewHTTPRoute(*route), svcName)
+		assert.NoError(t, err)
+		assert.Equal(t, "route", tgp.Name)
+		assert.Equal(t, "HTTPS", *tgp.Spec.Protocol)
+		assert.Equal(t, "HTTP2", *tgp.Spec.ProtocolVersion)
+		assert.Equal(t, "/ready", *tgp.Spec.HealthCheck.Path)
+		assert.Equal(t, int64(5), *tgp.Spec.HealthCheck.IntervalSeconds)
+	})
+
+	t.Run("service export overrides service without gateway defaults", func(t *testing.T) {
+		tgp, err := GetServiceExportTargetGroupPolicy(ctx, k8sClient, svcName)
+		assert.NoError(t, err)
+		assert.Equal(t, "export", tgp.Name)
+		assert.Equal(t, "TCP", *tgp.Spec.Protocol)
+		assert.Nil(t, tgp.Spec.ProtocolVersion)
+		assert.Equal(t, "/ready", *tgp.Spec.HealthCheck.Path)
+	})
+
+	t.Run("service ignores route backend and service export policies", func(t *testing.T) {
+		tgp, err := GetTargetGroupPolicy(ctx, k8sClient, svcName)
+		assert.NoError(t, err)
+		assert.Equal(t, "service", tgp.Name)
+		assert.Equal(t, "HTTP", *tgp.Spec.Protocol)
+		assert.Nil(t, tgp.Spec.HealthCheck.IntervalSeconds)
+	})
+
+	t.Run("route backend policy of another route", func(t *testing.T) {
+		otherRoute := newTargetGroupPolicyTestRoute("svc")
+		otherRoute.Name = "other-route"
+		tgp, err := GetRouteTargetGroupPolicy(ctx, k8sClient, core.NewHTTPRoute(*otherRoute), svcName)
+		assert.NoError(t, err)
+		assert.Equal(t, "service", tgp.Name)
+		assert.Equal(t, "HTTP", *tgp.Spec.Protocol)
+	})
+}
+
+func Test_GetTargetGroupPolicyRouteBackend(t *testing.T) {
+	otherNamespace := gwv1beta1.Namespace("ns2")
+	route := newTargetGroupPolicyTestRoute("svc")
+	route.Spec.Rules[0].BackendRefs = append(route.Spec.Rules[0].BackendRefs, gwv1beta1.HTTPBackendRef{
+		BackendRef: gwv1beta1.BackendRef{
+			BackendObjectReference: gwv1beta1.BackendObjectReference{Name: "remote-svc", Namespace: &otherNamespace},
+		},
+	})
+	newRoutePolicy := func(sectionName string) *anv1alpha1.TargetGroupPolicy {
+		return withSectionName(newTargetGroupPolicy("route", time.Hour, gwv1beta1.GroupName, "HTTPRoute", "route",
+			anv1alpha1.TargetGroupPolicySpec{}), sectionName)
+	}
+
+	backend, ok := GetTargetGroupPolicyRouteBackend(newRoutePolicy("svc"), core.NewHTTPRoute(*route))
+	assert.True(t, ok)
+	assert.Equal(t, types.NamespacedName{Namespace: "ns1", Name: "svc"}, backend)
+
+	// the sectionName names a backend in the namespace of the route
+	_, ok = GetTargetGroupPolicyRouteBackend(newRoutePolicy("remote-svc"), core.NewHTTPRoute(*route))
+	assert.False(t, ok)
+
+	_, ok = GetTargetGroupPolicyRouteBackend(newRoutePolicy("unknown-svc"), core.NewHTTPRoute(*route))
+	assert.False(t, ok)
+}
+
+func Test_GetConflictingPolicy(t *testing.T) {
+	ctx := context.Background()
+	older := newTargetGroupPolicy("older", time.Hour, "", "Service", "svc", anv1alpha1.TargetGroupPolicySpec{})
+	newer := newTargetGroupPolicy("newer", time.Minute, "", "Service", "svc", anv1alpha1.TargetGroupPolicySpec{})
+	sameAge := newTargetGroupPolicy("same-age", time.Minute, "", "Service", "svc", anv1alpha1.TargetGroupPolicySpec{})
+	other := newTargetGroupPolicy("other", 2*time.Hour, "", "Service", "other-svc", anv1alpha1.TargetGroupPolicySpec{})
+	k8sClient := newTargetGroupPolicyTestClient(older, newer, sameAge, other)
+
+	conflicting, err := GetConflictingPolicy(ctx, k8sClient, older)
+	assert.NoError(t, err)
+	assert.Nil(t, conflicting)
+
+	conflicting, err = GetConflictingPolicy(ctx, k8sClient, newer)
+	assert.NoError(t, err)
+	assert.Equal(t, "older", conflicting.GetNamespacedName().Name)
+
+	// ties are broken by name
+	assert.True(t, IsOlderPolicy(newer, sameAge))
+	assert.False(t, IsOlderPolicy(sameAge, newer))
+
+	// policies attached to different sections of the same route do not conflict
+	olderBackend := withSectionName(newTargetGroupPolicy("older-backend", time.Hour, gwv1beta1.GroupName, "HTTPRoute", "route",
+		anv1alpha1.TargetGroupPolicySpec{}), "svc")
+	newerBackend := withSectionName(newTargetGroupPolicy("newer-backend", time.Minute, gwv1beta1.GroupName, "HTTPRoute", "route",
+		anv1alpha1.TargetGroupPolicySpec{}), "svc")
+	otherBackend := withSectionName(newTargetGroupPolicy("other-backend", time.Minute, gwv1beta1.GroupName, "HTTPRoute", "route",
+		anv1alpha1.TargetGroupPolicySpec{}), "other-svc")
+	k8sClient = newTargetGroupPolicyTestClient(olderBackend, newerBackend, otherBackend)
+
+	conflicting, err = GetConflictingPolicy(ctx, k8sClient, newerBackend)
+	assert.NoError(t, err)
+	assert.Equal(t, "older-backend", conflicting.GetNamespacedName().Name)
+
+	conflicting, err = GetConflictingPolicy(ctx, k8sClient, otherBackend)
+	assert.NoError(t, err)
+	assert.Nil(t, conflicting)
+}
+
+func Test_IsTargetGroupPolicyInUse(t *testing.T) {
+	ctx := context.Background()
+	servicePolicy := newTargetGroupPolicy("service", time.Hour, "", "Service", "svc", anv1alpha1.TargetGroupPolicySpec{})
+	namespacePolicy := newTargetGroupPolicy("namespace", time.Hour, "", "Namespace", "ns1", anv1alpha1.TargetGroupPolicySpec{})
+	gatewayPolicy := newTargetGroupPolicy("gateway", time.Hour, gwv1beta1.GroupName, "Gateway", "gw", anv1alpha1.TargetGroupPolicySpec{})
+	export := &mcsv1alpha1.ServiceExport{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns1"}}
+	routePolicy := withSectionName(newTargetGroupPolicy("route", time.Hour, gwv1beta1.GroupName, "HTTPRoute", "route",
+		anv1alpha1.TargetGroupPolicySpec{}), "svc")
+	exportPolicy := newTargetGroupPolicy("export", time.Hour, mcsv1alpha1.GroupName, "ServiceExport", "svc", anv1alpha1.TargetGroupPolicySpec{})
+
+	tests := []struct {
+		name   string
+		tgp    *anv1alpha1.TargetGroupPolicy
+		objs   []client.Object
+		expect bool
+	}{
+		{name: "service behind route", tgp: servicePolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("svc")}, expect: true},
+		{name: "other service behind route", tgp: servicePolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("other-svc")}, expect: false},
+		{name: "service exported", tgp: servicePolicy, objs: []client.Object{export}, expect: true},
+		{name: "service unused", tgp: servicePolicy, expect: false},
+		{name: "namespace with service behind route", tgp: namespacePolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("other-svc")}, expect: true},
+		{name: "namespace with exported service", tgp: namespacePolicy, objs: []client.Object{export}, expect: true},
+		{name: "gateway with route", tgp: gatewayPolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("other-svc")}, expect: true},
+		{name: "gateway without route", tgp: gatewayPolicy, objs: []client.Object{export}, expect: false},
+		{name: "route backend", tgp: routePolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("svc")}, expect: true},
+		{name: "route without the backend", tgp: routePolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("other-svc"), export}, expect: false},
+		{name: "service export", tgp: exportPolicy, objs: []client.Object{export}, expect: true},
+		{name: "service export missing", tgp: exportPolicy, objs: []client.Object{newTargetGroupPolicyTestRoute("svc")}, expect: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inUse, err := IsTargetGroupPolicyInUse(ctx, newTargetGroupPolicyTestClient(tt.objs...), tt.tgp)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expect, inUse)
+		})
+	}
+}
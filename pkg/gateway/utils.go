@@ -12,8 +12,11 @@ import (
 
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
+	model "github.com/aws/aws-application-networking-k8s/pkg/model/lattice"
 )
 
+// GetAttachedPolicy returns the policy of the type of policy attached to the object refObjNamespacedName.
+// When several policies are attached to it, the oldest one wins, see IsOlderPolicy.
 func GetAttachedPolicy[T core.Policy](ctx context.Context, k8sClient client.Client, refObjNamespacedName types.NamespacedName, policy T) (T, error) {
 	null := *new(T)
 	policyList, expectedTargetRefObjGroup, expectedTargetRefObjKind, err := policyTypeToPolicyListAndTargetRefGroupKind(policy)
@@ -31,24 +34,139 @@ func GetAttachedPolicy[T core.Policy](ctx context.Context, k8sClient client.Clie
 		}
 		return null, err
 	}
+	var attached core.Policy
 	for _, p := range policyList.GetItems() {
-		targetRef := p.GetTargetRef()
-		if targetRef == nil {
+		if !IsPolicyAttachedTo(p, expectedTargetRefObjGroup, expectedTargetRefObjKind, refObjNamespacedName) {
 			continue
 		}
-		groupKindMatch := targetRef.Group == expectedTargetRefObjGroup && targetRef.Kind == expectedTargetRefObjKind
-		nameMatch := string(targetRef.Name) == refObjNamespacedName.Name
+		if attached == nil || IsOlderPolicy(p, attached) {
+			attached = p
+		}
+	}
+	if attached == nil {
+		return null, nil
+	}
+	return attached.(T), nil
+}
+
+// GetConflictingPolicy returns the oldest policy of the same type as policy which is attached to the same target,
+// and conflicts with it, see policiesConflict, when it is older than policy. Per Gateway API policy attachment,
+// that policy wins, and policy is conflicted.
+func GetConflictingPolicy(ctx context.Context, k8sClient client.Client, policy core.Policy) (core.Policy, error) {
+	targetRef := policy.GetTargetRef()
+	if targetRef == nil {
+		return nil, nil
+	}
+	policyList, err := policyTypeToPolicyList(policy)
+	if err != nil {
+		return nil, err
+	}
+	policyNamespacedName := policy.GetNamespacedName()
+	if err := k8sClient.List(ctx, policyList.(client.ObjectList), client.InNamespace(policyNamespacedName.Namespace)); err != nil {
+		return nil, err
+	}
+
+	targetNamespacedName := types.NamespacedName{
+		Namespace: policyNamespacedName.Namespace,
+		Name:      string(targetRef.Name),
+	}
+	if targetRef.Namespace != nil {
+		targetNamespacedName.Namespace = string(*targetRef.Namespace)
+	}
+	var conflicting core.Policy
+	for _, p := range policyList.GetItems() {
+		if p.GetNamespacedName() == policyNamespacedName ||
+			!IsPolicyAttachedTo(p, targetRef.Group, targetRef.Kind, targetNamespacedName) ||
+			!policiesConflict(p, policy) {
+			continue
+		}
+		if IsOlderPolicy(p, policy) && (conflicting == nil || IsOlderPolicy(p, conflicting)) {
+			conflicting = p
+		}
+	}
+	return conflicting, nil
+}
+
+// IsPolicyAttachedTo tells whether the targetRef of policy is the object of group and kind named refObjNamespacedName
+func IsPolicyAttachedTo(policy core.Policy, group gwv1beta1.Group, kind gwv1beta1.Kind, refObjNamespacedName types.NamespacedName) bool {
+	targetRef := policy.GetTargetRef()
+	if targetRef == nil {
+		return false
+	}
+	groupKindMatch := targetRef.Group == group && targetRef.Kind == kind
+	nameMatch := string(targetRef.Name) == refObjNamespacedName.Name
 
-		retrievedNamespace := p.GetNamespacedName().Namespace
-		if targetRef.Namespace != nil {
-			retrievedNamespace = string(*targetRef.Namespace)
+	retrievedNamespace := policy.GetNamespacedName().Namespace
+	if targetRef.Namespace != nil {
+		retrievedNamespace = string(*targetRef.Namespace)
+	}
+	namespaceMatch := retrievedNamespace == refObjNamespacedName.Namespace
+	return groupKindMatch && nameMatch && namespaceMatch
+}
+
+// policiesConflict tells whether two policies attached to the same target conflict. Policies attached to different
+// sections of the target do not, nor do AccessLogPolicies of different scopes or without destinations of the same
+// type, as VPC Lattice has one access log subscription of each destination type for a resource.
+func policiesConflict(a, b core.Policy) bool {
+	if targetRefSectionName(a) != targetRefSectionName(b) {
+		return false
+	}
+	alpA, okA := a.(*anv1alpha1.AccessLogPolicy)
+	alpB, okB := b.(*anv1alpha1.AccessLogPolicy)
+	if !okA || !okB {
+		return true
+	}
+	if alpA.GetScope() != alpB.GetScope() {
+		return false
+	}
+	typesA := accessLogDestinationTypes(alpA)
+	for destinationType := range accessLogDestinationTypes(alpB) {
+		if _, ok := typesA[destinationType]; ok {
+			return true
 		}
-		namespaceMatch := retrievedNamespace == refObjNamespacedName.Namespace
-		if groupKindMatch && nameMatch && namespaceMatch {
-			return p.(T), nil
+	}
+	return false
+}
+
+// accessLogDestinationTypes returns the types of the valid destinations of alp
+func accessLogDestinationTypes(alp *anv1alpha1.AccessLogPolicy) map[model.AccessLogDestinationType]struct{} {
+	destinationTypes := make(map[model.AccessLogDestinationType]struct{})
+	for _, destinationArn := range alp.GetDestinationArns() {
+		if destinationType, err := model.GetAccessLogDestinationType(destinationArn); err == nil {
+			destinationTypes[destinationType] = struct{}{}
 		}
 	}
-	return null, nil
+	return destinationTypes
+}
+
+// targetRefSectionName returns the sectionName of the targetRef of policy, "" when it targets a whole object
+func targetRefSectionName(policy core.Policy) string {
+	if tgp, ok := policy.(*anv1alpha1.TargetGroupPolicy); ok && tgp.Spec.TargetRef != nil && tgp.Spec.TargetRef.SectionName != nil {
+		return string(*tgp.Spec.TargetRef.SectionName)
+	}
+	return ""
+}
+
+// IsOlderPolicy tells whether policy a was created before policy b. Policies created at the same time are
+// ordered by namespace and name, so that conflicts between policies always resolve the same way.
+func IsOlderPolicy(a, b core.Policy) bool {
+	aCreated, bCreated := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+	if !aCreated.Equal(&bCreated) {
+		return aCreated.Before(&bCreated)
+	}
+	return a.GetNamespacedName().String() < b.GetNamespacedName().String()
+}
+
+func policyTypeToPolicyList(policyType core.Policy) (core.PolicyList, error) {
+	switch policyType.(type) {
+	case *anv1alpha1.IAMAuthPolicy:
+		return &anv1alpha1.IAMAuthPolicyList{}, nil
+	case *anv1alpha1.AccessLogPolicy:
+		return &anv1alpha1.AccessLogPolicyList{}, nil
+	default:
+		policyList, _, _, err := policyTypeToPolicyListAndTargetRefGroupKind(policyType)
+		return policyList, err
+	}
 }
 
 func policyTypeToPolicyListAndTargetRefGroupKind(policyType core.Policy) (core.PolicyList, gwv1beta1.Group, gwv1beta1.Kind, error) {
@@ -3,6 +3,7 @@ package gateway
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	mock_client "github.com/aws/aws-application-networking-k8s/mocks/controller-runtime/client"
 	anv1alpha1 "github.com/aws/aws-application-networking-k8s/pkg/apis/applicationnetworking/v1alpha1"
@@ -39,11 +41,13 @@ func Test_getAttachedPolicy(t *testing.T) {
 			Namespace: ns,
 		},
 		Spec: anv1alpha1.TargetGroupPolicySpec{
-			TargetRef: &gwv1alpha2.PolicyTargetReference{
-				Group:     corev1.GroupName,
-				Name:      "svc-1",
-				Kind:      "Service",
-				Namespace: &typedNs,
+			TargetRef: &anv1alpha1.PolicyTargetReferenceWithSectionName{
+				PolicyTargetReference: gwv1alpha2.PolicyTargetReference{
+					Group:     corev1.GroupName,
+					Name:      "svc-1",
+					Kind:      "Service",
+					Namespace: &typedNs,
+				},
 			},
 			Protocol:        &prtocol,
 			ProtocolVersion: &protocolVersion,
@@ -57,7 +61,7 @@ func Test_getAttachedPolicy(t *testing.T) {
 	policyTargetRefKindWrong.Spec.TargetRef.Kind = "ServiceImport"
 
 	notRelatedTargetGroupPolicy := targetGroupPolicyHappyPath.DeepCopyObject().(*anv1alpha1.TargetGroupPolicy)
-	notRelatedTargetGroupPolicy.Spec.TargetRef.Name = "another-svc"
+	notRelatedTargetGroupPolicy.Spec.TargetRef.Name = "not-related-svc"
 
 	vpcAssociationPolicyHappyPath := &anv1alpha1.VpcAssociationPolicy{
 		ObjectMeta: metav1.ObjectMeta{
@@ -210,3 +214,45 @@ func Test_getAttachedPolicy(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetConflictingAccessLogPolicy(t *testing.T) {
+	ctx := context.Background()
+	newPolicy := func(name string, age time.Duration, destinationArns ...string) *anv1alpha1.AccessLogPolicy {
+		return &anv1alpha1.AccessLogPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "ns1",
+				CreationTimestamp: metav1.NewTime(time.Unix(10000, 0).Add(-age)),
+			},
+			Spec: anv1alpha1.AccessLogPolicySpec{
+				DestinationArns: destinationArns,
+				TargetRef: &gwv1alpha2.PolicyTargetReference{
+					Group: gwv1beta1.GroupName,
+					Kind:  "Gateway",
+					Name:  "gw",
+				},
+			},
+		}
+	}
+	s3 := newPolicy("s3", time.Hour, "arn:aws:s3:::bucket")
+	logs := newPolicy("logs", time.Minute, "arn:aws:logs:us-west-2:123456789012:log-group:test")
+	otherS3 := newPolicy("other-s3", time.Minute, "arn:aws:s3:::other-bucket", "arn:aws:firehose:us-west-2:123456789012:deliverystream/test")
+	servicesS3 := newPolicy("services-s3", time.Minute, "arn:aws:s3:::services-bucket")
+	servicesScope := anv1alpha1.AccessLogPolicyScopeServices
+	servicesS3.Spec.Scope = &servicesScope
+	k8sClient := newTargetGroupPolicyTestClient(s3, logs, otherS3, servicesS3)
+
+	// destinations of different types do not conflict
+	conflicting, err := GetConflictingPolicy(ctx, k8sClient, logs)
+	assert.NoError(t, err)
+	assert.Nil(t, conflicting)
+
+	conflicting, err = GetConflictingPolicy(ctx, k8sClient, otherS3)
+	assert.NoError(t, err)
+	assert.Equal(t, "s3", conflicting.GetNamespacedName().Name)
+
+	// destinations of different scopes do not conflict
+	conflicting, err = GetConflictingPolicy(ctx, k8sClient, servicesS3)
+	assert.NoError(t, err)
+	assert.Nil(t, conflicting)
+}
@@ -13,6 +13,8 @@ const (
 	RouteEventReasonFailedBuildModel   = "FailedBuildModel"
 	RouteEventReasonFailedDeployModel  = "FailedDeployModel"
 	RouteEventReasonRetryReconcile     = "Retry-Reconcile"
+	RouteEventReasonRefNotPermitted    = "RefNotPermitted"
+	RouteEventReasonInvalidBackendRef  = "InvalidBackendRef"
 
 	// Service events
 	ServiceEventReasonFailedAddFinalizer = "FailedAddFinalizer"
@@ -32,4 +34,17 @@ const (
 	// AccessLogPolicy events
 	AccessLogPolicyEventReasonFailedAddFinalizer = "FailedAddFinalizer"
 	AccessLogPolicyEventReasonFailedBuildModel   = "FailedBuildModel"
+
+	// IAMAuthPolicy events
+	IAMAuthPolicyEventReasonFailedAddFinalizer = "FailedAddFinalizer"
+	IAMAuthPolicyEventReasonFailedDeployModel  = "FailedDeployModel"
+
+	// Target events, recorded on the resources whose targets VPC Lattice failed to register or deregister
+	TargetEventReasonFailedRegister   = "FailedRegisterTarget"
+	TargetEventReasonFailedDeregister = "FailedDeregisterTarget"
+
+	// LatticeRollout events
+	LatticeRolloutEventReasonStep       = "RolloutStep"
+	LatticeRolloutEventReasonRolledBack = "RolledBack"
+	LatticeRolloutEventReasonInvalid    = "Invalid"
 )
@@ -41,13 +41,15 @@ type TargetGroupKey struct {
 }
 
 type TargetGroup struct {
-	TargetGroupKey  TargetGroupKey
-	ARN             string
-	ID              string
-	EndPoints       []Target
-	VpcID           string
-	ByServiceExport bool // triggered by K8S serviceexport object
-	ByBackendRef    bool // triggered by backend ref which points to service
+	TargetGroupKey TargetGroupKey
+	ARN            string
+	ID             string
+	EndPoints      []Target
+	// deregistered targets VPC Lattice still completes in-flight requests of
+	DrainingEndPoints []Target
+	VpcID             string
+	ByServiceExport   bool // triggered by K8S serviceexport object
+	ByBackendRef      bool // triggered by backend ref which points to service
 }
 
 type Target struct {
@@ -120,6 +122,16 @@ func TargetGroupName(name, namespace string) string {
 	)
 }
 
+// LambdaTargetGroupName is the name of the target group of a LambdaFunction. Unlike TargetGroupName it does not start
+// with k8s-, so it is stored apart from the target group of a Service with the same name and namespace
+// worst case - lambda-(50)-(50) (108 chars)
+func LambdaTargetGroupName(name, namespace string) string {
+	return fmt.Sprintf("lambda-%s-%s",
+		utils.Truncate(name, 50),
+		utils.Truncate(namespace, 50),
+	)
+}
+
 // worst case - (70)-(20)-(21)-https-http2 (125 chars)
 func TargetGroupLongName(defaultName, routeName, vpcId string) string {
 	return fmt.Sprintf("%s-%s-%s",
@@ -257,6 +269,9 @@ func (ds *LatticeDataStore) GetTargetGroup(name string, routeName string, isServ
 }
 
 func (ds *LatticeDataStore) GetTargetGroupsByName(name string) []TargetGroup {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
 	tgs := make([]TargetGroup, 0)
 
 	for _, tg := range ds.targetGroups {
@@ -294,6 +309,31 @@ func (ds *LatticeDataStore) UpdateTargetsForTargetGroup(name string, routeName s
 	return nil
 }
 
+func (ds *LatticeDataStore) UpdateDrainingTargetsForTargetGroup(name string, routeName string, targetList []Target) error {
+	ds.lock.Lock()
+	defer ds.lock.Unlock()
+
+	targetGroupKey := TargetGroupKey{
+		Name:            name,
+		RouteName:       routeName,
+		IsServiceImport: false, // only local targets are drained
+	}
+
+	tg, ok := ds.targetGroups[targetGroupKey]
+
+	if !ok {
+		ds.log.Debugf("UpdateDrainingTargetGroup name does NOT exist: %s", name)
+		return errors.New(DATASTORE_TG_NOT_EXIST)
+	}
+
+	tg.DrainingEndPoints = make([]Target, len(targetList))
+	copy(tg.DrainingEndPoints, targetList)
+
+	ds.log.Debugf("Success UpdateDrainingTarget Group name: %s,  drainingTargetIPList: %+v", name, tg.DrainingEndPoints)
+
+	return nil
+}
+
 func (ds *LatticeDataStore) AddListener(name string, namespace string, port int64, protocol string, arn string, id string) error {
 	ds.lock.Lock()
 	defer ds.lock.Unlock()
@@ -34,9 +34,9 @@ func GetGRPCRoute(ctx context.Context, client client.Client, routeNamespacedName
 	return NewGRPCRoute(*grpcRoute), nil
 }
 
-func ListGRPCRoutes(context context.Context, client client.Client) ([]Route, error) {
+func ListGRPCRoutes(context context.Context, client client.Client, opts ...client.ListOption) ([]Route, error) {
 	routeList := &gwv1alpha2.GRPCRouteList{}
-	if err := client.List(context, routeList); err != nil {
+	if err := client.List(context, routeList, opts...); err != nil {
 		return nil, err
 	}
 
@@ -139,17 +139,25 @@ func (s *GRPCRouteStatus) SetParents(parents []gwv1beta1.RouteParentStatus) {
 	s.s.Parents = parents
 }
 
+// UpdateParentRefs adds a status for parent unless there is one already
 func (s *GRPCRouteStatus) UpdateParentRefs(parent gwv1beta1.ParentReference, controllerName gwv1beta1.GatewayController) {
-	if len(s.Parents()) == 0 {
-		s.SetParents(make([]gwv1beta1.RouteParentStatus, 1))
+	i := parentStatusIndex(s.Parents(), parent)
+	if i < 0 {
+		s.SetParents(append(s.Parents(), gwv1beta1.RouteParentStatus{ParentRef: parent}))
+		i = len(s.Parents()) - 1
 	}
 
-	s.Parents()[0].ParentRef = parent
-	s.Parents()[0].ControllerName = controllerName
+	s.Parents()[i].ControllerName = controllerName
 }
 
-func (s *GRPCRouteStatus) UpdateRouteCondition(condition metav1.Condition) {
-	s.Parents()[0].Conditions = utils.GetNewConditions(s.Parents()[0].Conditions, condition)
+// UpdateRouteCondition sets condition on the status of parent, which UpdateParentRefs has to add first
+func (s *GRPCRouteStatus) UpdateRouteCondition(parent gwv1beta1.ParentReference, condition metav1.Condition) {
+	i := parentStatusIndex(s.Parents(), parent)
+	if i < 0 {
+		return
+	}
+
+	s.Parents()[i].Conditions = utils.GetNewConditions(s.Parents()[i].Conditions, condition)
 }
 
 type GRPCRouteRule struct {
@@ -172,6 +180,17 @@ func (r *GRPCRouteRule) Matches() []RouteMatch {
 	return routeMatches
 }
 
+// ExtensionRefs returns the references of the ExtensionRef filters of the rule
+func (r *GRPCRouteRule) ExtensionRefs() []gwv1beta1.LocalObjectReference {
+	var extensionRefs []gwv1beta1.LocalObjectReference
+	for _, filter := range r.r.Filters {
+		if filter.Type == gwv1alpha2.GRPCRouteFilterExtensionRef && filter.ExtensionRef != nil {
+			extensionRefs = append(extensionRefs, *filter.ExtensionRef)
+		}
+	}
+	return extensionRefs
+}
+
 func (r *GRPCRouteRule) Equals(routeRule RouteRule) bool {
 	other, ok := routeRule.(*GRPCRouteRule)
 	if !ok {
@@ -198,6 +217,10 @@ func (r *GRPCRouteRule) Equals(routeRule RouteRule) bool {
 		}
 	}
 
+	if !reflect.DeepEqual(r.ExtensionRefs(), other.ExtensionRefs()) {
+		return false
+	}
+
 	return true
 }
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 )
@@ -296,6 +297,30 @@ func TestHTTPRouteRule_Equals(t *testing.T) {
 			expectEqual: false,
 			description: "Instances with different Match values are not equal",
 		},
+		{
+			routeRule1: &HTTPRouteRule{
+				r: gwv1beta1.HTTPRouteRule{
+					Filters: []gwv1beta1.HTTPRouteFilter{
+						{
+							Type:         gwv1beta1.HTTPRouteFilterExtensionRef,
+							ExtensionRef: &gwv1beta1.LocalObjectReference{Name: "maintenance"},
+						},
+					},
+				},
+			},
+			routeRule2: &HTTPRouteRule{
+				r: gwv1beta1.HTTPRouteRule{
+					Filters: []gwv1beta1.HTTPRouteFilter{
+						{
+							Type:         gwv1beta1.HTTPRouteFilterExtensionRef,
+							ExtensionRef: &gwv1beta1.LocalObjectReference{Name: "blocked"},
+						},
+					},
+				},
+			},
+			expectEqual: false,
+			description: "Instances with different ExtensionRef filters are not equal",
+		},
 		{
 			routeRule1:  &HTTPRouteRule{},
 			routeRule2:  nil,
@@ -803,3 +828,28 @@ func TestHTTPRouteMatch_Equals(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPRouteStatus_UpdateParentRefs(t *testing.T) {
+	gw1 := gwv1beta1.ParentReference{Name: "gw1"}
+	gw2 := gwv1beta1.ParentReference{Name: "gw2"}
+	route := NewHTTPRoute(gwv1beta1.HTTPRoute{})
+
+	route.Status().UpdateParentRefs(gw1, "controller")
+	route.Status().UpdateParentRefs(gw2, "controller")
+	route.Status().UpdateParentRefs(gw1, "controller")
+	assert.Equal(t, 2, len(route.Status().Parents()))
+
+	route.Status().UpdateRouteCondition(gw2, metav1.Condition{
+		Type:   string(gwv1beta1.RouteConditionAccepted),
+		Status: metav1.ConditionFalse,
+	})
+	route.Status().UpdateRouteCondition(gwv1beta1.ParentReference{Name: "unknown"}, metav1.Condition{
+		Type:   string(gwv1beta1.RouteConditionAccepted),
+		Status: metav1.ConditionTrue,
+	})
+
+	assert.Equal(t, gw1, route.Status().Parents()[0].ParentRef)
+	assert.Empty(t, route.Status().Parents()[0].Conditions)
+	assert.Equal(t, gw2, route.Status().Parents()[1].ParentRef)
+	assert.Equal(t, metav1.ConditionFalse, route.Status().Parents()[1].Conditions[0].Status)
+}
@@ -8,6 +8,7 @@ import (
 
 type Policy interface {
 	GetNamespacedName() types.NamespacedName
+	GetCreationTimestamp() apimachineryv1.Time
 	GetTargetRef() *gwv1alpha2.PolicyTargetReference
 	GetStatusConditions() []apimachineryv1.Condition
 	SetStatusConditions(conditions []apimachineryv1.Condition)
@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"fmt"
+	"reflect"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,22 +31,42 @@ func NewRoute(object client.Object) (Route, error) {
 		return NewHTTPRoute(*obj), nil
 	case *gwv1alpha2.GRPCRoute:
 		return NewGRPCRoute(*obj), nil
+	case *gwv1alpha2.TLSRoute:
+		return NewTLSRoute(*obj), nil
 	default:
 		return nil, fmt.Errorf("unexpected route type for object %+v", object)
 	}
 }
 
-func ListAllRoutes(context context.Context, client client.Client) ([]Route, error) {
-	httpRoutes, err := ListHTTPRoutes(context, client)
+// RouteKind is the Gateway API kind of the route, typed objects read by the client do not carry it
+func RouteKind(route Route) gwv1beta1.Kind {
+	switch route.(type) {
+	case *GRPCRoute:
+		return "GRPCRoute"
+	case *TLSRoute:
+		return "TLSRoute"
+	default:
+		return "HTTPRoute"
+	}
+}
+
+func ListAllRoutes(context context.Context, client client.Client, opts ...client.ListOption) ([]Route, error) {
+	httpRoutes, err := ListHTTPRoutes(context, client, opts...)
 	if err != nil {
 		return nil, err
 	}
 
-	grpcRoutes, err := ListGRPCRoutes(context, client)
+	grpcRoutes, err := ListGRPCRoutes(context, client, opts...)
 	if err != nil {
 		return nil, err
 	}
 
+	// TLSRoute is in the experimental channel of the Gateway API, so its CRD may not be installed
+	tlsRoutes, err := ListTLSRoutes(context, client, opts...)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return nil, err
+	}
+
 	var routes []Route
 	for _, route := range httpRoutes {
 		routes = append(routes, route)
@@ -52,6 +74,9 @@ func ListAllRoutes(context context.Context, client client.Client) ([]Route, erro
 	for _, route := range grpcRoutes {
 		routes = append(routes, route)
 	}
+	for _, route := range tlsRoutes {
+		routes = append(routes, route)
+	}
 
 	return routes, nil
 }
@@ -67,12 +92,23 @@ type RouteStatus interface {
 	Parents() []gwv1beta1.RouteParentStatus
 	SetParents(parents []gwv1beta1.RouteParentStatus)
 	UpdateParentRefs(parent gwv1beta1.ParentReference, controllerName gwv1beta1.GatewayController)
-	UpdateRouteCondition(condition metav1.Condition)
+	UpdateRouteCondition(parent gwv1beta1.ParentReference, condition metav1.Condition)
+}
+
+// parentStatusIndex returns the index of the status of parent in parents, -1 if there is none
+func parentStatusIndex(parents []gwv1beta1.RouteParentStatus, parent gwv1beta1.ParentReference) int {
+	for i, parentStatus := range parents {
+		if reflect.DeepEqual(parentStatus.ParentRef, parent) {
+			return i
+		}
+	}
+	return -1
 }
 
 type RouteRule interface {
 	BackendRefs() []BackendRef
 	Matches() []RouteMatch
+	ExtensionRefs() []gwv1beta1.LocalObjectReference
 	Equals(routeRule RouteRule) bool
 }
 
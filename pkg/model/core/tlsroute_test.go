@@ -0,0 +1,120 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/pointer"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+func TestTLSRouteSpec_Equals(t *testing.T) {
+	name1 := gwv1alpha2.ObjectName("name1")
+	name2 := gwv1alpha2.ObjectName("name2")
+
+	tests := []struct {
+		routeSpec1  *TLSRouteSpec
+		routeSpec2  RouteSpec
+		expectEqual bool
+		description string
+	}{
+		{
+			routeSpec1:  &TLSRouteSpec{},
+			routeSpec2:  &TLSRouteSpec{},
+			expectEqual: true,
+			description: "Empty instances are equal",
+		},
+		{
+			routeSpec1: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					CommonRouteSpec: gwv1alpha2.CommonRouteSpec{
+						ParentRefs: []gwv1alpha2.ParentReference{{Name: name1}},
+					},
+					Hostnames: []gwv1alpha2.Hostname{"example.com"},
+					Rules: []gwv1alpha2.TLSRouteRule{
+						{BackendRefs: []gwv1alpha2.BackendRef{{Weight: pointer.Int32(1)}}},
+					},
+				},
+			},
+			routeSpec2: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					CommonRouteSpec: gwv1alpha2.CommonRouteSpec{
+						ParentRefs: []gwv1alpha2.ParentReference{{Name: name1}},
+					},
+					Hostnames: []gwv1alpha2.Hostname{"example.com"},
+					Rules: []gwv1alpha2.TLSRouteRule{
+						{BackendRefs: []gwv1alpha2.BackendRef{{Weight: pointer.Int32(1)}}},
+					},
+				},
+			},
+			expectEqual: true,
+			description: "Instances populated with the same values are equal",
+		},
+		{
+			routeSpec1:  &TLSRouteSpec{},
+			routeSpec2:  &HTTPRouteSpec{},
+			expectEqual: false,
+			description: "Instances of different types are not equal",
+		},
+		{
+			routeSpec1: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					CommonRouteSpec: gwv1alpha2.CommonRouteSpec{
+						ParentRefs: []gwv1alpha2.ParentReference{{Name: name1}},
+					},
+				},
+			},
+			routeSpec2: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					CommonRouteSpec: gwv1alpha2.CommonRouteSpec{
+						ParentRefs: []gwv1alpha2.ParentReference{{Name: name2}},
+					},
+				},
+			},
+			expectEqual: false,
+			description: "Instances with different parentRefs are not equal",
+		},
+		{
+			routeSpec1: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					Hostnames: []gwv1alpha2.Hostname{"example.com"},
+				},
+			},
+			routeSpec2: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					Hostnames: []gwv1alpha2.Hostname{"example.org"},
+				},
+			},
+			expectEqual: false,
+			description: "Instances with different hostnames are not equal",
+		},
+		{
+			routeSpec1: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					Rules: []gwv1alpha2.TLSRouteRule{
+						{BackendRefs: []gwv1alpha2.BackendRef{{Weight: pointer.Int32(1)}}},
+					},
+				},
+			},
+			routeSpec2: &TLSRouteSpec{
+				s: gwv1alpha2.TLSRouteSpec{
+					Rules: []gwv1alpha2.TLSRouteRule{
+						{BackendRefs: []gwv1alpha2.BackendRef{{Weight: pointer.Int32(2)}}},
+					},
+				},
+			},
+			expectEqual: false,
+			description: "Instances with different backendRefs are not equal",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expectEqual, test.routeSpec1.Equals(test.routeSpec2), test.description)
+	}
+}
+
+func TestTLSRouteRule_Matches(t *testing.T) {
+	rule := &TLSRouteRule{}
+	assert.Empty(t, rule.Matches())
+	assert.Empty(t, rule.ExtensionRefs())
+}
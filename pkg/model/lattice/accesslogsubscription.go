@@ -2,7 +2,9 @@ package lattice
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/aws/aws-sdk-go/aws/arn"
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/aws"
@@ -18,6 +20,16 @@ const (
 	ServiceSourceType        SourceType = "Service"
 )
 
+// AccessLogDestinationType is the type of the destination of access logs. VPC Lattice has at most one
+// access log subscription of each destination type for a source.
+type AccessLogDestinationType string
+
+const (
+	S3AccessLogDestinationType             AccessLogDestinationType = "S3"
+	CloudWatchLogsAccessLogDestinationType AccessLogDestinationType = "CloudWatchLogs"
+	FirehoseAccessLogDestinationType       AccessLogDestinationType = "Firehose"
+)
+
 type AccessLogSubscription struct {
 	core.ResourceMeta `json:"-"`
 	Spec              AccessLogSubscriptionSpec    `json:"spec"`
@@ -48,3 +60,34 @@ func NewAccessLogSubscription(
 		Status:       status,
 	}
 }
+
+// GetAccessLogDestinationType returns the type of the access log destination destinationArn, or an error when it
+// is not the ARN of an S3 bucket, a CloudWatch Logs log group or a Firehose delivery stream
+func GetAccessLogDestinationType(destinationArn string) (AccessLogDestinationType, error) {
+	parsed, err := arn.Parse(destinationArn)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination ARN %s, %w", destinationArn, err)
+	}
+	switch parsed.Service {
+	case "s3":
+		// arn:aws:s3:::bucket-name
+		if parsed.Region == "" && parsed.AccountID == "" && parsed.Resource != "" &&
+			!strings.ContainsAny(parsed.Resource, "/:") {
+			return S3AccessLogDestinationType, nil
+		}
+	case "logs":
+		// arn:aws:logs:region:account-id:log-group:log-group-name, optionally followed by :*
+		name, ok := strings.CutPrefix(parsed.Resource, "log-group:")
+		if ok && parsed.Region != "" && parsed.AccountID != "" && strings.TrimSuffix(name, ":*") != "" {
+			return CloudWatchLogsAccessLogDestinationType, nil
+		}
+	case "firehose":
+		// arn:aws:firehose:region:account-id:deliverystream/delivery-stream-name
+		name, ok := strings.CutPrefix(parsed.Resource, "deliverystream/")
+		if ok && parsed.Region != "" && parsed.AccountID != "" && name != "" {
+			return FirehoseAccessLogDestinationType, nil
+		}
+	}
+	return "", fmt.Errorf("destination ARN %s is not the ARN of an S3 bucket, a CloudWatch Logs log group "+
+		"or a Firehose delivery stream", destinationArn)
+}
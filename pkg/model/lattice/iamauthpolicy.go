@@ -0,0 +1,19 @@
+package lattice
+
+type IAMAuthPolicyTargetType string
+
+const (
+	ServiceNetworkIAMAuthPolicyTarget IAMAuthPolicyTargetType = "ServiceNetwork"
+	ServiceIAMAuthPolicyTarget        IAMAuthPolicyTargetType = "Service"
+)
+
+type IAMAuthPolicy struct {
+	Type       IAMAuthPolicyTargetType
+	ResourceId string
+	Policy     string
+}
+
+type IAMAuthPolicyStatus struct {
+	ResourceId string
+	State      string
+}
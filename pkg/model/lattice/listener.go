@@ -4,6 +4,9 @@ import (
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
 )
 
+// VPC Lattice listener protocol for TLS connections which are forwarded to the targets without being terminated
+const ListenerProtocolTLSPassthrough = "TLS_PASSTHROUGH"
+
 type Listener struct {
 	core.ResourceMeta `json:"-"`
 	Spec              ListenerSpec    `json:"spec"`
@@ -18,10 +21,13 @@ type ListenerSpec struct {
 	DefaultAction DefaultAction `json:"defaultaction"`
 }
 
+// DefaultAction is applied to requests no listener rule matches. It forwards to the target groups of a
+// catch-all route rule when the route has one, otherwise it replies with a fixed response.
 type DefaultAction struct {
-	BackendServiceName      string `json:"backendservicename"`
-	BackendServiceNamespace string `json:"backendservicenamespace"`
+	Forward                 *RuleAction `json:"forward,omitempty"`
+	FixedResponseStatusCode int64       `json:"fixedresponsestatuscode,omitempty"`
 }
+
 type ListenerStatus struct {
 	Name        string `json:"name"`
 	Namespace   string `json:"namespace"`
@@ -1,8 +1,15 @@
 package lattice
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+
 	"github.com/aws/aws-sdk-go/service/vpclattice"
 
 	"github.com/aws/aws-application-networking-k8s/pkg/model/core"
@@ -16,6 +23,8 @@ type Rule struct {
 
 const (
 	MAX_NUM_OF_MATCHED_HEADERS = 5
+	// VPC Lattice rule priorities range from 1 to 100
+	MAX_RULE_PRIORITY = 100
 )
 
 type RuleSpec struct {
@@ -30,12 +39,15 @@ type RuleSpec struct {
 	MatchedHeaders     [MAX_NUM_OF_MATCHED_HEADERS]vpclattice.HeaderMatch
 	Method             string     `json:"method"`
 	RuleID             string     `json:"id"`
+	Order              int        `json:"order"` // position within the listener, lower is evaluated first
 	Action             RuleAction `json:"action"`
 	CreateTime         time.Time  `json:"time"`
 }
 
 type RuleAction struct {
 	TargetGroups []*RuleTargetGroup `json:"ruletarget"`
+	// requests are answered with this status code instead of being forwarded to TargetGroups when it is set
+	FixedResponseStatusCode int64 `json:"fixedresponsestatuscode,omitempty"`
 }
 
 type RuleTargetGroup struct {
@@ -43,17 +55,20 @@ type RuleTargetGroup struct {
 	Namespace       string `json:"namespace"`
 	RouteName       string `json:"routename"`
 	IsServiceImport bool   `json:"isServiceImport"`
-	Weight          int64  `json:"weight"`
+	// the target group is the one of the LambdaFunction Name, rather than the one of the Service Name
+	IsLambdaFunction bool  `json:"isLambdaFunction"`
+	Weight           int64 `json:"weight"`
+	// ID of an existing target group the controller does not manage, which is not in the datastore
+	LatticeTargetGroupID string `json:"latticeTargetGroupID,omitempty"`
 }
 
 type RuleStatus struct {
-	RuleARN              string `json:"ARN"`
-	RuleID               string `json:"ID"`
-	Priority             int64  `json:"priority"`
-	ListenerID           string `json:"Listner"`
-	ServiceID            string `json:"Service"`
-	UpdatePriorityNeeded bool   `json:"updatepriorityneeded"`
-	UpdateTGsNeeded      bool   `json:"updateTGneeded"`
+	RuleARN    string `json:"ARN"`
+	RuleID     string `json:"ID"`
+	Priority   int64  `json:"priority"`
+	ListenerID string `json:"Listner"`
+	ServiceID  string `json:"Service"`
+	Name       string `json:"name"`
 }
 
 func NewRule(stack core.Stack, id string, name string, namespace string, port int64,
@@ -75,3 +90,28 @@ func NewRule(stack core.Stack, id string, name string, namespace string, port in
 	stack.AddResource(rule)
 	return rule
 }
+
+// RuleMatchKey returns a stable key for the match of a rule on the given listener.
+// It does not depend on the position of the rule within the route nor on its action,
+// so a rule keeps its key when route rules are reordered or their backends change.
+func RuleMatchKey(port int64, protocol string, spec *RuleSpec) string {
+	var headers []string
+	for i := 0; i < spec.NumOfHeaderMatches; i++ {
+		header := spec.MatchedHeaders[i]
+		var exact, prefix, contains string
+		if header.Match != nil {
+			exact = aws.StringValue(header.Match.Exact)
+			prefix = aws.StringValue(header.Match.Prefix)
+			contains = aws.StringValue(header.Match.Contains)
+		}
+		headers = append(headers, fmt.Sprintf("%q/%t/%q/%q/%q", aws.StringValue(header.Name),
+			aws.BoolValue(header.CaseSensitive), exact, prefix, contains))
+	}
+	// lattice evaluates all header matches of a rule, their order does not matter
+	sort.Strings(headers)
+
+	key := fmt.Sprintf("%d/%s/%t/%t/%q/%q/%s", port, protocol, spec.PathMatchExact, spec.PathMatchPrefix,
+		spec.PathMatchValue, spec.Method, strings.Join(headers, ","))
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:8])
+}
@@ -18,8 +18,10 @@ type ServiceSpec struct {
 	Protocols           []*string `json:"protocols"`
 	ServiceNetworkNames []string  `json:"servicenetworkhname"`
 	CustomerDomainName  string    `json:"customerdomainname"`
-	CustomerCertARN     string    `json:"customercertarn"`
-	IsDeleted           bool
+	// hostnames of the route after the 1st, which only get a DNS record for the service
+	AdditionalDomainNames []string `json:"additionaldomainnames,omitempty"`
+	CustomerCertARN       string   `json:"customercertarn"`
+	IsDeleted             bool
 }
 
 type ServiceStatus struct {
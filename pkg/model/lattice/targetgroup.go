@@ -39,6 +39,10 @@ type TargetGroupConfig struct {
 	IsServiceImport   bool                          `json:"serviceimport"`
 	HealthCheckConfig *vpclattice.HealthCheckConfig `json:"healthCheckConfig"`
 
+	// the target group of a dual-stack Service uses the secondary IP family of the Service,
+	// which is part of the VPC Lattice name of the target group
+	SecondaryIpFamily bool `json:"secondaryipfamily"`
+
 	// the following fields are used for AWS resource tagging
 	IsServiceExport       bool   `json:"serviceexport"`
 	K8SServiceName        string `json:"k8sservice"`
@@ -55,9 +59,16 @@ type TargetGroupStatus struct {
 type TargetGroupType string
 
 const (
-	TargetGroupTypeIP TargetGroupType = "IP"
+	TargetGroupTypeIP     TargetGroupType = "IP"
+	TargetGroupTypeLambda TargetGroupType = "LAMBDA"
+	// target groups of type INSTANCE have the instance IDs of nodes as targets, with the NodePort of a Service
+	TargetGroupTypeInstance TargetGroupType = "INSTANCE"
 )
 
+// TargetGroupProtocolTCP is the protocol of target groups behind TLS passthrough listeners,
+// TCP target groups have no protocol version
+const TargetGroupProtocolTCP = "TCP"
+
 func NewTargetGroup(stack core.Stack, id string, spec TargetGroupSpec) *TargetGroup {
 	tg := &TargetGroup{
 		ResourceMeta: core.NewResourceMeta(stack, "AWS:VPCServiceNetwork::TargetGroup", id),
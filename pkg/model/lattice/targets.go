@@ -15,6 +15,8 @@ type TargetsSpec struct {
 	RouteName     string   `json:"routename"`
 	TargetGroupID string   `json:"targetgroupID"`
 	TargetIPList  []Target `json:"targetIPlist"`
+	// the targets are the function of the LambdaFunction Name, rather than the endpoints of the Service Name
+	IsLambdaFunction bool `json:"isLambdaFunction"`
 }
 
 type Target struct {
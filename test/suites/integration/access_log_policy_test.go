@@ -215,8 +215,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(listALSOutput.Items[0].ResourceId).To(BeEquivalentTo(testServiceNetwork.Id))
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
-			// Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			// Access Log Subscription ARN should be in the Access Log Policy's status
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
 
 			// Access Log Subscription should have default tags and Access Log Policy tag applied
 			expectedTags := testFramework.Cloud.DefaultTagsMergedWith(services.Tags{
@@ -275,8 +275,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(listALSOutput.Items[0].ResourceId).To(BeEquivalentTo(latticeService.Id))
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
-			// Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			// Access Log Subscription ARN should be in the Access Log Policy's status
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
 
 			// Access Log Subscription should have default tags and Access Log Policy tag applied
 			expectedTags := testFramework.Cloud.DefaultTagsMergedWith(services.Tags{
@@ -335,8 +335,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(listALSOutput.Items[0].ResourceId).To(BeEquivalentTo(latticeService.Id))
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
-			// Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			// Access Log Subscription ARN should be in the Access Log Policy's status
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
 
 			// Access Log Subscription should have default tags and Access Log Policy tag applied
 			expectedTags := testFramework.Cloud.DefaultTagsMergedWith(services.Tags{
@@ -639,11 +639,11 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(listALSOutput.Items[0].ResourceId).To(BeEquivalentTo(testServiceNetwork.Id))
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(logGroupArn))
 
-			// Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			// Access Log Subscription ARN should be in the Access Log Policy's status
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
 
-			currentAlsArn = alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]
-			originalAlsArn = alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]
+			currentAlsArn = accessLogSubscriptionArn(alp)
+			originalAlsArn = accessLogSubscriptionArn(alp)
 		}).Should(Succeed())
 
 		// Update to different destination of same type
@@ -680,8 +680,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(logGroup2Arn))
 
 			// Access Log Subscription ARN should be unchanged
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(originalAlsArn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(originalAlsArn))
 
 			// Access Log Subscription should have default tags and Access Log Policy tag applied
 			expectedTags := testFramework.Cloud.DefaultTagsMergedWith(services.Tags{
@@ -725,9 +725,9 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
 			// New Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).ToNot(BeEquivalentTo(originalAlsArn))
-			currentAlsArn = alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			g.Expect(accessLogSubscriptionArn(alp)).ToNot(BeEquivalentTo(originalAlsArn))
+			currentAlsArn = accessLogSubscriptionArn(alp)
 
 			// New Access Log Subscription should have default tags and Access Log Policy tag applied
 			expectedTags := testFramework.Cloud.DefaultTagsMergedWith(services.Tags{
@@ -784,9 +784,9 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(listALSForSvcOutput.Items[0].ResourceId).To(BeEquivalentTo(latticeService.Id))
 
 			// New Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSForSvcOutput.Items[0].Arn))
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).ToNot(BeEquivalentTo(originalAlsArn))
-			currentAlsArn = alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSForSvcOutput.Items[0].Arn))
+			g.Expect(accessLogSubscriptionArn(alp)).ToNot(BeEquivalentTo(originalAlsArn))
+			currentAlsArn = accessLogSubscriptionArn(alp)
 
 			// New Access Log Subscription should have default tags and Access Log Policy tag applied
 			expectedTags := testFramework.Cloud.DefaultTagsMergedWith(services.Tags{
@@ -830,8 +830,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
 			// Same Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(currentAlsArn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(currentAlsArn))
 		}).Should(Succeed())
 
 		// Update to targetRef that does not exist
@@ -870,8 +870,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
 			// Same Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(currentAlsArn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(currentAlsArn))
 		}).Should(Succeed())
 
 		// Update to targetRef with wrong namespace
@@ -905,8 +905,8 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(*listALSOutput.Items[0].DestinationArn).To(BeEquivalentTo(bucketArn))
 
 			// Same Access Log Subscription ARN should be in the Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(currentAlsArn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(*listALSOutput.Items[0].Arn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(currentAlsArn))
 		}).Should(Succeed())
 
 		// Create second Access Log Policy for original destination
@@ -979,7 +979,7 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 			g.Expect(len(listALSOutput.Items)).To(BeEquivalentTo(2))
 
 			// Same Access Log Subscription ARN should be in the first Access Log Policy's annotations
-			g.Expect(alp.Annotations[anv1alpha1.AccessLogSubscriptionAnnotationKey]).To(BeEquivalentTo(currentAlsArn))
+			g.Expect(accessLogSubscriptionArn(alp)).To(BeEquivalentTo(currentAlsArn))
 		}).Should(Succeed())
 	})
 
@@ -1219,3 +1219,11 @@ var _ = Describe("Access Log Policy", Ordered, func() {
 		Expect(err).To(BeNil())
 	})
 })
+
+// accessLogSubscriptionArn returns the ARN of the Access Log Subscription of a policy with a single destination
+func accessLogSubscriptionArn(alp *anv1alpha1.AccessLogPolicy) string {
+	if len(alp.Status.Destinations) != 1 {
+		return ""
+	}
+	return alp.Status.Destinations[0].AccessLogSubscriptionArn
+}